@@ -0,0 +1,16 @@
+package common
+
+import xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+// BodyFrom references the key of a ConfigMap or a Secret holding the body of a request, sent as is.
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef and secretKeyRef must be set"
+type BodyFrom struct {
+	// ConfigMapKeyRef references the key of a ConfigMap holding the body.
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeyRef `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef references the key of a Secret holding the body. The body is masked with a secret
+	// placeholder in the status.
+	// +optional
+	SecretKeyRef *xpv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
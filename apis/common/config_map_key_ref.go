@@ -0,0 +1,13 @@
+package common
+
+// ConfigMapKeyRef references a key of a Kubernetes ConfigMap.
+type ConfigMapKeyRef struct {
+	// Name is the name of the Kubernetes ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Kubernetes ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Key is the key within the Kubernetes ConfigMap.
+	Key string `json:"key"`
+}
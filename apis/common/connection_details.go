@@ -0,0 +1,12 @@
+package common
+
+// ConnectionDetail specifies a connection detail published from the response, in the connection secret
+// of writeConnectionSecretToRef.
+type ConnectionDetail struct {
+	// Key is the key of the connection detail in the connection secret.
+	Key string `json:"key"`
+
+	// ResponseJQ is a jq filter expression extracting the value of the connection detail from the
+	// response, e.g. .body.endpoint.
+	ResponseJQ string `json:"responseJQ"`
+}
@@ -0,0 +1,19 @@
+package common
+
+// CorrelationHeaders specifies the headers carrying the details of the reconcile sending the requests, so
+// that the requests can be correlated upstream. Headers set by the mappings take precedence.
+type CorrelationHeaders struct {
+	// Timestamp is the name of the header set to the time of the reconcile, in RFC 3339 format,
+	// e.g. X-Reconcile-Timestamp.
+	// +optional
+	Timestamp string `json:"timestamp,omitempty"`
+
+	// Attempt is the name of the header set to the attempt number, e.g. X-Reconcile-Attempt: one more
+	// than the failed attempts recorded in status.failed.
+	// +optional
+	Attempt string `json:"attempt,omitempty"`
+
+	// Generation is the name of the header set to the generation of the resource, e.g. X-Resource-Generation.
+	// +optional
+	Generation string `json:"generation,omitempty"`
+}
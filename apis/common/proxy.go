@@ -0,0 +1,13 @@
+package common
+
+// ProxyConfig specifies the proxy the requests are sent through.
+type ProxyConfig struct {
+	// URL is the URL of the proxy, e.g. http://proxy.internal:3128. Credentials can be set in the
+	// user information of the URL.
+	URL string `json:"url"`
+
+	// NoProxy lists the hosts reached without the proxy: host names, domain suffixes like .internal,
+	// IP addresses and CIDR ranges like 10.0.0.0/8, optionally with a port.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
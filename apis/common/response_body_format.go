@@ -0,0 +1,12 @@
+package common
+
+const (
+	// ResponseBodyFormatJSON exposes a JSON response body as an object, any other body as a string.
+	ResponseBodyFormatJSON = "json"
+	// ResponseBodyFormatXML parses the response body as XML.
+	ResponseBodyFormatXML = "xml"
+	// ResponseBodyFormatYAML parses the response body as YAML.
+	ResponseBodyFormatYAML = "yaml"
+	// ResponseBodyFormatRaw exposes the response body as a string, without parsing it.
+	ResponseBodyFormatRaw = "raw"
+)
@@ -9,6 +9,34 @@ type SecretRef struct {
 	Namespace string `json:"namespace"`
 }
 
+const (
+	// ExtractionEngineJQ extracts the values injected into secrets with jq filters.
+	ExtractionEngineJQ = "jq"
+	// ExtractionEngineJSONPath extracts the values injected into secrets with JSONPath expressions.
+	ExtractionEngineJSONPath = "jsonpath"
+)
+
+const (
+	// MissingFieldStrategySetEmpty sets the secret keys of the fields missing from the response to an empty string.
+	MissingFieldStrategySetEmpty = "setEmpty"
+	// MissingFieldStrategyPreserve keeps the current values of the secret keys of the fields missing from the response.
+	MissingFieldStrategyPreserve = "preserve"
+	// MissingFieldStrategyFail fails the injection, without updating any key of the secret, when a field is missing
+	// from the response.
+	MissingFieldStrategyFail = "fail"
+)
+
+const (
+	// EncodingNone stores the values in secrets as they are extracted from the response.
+	EncodingNone = "none"
+	// EncodingBase64 is the standard base64 encoding, with padding.
+	EncodingBase64 = "base64"
+	// EncodingBase64URL is the URL and file name safe base64 encoding, with padding.
+	EncodingBase64URL = "base64url"
+	// EncodingHex is the hexadecimal encoding.
+	EncodingHex = "hex"
+)
+
 // SecretInjectionConfig represents the configuration for injecting secret data into a Kubernetes secret.
 type SecretInjectionConfig struct {
 	// SecretRef contains the name and namespace of the Kubernetes secret where the data will be injected.
@@ -23,13 +51,29 @@ type SecretInjectionConfig struct {
 	ResponsePath string `json:"responsePath,omitempty"`
 
 	// KeyMappings allows injecting data into single or multiple keys within the same Kubernetes secret.
+	// All the keys are updated together, in a single update of the secret.
 	KeyMappings []KeyInjection `json:"keyMappings,omitempty"`
 
+	// MissingFieldStrategy specifies how the fields missing from the response, or extracted as an empty
+	// string, are injected: setEmpty, the default, sets their keys to an empty string, preserve keeps the
+	// current values of their keys, and fail fails the injection without updating any key of the secret.
+	// +kubebuilder:validation:Enum=setEmpty;preserve;fail
+	MissingFieldStrategy string `json:"missingFieldStrategy,omitempty"`
+
 	// Metadata contains labels and annotations to apply to the Kubernetes secret.
 	Metadata Metadata `json:"metadata,omitempty"`
 
 	// SetOwnerReference determines whether to set the owner reference on the Kubernetes secret.
 	SetOwnerReference bool `json:"setOwnerReference,omitempty"`
+
+	// Engine is the language of the responseJQ and responsePath expressions and of the label and
+	// annotation values extracted from the response: jq, the default, or jsonpath, e.g. $.body.token.
+	// +kubebuilder:validation:Enum=jq;jsonpath
+	Engine string `json:"engine,omitempty"`
+
+	// DiscriminatorValue restricts the config to the responses for which the secretInjectionDiscriminator
+	// of the resource returns this value. Configs without a value are applied to every response.
+	DiscriminatorValue string `json:"discriminatorValue,omitempty"`
 }
 
 // KeyInjection represents the configuration for injecting data into a specific key in a Kubernetes secret.
@@ -39,6 +83,15 @@ type KeyInjection struct {
 
 	// ResponseJQ is a jq filter expression representing the path in the response where the secret value will be extracted from.
 	ResponseJQ string `json:"responseJQ"`
+
+	// Encoding is the encoding applied to the value before it is stored in the secret: none, the default,
+	// base64, base64url or hex.
+	// +kubebuilder:validation:Enum=none;base64;base64url;hex
+	Encoding string `json:"encoding,omitempty"`
+
+	// Decode decodes the value from the encoding instead, for responses returning encoded values that
+	// must be stored as is.
+	Decode bool `json:"decode,omitempty"`
 }
 
 // Metadata contains labels and annotations to apply to a Kubernetes secret.
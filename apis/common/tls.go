@@ -0,0 +1,33 @@
+package common
+
+// TLSConfig specifies the TLS settings of the connections to the servers.
+type TLSConfig struct {
+	// MinVersion is the minimum TLS version of the connections, 1.2 or 1.3. Defaults to 1.2.
+	// +kubebuilder:validation:Enum="1.2";"1.3"
+	// +optional
+	MinVersion string `json:"minVersion,omitempty"`
+
+	// CipherSuites restricts the cipher suites of the TLS 1.2 connections, e.g.
+	// TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Only the secure cipher suites of Go are supported, and
+	// the cipher suites of TLS 1.3 can't be configured. Defaults to all the secure cipher suites.
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+
+	// ServerName overrides the TLS server name (SNI) of the connections, against which the certificate
+	// of the server is also verified, e.g. when the URL host is an IP address in front of services with
+	// a shared certificate. Takes precedence over the serverName of the routing profile.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// ClientCertificateSecretRef references a kubernetes.io/tls secret whose tls.crt and tls.key are
+	// presented as the client certificate of mutual TLS connections. The secret is read on every TLS
+	// handshake, so that a rotated certificate is used by the next connection.
+	// +optional
+	ClientCertificateSecretRef *SecretRef `json:"clientCertificateSecretRef,omitempty"`
+
+	// CABundleSecretRef references a secret whose ca.crt holds the PEM encoded certificate authorities
+	// the certificates of the servers are verified against, instead of the system roots, e.g. for servers
+	// with certificates issued by a private certificate authority. The secret is read when connecting.
+	// +optional
+	CABundleSecretRef *SecretRef `json:"caBundleSecretRef,omitempty"`
+}
@@ -0,0 +1,8 @@
+package common
+
+const (
+	// UnresolvedSecretPolicyFail fails when a secret placeholder references a missing secret or key.
+	UnresolvedSecretPolicyFail = "fail"
+	// UnresolvedSecretPolicyLeaveUnresolved keeps the placeholders referencing a missing secret or key as is.
+	UnresolvedSecretPolicyLeaveUnresolved = "leaveUnresolved"
+)
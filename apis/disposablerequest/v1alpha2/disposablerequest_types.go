@@ -37,28 +37,133 @@ type DisposableRequestParameters struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'forProvider.body' is immutable"
 	Body string `json:"body,omitempty"`
 
-	// WaitTimeout specifies the maximum time duration for waiting.
+	// BodyFrom references the key of a ConfigMap or a Secret holding the body of the request, e.g. for
+	// large payloads. The inline body takes precedence.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'forProvider.bodyFrom' is immutable"
+	// +optional
+	BodyFrom *common.BodyFrom `json:"bodyFrom,omitempty"`
+
+	// WaitTimeout specifies the maximum time duration for waiting. Unset or zero means the provider
+	// default of 5 minutes is used.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="waitTimeout must not be negative"
 	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
 
 	// RollbackRetriesLimit is max number of attempts to retry HTTP request by sending again the request.
 	RollbackRetriesLimit *int32 `json:"rollbackRetriesLimit,omitempty"`
 
+	// RetryBackoff spaces the retries of the failed requests exponentially, instead of retrying them
+	// on every poll. It only applies when rollbackRetriesLimit is set.
+	RetryBackoff *RetryBackoff `json:"retryBackoff,omitempty"`
+
 	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP request
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 
+	// TLSRenegotiation controls whether the server may request TLS renegotiation, which some legacy
+	// servers require. Defaults to never.
+	// +kubebuilder:validation:Enum=never;onceAsClient;freelyAsClient
+	TLSRenegotiation string `json:"tlsRenegotiation,omitempty"`
+
+	// RoutingProfile is the name of the routing profile of the ProviderConfig the requests are sent
+	// through, e.g. to reach a logical service name behind a shared gateway.
+	RoutingProfile string `json:"routingProfile,omitempty"`
+
+	// Proxy overrides the proxy of the ProviderConfig the requests are sent through.
+	Proxy *common.ProxyConfig `json:"proxy,omitempty"`
+
+	// TLS overrides the TLS settings of the ProviderConfig, e.g. to restrict the cipher suites of a
+	// given endpoint. The settings it leaves unset are the ones of the ProviderConfig.
+	TLS *common.TLSConfig `json:"tls,omitempty"`
+
+	// CorrelationHeaders sets headers carrying the time of the reconcile, the attempt number and the
+	// generation of the resource on the requests, for their correlation upstream.
+	CorrelationHeaders *common.CorrelationHeaders `json:"correlationHeaders,omitempty"`
+
+	// MaxResponseBodyBytes overrides the maximum size of the response bodies of the ProviderConfig,
+	// the requests whose response body is larger fail. 0 means no limit.
+	// +kubebuilder:validation:Minimum=0
+	MaxResponseBodyBytes *int64 `json:"maxResponseBodyBytes,omitempty"`
+
+	// MaxStatusBodyBytes caps the size of the response body stored in status.response.body, which is
+	// truncated with a marker beyond it. The whole body is still used during the reconcile, e.g. to
+	// inject secrets. Defaults to 0, which stores the whole body.
+	// +kubebuilder:validation:Minimum=0
+	MaxStatusBodyBytes int `json:"maxStatusBodyBytes,omitempty"`
+
+	// HistoryLimit is the number of the last responses kept in status.history, e.g. to debug a flaky
+	// webhook. Their bodies are truncated to 1KiB. 0, the default, keeps no history.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HistoryLimit int32 `json:"historyLimit,omitempty"`
+
 	// ExpectedResponse is a jq filter expression used to evaluate the HTTP response and determine if it matches the expected criteria.
 	// The expression should return a boolean; if true, the response is considered expected.
 	// Example: '.body.job_status == "success"'
 	ExpectedResponse string `json:"expectedResponse,omitempty"`
 
+	// ResponseBodyFormat specifies how the response body is exposed to the jq filters. json, the default,
+	// exposes a JSON body as an object, and when set explicitly also parses the bodies whose root is an
+	// array or a scalar in the checks. xml and yaml parse the body into an object, and raw exposes the
+	// body as a string without parsing it.
+	// +kubebuilder:validation:Enum=json;xml;yaml;raw
+	ResponseBodyFormat string `json:"responseBodyFormat,omitempty"`
+
+	// UnresolvedSecretPolicy specifies how the {{name:namespace:key}} secret placeholders referencing a
+	// missing secret or key are handled. fail, the default, fails the reconcile with an error naming the
+	// missing secret or key, and leaveUnresolved keeps the placeholders as is.
+	// +kubebuilder:validation:Enum=fail;leaveUnresolved
+	UnresolvedSecretPolicy string `json:"unresolvedSecretPolicy,omitempty"`
+
 	// NextReconcile specifies the duration after which the next reconcile should occur.
 	NextReconcile *metav1.Duration `json:"nextReconcile,omitempty"`
 
 	// ShouldLoopInfinitely specifies whether the reconciliation should loop indefinitely.
 	ShouldLoopInfinitely bool `json:"shouldLoopInfinitely,omitempty"`
 
+	// LoopUntil is a jq filter expression evaluated on the response of each request of an infinite loop.
+	// When it returns true, the loop stops and the resource is no longer sent again.
+	// Example: '.body.status == "complete"'
+	// +optional
+	LoopUntil string `json:"loopUntil,omitempty"`
+
+	// Schedule is a cron expression (minute, hour, day of month, month and day of week, in UTC), e.g.
+	// "0 */6 * * *", sending the request again at every fire time after the last one was sent. It takes
+	// precedence over nextReconcile and shouldLoopInfinitely.
+	// +kubebuilder:validation:Pattern=`^(@\w+|\S+(\s+\S+){4})$`
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
 	// SecretInjectionConfig specifies the secrets receiving patches from response data.
 	SecretInjectionConfigs []common.SecretInjectionConfig `json:"secretInjectionConfigs,omitempty"`
+
+	// ConnectionDetails are the fields of the last response published as connection details, in the
+	// connection secret of writeConnectionSecretToRef, so that e.g. compositions can consume them.
+	// +optional
+	ConnectionDetails []common.ConnectionDetail `json:"connectionDetails,omitempty"`
+
+	// AtomicSecretInjection, when set to true, applies the SecretInjectionConfigs all or nothing: when one of them
+	// fails, the secrets already patched from the same response are rolled back.
+	AtomicSecretInjection bool `json:"atomicSecretInjection,omitempty"`
+
+	// SecretInjectionDiscriminator is a jq filter evaluated against the response, e.g.
+	// if .body.error then "error" else "success" end, selecting the SecretInjectionConfigs whose
+	// discriminatorValue matches its result, for endpoints returning differently shaped responses.
+	SecretInjectionDiscriminator string `json:"secretInjectionDiscriminator,omitempty"`
+}
+
+// RetryBackoff computes the interval before the retry of a request that failed a number of times
+// as min(base * factor^failed, max).
+type RetryBackoff struct {
+	// Base is the interval multiplied by the factor once per failure.
+	Base metav1.Duration `json:"base"`
+
+	// Factor multiplies the interval after every failure. Defaults to 2.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Factor *int32 `json:"factor,omitempty"`
+
+	// Max caps the interval before a retry.
+	Max metav1.Duration `json:"max"`
 }
 
 // A DisposableRequestSpec defines the desired state of a DisposableRequest.
@@ -71,6 +176,27 @@ type Response struct {
 	StatusCode int                 `json:"statusCode,omitempty"`
 	Body       string              `json:"body,omitempty"`
 	Headers    map[string][]string `json:"headers,omitempty"`
+
+	// DurationMs is how long the request took, in milliseconds, until its response body was read.
+	DurationMs int64 `json:"durationMs,omitempty"`
+
+	// Proto is the HTTP protocol version of the response, e.g. HTTP/1.1 or HTTP/2.0.
+	Proto string `json:"proto,omitempty"`
+}
+
+// HistoryEntry is a response of the request history of a DisposableRequest.
+type HistoryEntry struct {
+	// StatusCode is the status code of the response, 0 when the request failed without response.
+	StatusCode int `json:"statusCode,omitempty"`
+
+	// Timestamp is the time the response was recorded.
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// Body is the response body, truncated to 1KiB, with the values injected in secrets masked.
+	Body string `json:"body,omitempty"`
+
+	// Error is the error of the request, if any.
+	Error string `json:"error,omitempty"`
 }
 
 type Mapping struct {
@@ -91,6 +217,14 @@ type DisposableRequestStatus struct {
 
 	// LastReconcileTime records the last time the resource was reconciled.
 	LastReconcileTime metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// LoopCompleted is true once the response of an infinite loop matched its loopUntil expression.
+	// +optional
+	LoopCompleted bool `json:"loopCompleted,omitempty"`
+
+	// History holds the last responses, up to historyLimit of them, the oldest first.
+	// +optional
+	History []HistoryEntry `json:"history,omitempty"`
 }
 
 // +kubebuilder:object:root=true
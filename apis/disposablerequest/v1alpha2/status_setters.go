@@ -18,6 +18,14 @@ func (d *DisposableRequest) SetBody(body string) {
 	d.Status.Response.Body = body
 }
 
+func (d *DisposableRequest) SetDurationMs(durationMs int64) {
+	d.Status.Response.DurationMs = durationMs
+}
+
+func (d *DisposableRequest) SetProto(proto string) {
+	d.Status.Response.Proto = proto
+}
+
 func (d *DisposableRequest) SetSynced(synced bool) {
 	d.Status.Synced = synced
 	d.Status.Failed = 0
@@ -104,6 +104,11 @@ func (in *DisposableRequestParameters) DeepCopyInto(out *DisposableRequestParame
 			(*out)[key] = outVal
 		}
 	}
+	if in.BodyFrom != nil {
+		in, out := &in.BodyFrom, &out.BodyFrom
+		*out = new(common.BodyFrom)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.WaitTimeout != nil {
 		in, out := &in.WaitTimeout, &out.WaitTimeout
 		*out = new(v1.Duration)
@@ -114,6 +119,31 @@ func (in *DisposableRequestParameters) DeepCopyInto(out *DisposableRequestParame
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(RetryBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(common.ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(common.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CorrelationHeaders != nil {
+		in, out := &in.CorrelationHeaders, &out.CorrelationHeaders
+		*out = new(common.CorrelationHeaders)
+		**out = **in
+	}
+	if in.MaxResponseBodyBytes != nil {
+		in, out := &in.MaxResponseBodyBytes, &out.MaxResponseBodyBytes
+		*out = new(int64)
+		**out = **in
+	}
 	if in.NextReconcile != nil {
 		in, out := &in.NextReconcile, &out.NextReconcile
 		*out = new(v1.Duration)
@@ -126,6 +156,11 @@ func (in *DisposableRequestParameters) DeepCopyInto(out *DisposableRequestParame
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ConnectionDetails != nil {
+		in, out := &in.ConnectionDetails, &out.ConnectionDetails
+		*out = make([]common.ConnectionDetail, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisposableRequestParameters.
@@ -162,6 +197,13 @@ func (in *DisposableRequestStatus) DeepCopyInto(out *DisposableRequestStatus) {
 	in.Response.DeepCopyInto(&out.Response)
 	in.RequestDetails.DeepCopyInto(&out.RequestDetails)
 	in.LastReconcileTime.DeepCopyInto(&out.LastReconcileTime)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]HistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisposableRequestStatus.
@@ -174,6 +216,22 @@ func (in *DisposableRequestStatus) DeepCopy() *DisposableRequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HistoryEntry) DeepCopyInto(out *HistoryEntry) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HistoryEntry.
+func (in *HistoryEntry) DeepCopy() *HistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(HistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Mapping) DeepCopyInto(out *Mapping) {
 	*out = *in
@@ -235,3 +293,25 @@ func (in *Response) DeepCopy() *Response {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryBackoff) DeepCopyInto(out *RetryBackoff) {
+	*out = *in
+	out.Base = in.Base
+	if in.Factor != nil {
+		in, out := &in.Factor, &out.Factor
+		*out = new(int32)
+		**out = **in
+	}
+	out.Max = in.Max
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryBackoff.
+func (in *RetryBackoff) DeepCopy() *RetryBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
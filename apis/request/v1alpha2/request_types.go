@@ -31,6 +31,12 @@ const (
 	ExpectedResponseCheckTypeCustom  = "CUSTOM"
 )
 
+const (
+	OnCheckErrorFail          = "fail"
+	OnCheckErrorNotSynced     = "notSynced"
+	OnCheckErrorFallbackLogic = "fallbackLogic"
+)
+
 const (
 	ActionCreate  = "CREATE"
 	ActionObserve = "OBSERVE"
@@ -38,33 +44,272 @@ const (
 	ActionRemove  = "REMOVE"
 )
 
+const (
+	BodyEncodingJSON       = "json"
+	BodyEncodingNDJSON     = "ndjson"
+	BodyEncodingFormData   = "formData"
+	BodyEncodingURLEncoded = "urlencoded"
+)
+
+const (
+	ErrorCategoryRetryable = "retryable"
+	ErrorCategoryTerminal  = "terminal"
+	ErrorCategoryNotFound  = "notFound"
+	ErrorCategoryConflict  = "conflict"
+)
+
+const (
+	PatchStrategyJSONMerge = "jsonMerge"
+	PatchStrategyJSONPatch = "jsonPatch"
+)
+
+const (
+	ChecksumAlgorithmMD5    = "md5"
+	ChecksumAlgorithmSHA256 = "sha256"
+
+	ChecksumEncodingHex    = "hex"
+	ChecksumEncodingBase64 = "base64"
+)
+
 // RequestParameters are the configurable fields of a Request.
+// +kubebuilder:validation:XValidation:rule="has(self.mappings) || (has(self.forEach) && has(self.mappingTemplate))",message="either mappings or forEach and mappingTemplate must be set"
+// +kubebuilder:validation:XValidation:rule="!(has(self.forEach) && has(self.payload.items))",message="forEach and payload.items are mutually exclusive"
 type RequestParameters struct {
 	// Mappings defines the HTTP mappings for different methods.
 	// Either Method or Action must be specified. If both are omitted, the mapping will not be used.
 	// +kubebuilder:validation:MinItems=1
+	// +optional
 	Mappings []Mapping `json:"mappings"`
 
+	// ForEach is a list of JSON values, e.g. {"id": "team-a"}, for each of which the Request manages an
+	// object with its own set of mappings, rendered from the mappingTemplate. Values that are not JSON
+	// are used as strings. The Request is up to date only when all the objects are.
+	// +optional
+	ForEach []string `json:"forEach,omitempty"`
+
+	// MappingTemplate is the set of mappings sent for each value of forEach. Their jq filters reference
+	// the value as .each, e.g. .each.id, and its index in forEach as .index. In the headers, which are
+	// not jq filters, $(each) is replaced with the value, $(each.<field>) with one of its fields and
+	// $(index) with its index.
+	// +optional
+	MappingTemplate []Mapping `json:"mappingTemplate,omitempty"`
+
+	// ForEachItem is the forEach value managed by a copy of the Request sending the mappingTemplate for
+	// it. It is only set in memory, and is exposed to the jq filters of the mappings as .each and .index.
+	ForEachItem *ForEachItem `json:"-"`
+
+	// ItemKey is a jq filter evaluated on each payload item, or forEach value, returning its identity, e.g.
+	// .username. The status of an item follows its identity when the items are reordered or changed, and
+	// the object of an item whose identity is no longer listed is removed. The index of an item is its
+	// identity by default, so changing an item updates its object, while reordering the items updates the
+	// objects to their new item.
+	// +optional
+	ItemKey string `json:"itemKey,omitempty"`
+
 	// Payload defines the payload for the request.
 	Payload Payload `json:"payload"`
 
+	// ResourceRefs are other resources of the cluster, e.g. the managed resources of the same composition,
+	// exposed to the mappings as .resources.<name> with their metadata, spec and status. The provider
+	// needs the RBAC permissions to get them. Secrets can't be referenced, use secret placeholders instead.
+	// +listType=map
+	// +listMapKey=name
+	ResourceRefs []ResourceRef `json:"resourceRefs,omitempty"`
+
 	// Headers defines default headers for each request.
 	Headers map[string][]string `json:"headers,omitempty"`
 
-	// WaitTimeout specifies the maximum time duration for waiting.
+	// ResponseBodyTemplate is a Go text/template rendering status.message from the response, as an alternative
+	// to jq for human-friendly status messages. The template receives .statusCode, .headers and .body, parsed
+	// when it is JSON, e.g. {{ .body.name }} is {{ .body.state | lower }}.
+	ResponseBodyTemplate string `json:"responseBodyTemplate,omitempty"`
+
+	// UpdateConsideredSyncedOn are the status codes of an UPDATE response, e.g. 204 for fire-and-forget PUT
+	// endpoints, that mark the Request as synced without drift comparison until its spec changes.
+	// +kubebuilder:validation:items:Minimum=100
+	// +kubebuilder:validation:items:Maximum=599
+	UpdateConsideredSyncedOn []int `json:"updateConsideredSyncedOn,omitempty"`
+
+	// PreserveRawBody, when set to true, exposes the response body verbatim as .response.rawBody
+	// alongside the parsed .response.body in the mappings and checks, and as .rawBody in the secret
+	// injection configs and connection details.
+	PreserveRawBody bool `json:"preserveRawBody,omitempty"`
+
+	// CompactBody, when set to true, removes the insignificant whitespace of the rendered JSON bodies
+	// before sending them, for APIs rejecting pretty-printed JSON. Bodies that are not JSON are sent as is.
+	CompactBody bool `json:"compactBody,omitempty"`
+
+	// DisableHTMLEscaping, when set to true, sends the characters <, > and & of the rendered JSON bodies
+	// as is, instead of the \u003c, \u003e and \u0026 escapes of the default JSON serialization, for APIs
+	// expecting raw characters. Bodies that are not JSON are sent as is.
+	DisableHTMLEscaping bool `json:"disableHTMLEscaping,omitempty"`
+
+	// HeadersTransform is a jq program applied to the evaluated headers of every request. It receives
+	// the request object with .headers set to the evaluated headers and returns the headers to send,
+	// e.g. (.headers | del(.["X-Debug"])) + {"X-Tenant": [.payload.body.tenant]}.
+	HeadersTransform string `json:"headersTransform,omitempty"`
+
+	// WaitTimeout specifies the maximum time duration for waiting. Unset or zero means the provider
+	// default of 5 minutes is used.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="waitTimeout must not be negative"
 	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
 
 	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP request
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 
+	// TLSRenegotiation controls whether the server may request TLS renegotiation, which some legacy
+	// servers require. Defaults to never.
+	// +kubebuilder:validation:Enum=never;onceAsClient;freelyAsClient
+	TLSRenegotiation string `json:"tlsRenegotiation,omitempty"`
+
+	// UseCookieJar, when set to true, keeps cookies set by responses and sends them on the
+	// subsequent requests of the same reconcile, for APIs relying on session cookies.
+	UseCookieJar bool `json:"useCookieJar,omitempty"`
+
+	// RoutingProfile is the name of the routing profile of the ProviderConfig the requests are sent
+	// through, e.g. to reach a logical service name behind a shared gateway.
+	RoutingProfile string `json:"routingProfile,omitempty"`
+
+	// Proxy overrides the proxy of the ProviderConfig the requests are sent through.
+	Proxy *common.ProxyConfig `json:"proxy,omitempty"`
+
+	// TLS overrides the TLS settings of the ProviderConfig, e.g. to restrict the cipher suites of a
+	// given endpoint. The settings it leaves unset are the ones of the ProviderConfig.
+	TLS *common.TLSConfig `json:"tls,omitempty"`
+
+	// CorrelationHeaders sets headers carrying the time of the reconcile, the attempt number and the
+	// generation of the resource on the requests, for their correlation upstream.
+	CorrelationHeaders *common.CorrelationHeaders `json:"correlationHeaders,omitempty"`
+
+	// MaxResponseBodyBytes overrides the maximum size of the response bodies of the ProviderConfig,
+	// the requests whose response body is larger fail. 0 means no limit.
+	// +kubebuilder:validation:Minimum=0
+	MaxResponseBodyBytes *int64 `json:"maxResponseBodyBytes,omitempty"`
+
+	// ServerDryRun is a query parameter (e.g. dryRun=All) appended to the CREATE request so that
+	// the server only validates it. A successful validation doesn't mark the resource as created,
+	// keeping it pending, and is recorded in status.serverDryRunValidated so that it isn't sent
+	// again until the spec changes.
+	ServerDryRun string `json:"serverDryRun,omitempty"`
+
+	// CreateSafeguard guards against creating duplicates when the status of the Request is lost, e.g.
+	// after a restore from a backup without status: before CREATE, the object is looked up with the
+	// OBSERVE mapping at a URL derived from the spec only, and CREATE is skipped when it is found. CREATE
+	// is only sent when the response shows that the object is absent, see resourceAbsentStatusCodes.
+	// +optional
+	CreateSafeguard *CreateSafeguard `json:"createSafeguard,omitempty"`
+
+	// DeletionCheck verifies that the object is gone after the REMOVE mapping was sent once: the OBSERVE
+	// mapping is sent at every poll, and the deletion is reported as complete only when its response
+	// shows that the object was removed, otherwise the Request keeps being deleted.
+	// +optional
+	DeletionCheck *DeletionCheck `json:"deletionCheck,omitempty"`
+
+	// ResponseDelayTolerance is how long after a successful CREATE an OBSERVE request that doesn't find
+	// the object, or fails with an HTTP error status code, means that the object is still being created,
+	// for eventually consistent APIs. Such responses are neither recorded nor counted as failures, and the
+	// object isn't created again, until the tolerance has elapsed.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="responseDelayTolerance must not be negative"
+	ResponseDelayTolerance *metav1.Duration `json:"responseDelayTolerance,omitempty"`
+
 	// SecretInjectionConfig specifies the secrets receiving patches for response data.
 	SecretInjectionConfigs []common.SecretInjectionConfig `json:"secretInjectionConfigs,omitempty"`
 
+	// ConnectionDetails are the fields of the last response published as connection details, in the
+	// connection secret of writeConnectionSecretToRef, so that e.g. compositions can consume them.
+	// +optional
+	ConnectionDetails []common.ConnectionDetail `json:"connectionDetails,omitempty"`
+
+	// AtomicSecretInjection, when set to true, applies the SecretInjectionConfigs all or nothing: when one of them
+	// fails, the secrets already patched from the same response are rolled back.
+	AtomicSecretInjection bool `json:"atomicSecretInjection,omitempty"`
+
+	// SecretInjectionDiscriminator is a jq filter evaluated against the response, e.g.
+	// if .body.error then "error" else "success" end, selecting the SecretInjectionConfigs whose
+	// discriminatorValue matches its result, for endpoints returning differently shaped responses.
+	SecretInjectionDiscriminator string `json:"secretInjectionDiscriminator,omitempty"`
+
 	// ExpectedResponseCheck specifies the mechanism to validate the OBSERVE response against expected value.
 	ExpectedResponseCheck ExpectedResponseCheck `json:"expectedResponseCheck,omitempty"`
 
+	// OwnedFields are jq paths, e.g. .spec.name, of the fields this resource owns. When set, the default
+	// expectedResponseCheck only compares the values at these paths, so drift in other fields of the
+	// response, e.g. server-side churn, does not trigger an UPDATE. They can't be combined with the
+	// patchStrategy of a mapping.
+	OwnedFields []string `json:"ownedFields,omitempty"`
+
 	// IsRemovedCheck specifies the mechanism to validate the OBSERVE response after removal against expected value.
 	IsRemovedCheck ExpectedResponseCheck `json:"isRemovedCheck,omitempty"`
+
+	// ResourceAbsentStatusCodes are the status codes of the OBSERVE response meaning that the object
+	// doesn't exist, e.g. 410 for APIs answering Gone. They are used by the DEFAULT isRemovedCheck and
+	// the deletionCheck, and default to 404.
+	// +optional
+	ResourceAbsentStatusCodes []int `json:"resourceAbsentStatusCodes,omitempty"`
+
+	// ResourceAbsentOnEmptyBody makes a successful OBSERVE response with an empty body also mean that the
+	// object doesn't exist, e.g. for APIs answering 200 with an empty body rather than 404.
+	// +optional
+	ResourceAbsentOnEmptyBody bool `json:"resourceAbsentOnEmptyBody,omitempty"`
+
+	// ErrorClassifications map responses to error categories, so that the controller reacts to them
+	// accordingly. They are evaluated in order against the responses of the requests, the first match wins.
+	ErrorClassifications []ErrorClassification `json:"errorClassifications,omitempty"`
+
+	// ResponseTransform is a jq filter applied to the JSON response body before it is checked or stored.
+	// When omitted, the ProviderConfig's default response transform is used.
+	ResponseTransform string `json:"responseTransform,omitempty"`
+
+	// ResponseBodyFormat specifies how the response body is exposed to the jq filters. json, the default,
+	// exposes a JSON body as an object, and when set explicitly also parses the bodies whose root is an
+	// array or a scalar in the checks. xml and yaml parse the body into an object, and raw exposes the
+	// body as a string without parsing it.
+	// +kubebuilder:validation:Enum=json;xml;yaml;raw
+	ResponseBodyFormat string `json:"responseBodyFormat,omitempty"`
+
+	// UnresolvedSecretPolicy specifies how the {{name:namespace:key}} secret placeholders referencing a
+	// missing secret or key are handled. fail, the default, fails the reconcile with an error naming the
+	// missing secret or key, and leaveUnresolved keeps the placeholders as is.
+	// +kubebuilder:validation:Enum=fail;leaveUnresolved
+	UnresolvedSecretPolicy string `json:"unresolvedSecretPolicy,omitempty"`
+
+	// MirrorAtProvider, when set to true, mirrors the last request and response, with their
+	// sensitive values masked, in status.atProvider.
+	MirrorAtProvider bool `json:"mirrorAtProvider,omitempty"`
+
+	// RefreshInterval is the interval at which the OBSERVE request is sent only to refresh the injected
+	// secrets, e.g. rotated tokens. These refreshes don't check for drift nor change the synced state,
+	// drift is still checked every poll interval.
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// PollIntervalHeader is the name of a response header, e.g. X-Poll-After, whose value sets when the
+	// Request is reconciled next, in seconds or as an HTTP date like Retry-After. The interval is bounded
+	// to between 1 second and 10 poll intervals. The poll interval is used when the last response doesn't
+	// have the header.
+	PollIntervalHeader string `json:"pollIntervalHeader,omitempty"`
+
+	// ResponseErrorMessagePath is a jq filter extracting the error message of a failed response, e.g.
+	// .body.error.message. The message is surfaced in the status and the Synced condition.
+	ResponseErrorMessagePath string `json:"responseErrorMessagePath,omitempty"`
+
+	// RecreateCondition is a jq filter evaluated against the OBSERVE response, e.g. .response.body.state == "failed".
+	// When it returns true, the resource is considered unrecoverable: it is removed using the REMOVE mapping
+	// and created again, once the removal succeeded or found it absent.
+	RecreateCondition string `json:"recreateCondition,omitempty"`
+
+	// LateInitFields map fields of the OBSERVE response into keys of the payload body that are not
+	// set yet, so that server-assigned defaults are recorded in the spec.
+	LateInitFields []LateInitField `json:"lateInitFields,omitempty"`
+}
+
+// LateInitField maps a field of the OBSERVE response into a key of the payload body.
+type LateInitField struct {
+	// ResponseJQ is a jq filter selecting the value in the response, e.g. .body.region.
+	ResponseJQ string `json:"responseJQ"`
+
+	// PayloadBodyKey is the key of the payload body late-initialized with the selected value.
+	PayloadBodyKey string `json:"payloadBodyKey"`
 }
 
 type Mapping struct {
@@ -76,16 +321,152 @@ type Mapping struct {
 	// Action specifies the intended action for the request.
 	Action string `json:"action,omitempty"`
 
+	// Condition is a jq filter evaluated against the payload and the last response, e.g.
+	// .response.body.state != "terminated". The mapping is skipped, without error, when it returns false.
+	// A skipped UPDATE mapping is not considered as drift.
+	Condition string `json:"condition,omitempty"`
+
 	// Body specifies the body of the request.
 	Body string `json:"body,omitempty"`
 
+	// BodyFrom references the key of a ConfigMap or a Secret holding the body of the request, sent as is
+	// rather than evaluated as a jq filter, e.g. for large payloads. The body takes precedence.
+	// +optional
+	BodyFrom *common.BodyFrom `json:"bodyFrom,omitempty"`
+
+	// BodyFromPrevious is the action of another mapping (e.g. CREATE) whose rendered body is used as
+	// a base for this mapping's body. The fields of this mapping's own body, if any, override it.
+	// +kubebuilder:validation:Enum=CREATE;OBSERVE;UPDATE;REMOVE
+	BodyFromPrevious string `json:"bodyFromPrevious,omitempty"`
+
+	// LayeredBody computes the body of the request by merging layers: the defaults are merged with the
+	// observed object, then overlaid with the desired fields. When set, it is used instead of Body.
+	LayeredBody *LayeredBody `json:"layeredBody,omitempty"`
+
+	// BodyFragments computes the body of the request from an ordered list of jq filters, each returning
+	// a JSON object, or null to skip it. Nested objects are merged recursively, later fragments take
+	// precedence. When set, it is used instead of Body.
+	BodyFragments []string `json:"bodyFragments,omitempty"`
+
+	// BodyEncoding specifies how the rendered body is sent. json, the default, sends it as is. ndjson
+	// serializes a JSON array body as newline-delimited JSON, one document per line, for bulk ingest
+	// endpoints, and defaults the Content-Type header to application/x-ndjson. formData sends the
+	// formFields as a multipart/form-data body instead of the body, with the matching Content-Type header.
+	// urlencoded serializes a JSON object body as an application/x-www-form-urlencoded form, e.g. for
+	// token endpoints, and defaults the Content-Type header to application/x-www-form-urlencoded.
+	// +kubebuilder:validation:Enum=json;ndjson;formData;urlencoded
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+
+	// FormFields are the fields of the multipart/form-data body sent with the formData body encoding.
+	// +optional
+	FormFields []FormField `json:"formFields,omitempty"`
+
+	// PatchStrategy specifies how the body of the UPDATE mapping, e.g. of a PATCH request, is compared
+	// with the OBSERVE response. jsonMerge reads the body as a JSON merge patch (RFC 7386), jsonPatch as a
+	// JSON patch (RFC 6902), and the response is up to date when applying the body to it changes nothing.
+	// When omitted, the response must contain the fields of the body. It can't be combined with the
+	// ownedFields of the Request, which scope the comparison differently.
+	// +kubebuilder:validation:Enum=jsonMerge;jsonPatch
+	PatchStrategy string `json:"patchStrategy,omitempty"`
+
 	// URL specifies the URL for the request.
 	URL string `json:"url"`
 
 	// Headers specifies the headers for the request.
 	Headers map[string][]string `json:"headers,omitempty"`
+
+	// ExpectedStatusCodes, when set, are the only status codes accepted for the requests of this mapping,
+	// e.g. 201 for CREATE or 204 for REMOVE. Any other status code fails the step.
+	// +kubebuilder:validation:items:Minimum=100
+	// +kubebuilder:validation:items:Maximum=599
+	ExpectedStatusCodes []int `json:"expectedStatusCodes,omitempty"`
+
+	// Timeout overrides the waitTimeout of the Request for the requests of this mapping, e.g. for a CREATE
+	// that takes longer than the other steps. Unset or zero means the waitTimeout is used. It can't exceed
+	// the reconcile timeout of the provider, which bounds every request.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="timeout must not be negative"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// BodyChecksums lists the headers set to a checksum of the rendered body, e.g. Content-MD5 or
+	// X-Content-SHA256. They override the headers of the same name.
+	BodyChecksums []BodyChecksum `json:"bodyChecksums,omitempty"`
+}
+
+// CreateSafeguard specifies how an object is looked up before it is created.
+type CreateSafeguard struct {
+	// URL is a jq filter returning the URL of the object from a stable external ID found in the spec,
+	// e.g. (.payload.baseUrl + "/" + .payload.body.username). It must not depend on the response, which
+	// is part of the status. The object is found when the OBSERVE mapping sent to this URL succeeds.
+	URL string `json:"url"`
+}
+
+// FormField is a field of a multipart/form-data body, either a value or a file part.
+// +kubebuilder:validation:XValidation:rule="has(self.value) != has(self.valueFrom)",message="exactly one of value and valueFrom must be set"
+type FormField struct {
+	// Name of the field.
+	Name string `json:"name"`
+
+	// Value is a jq filter returning the value of the field, e.g. .payload.body.description. Secret
+	// placeholders are resolved in the value.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// ValueFrom references the key of a ConfigMap or a Secret whose content is sent as a file part.
+	// +optional
+	ValueFrom *common.BodyFrom `json:"valueFrom,omitempty"`
+
+	// FileName is the file name of the file part. Defaults to the referenced key.
+	// +optional
+	FileName string `json:"fileName,omitempty"`
+
+	// ContentType is the content type of the file part. Defaults to application/octet-stream.
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// DeletionCheck specifies how the removal of an object is verified.
+type DeletionCheck struct {
+	// StatusCodes of the OBSERVE response meaning that the object was removed, e.g. 404 or 410.
+	// Defaults to the resourceAbsentStatusCodes of the Request when no logic is set either.
+	// +optional
+	StatusCodes []int `json:"statusCodes,omitempty"`
+
+	// Logic is a jq filter evaluated against the request object and the OBSERVE response, e.g.
+	// .response.body.state == "deleted", returning true when the object was removed.
+	// +optional
+	Logic string `json:"logic,omitempty"`
+}
+
+// BodyChecksum specifies a header set to a checksum of the request body.
+type BodyChecksum struct {
+	// Header is the name of the header set to the checksum.
+	Header string `json:"header"`
+
+	// Algorithm is the hash algorithm of the checksum.
+	// +kubebuilder:validation:Enum=md5;sha256
+	Algorithm string `json:"algorithm"`
+
+	// Encoding is the encoding of the checksum, hex by default. Content-MD5 expects base64.
+	// +kubebuilder:validation:Enum=hex;base64
+	// +kubebuilder:default=hex
+	Encoding string `json:"encoding,omitempty"`
 }
 
+// LayeredBody specifies the layers of a request body. Each layer is a jq filter returning a JSON
+// object, or null to skip it. Nested objects are merged recursively, later layers take precedence.
+type LayeredBody struct {
+	// Defaults returns the base fields of the body, e.g. { region: "eu-west-1" }.
+	Defaults string `json:"defaults,omitempty"`
+
+	// Observed returns the observed object, e.g. .response.body.
+	Observed string `json:"observed,omitempty"`
+
+	// Desired returns the fields desired in the spec, e.g. { name: .payload.body.name }.
+	Desired string `json:"desired,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!(has(self.logic) && has(self.logicRef))",message="logic and logicRef are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.onCheckError) || self.onCheckError != 'fallbackLogic' || has(self.fallbackLogic)",message="fallbackLogic must be set when onCheckError is fallbackLogic"
 type ExpectedResponseCheck struct {
 	// Type specifies the type of the expected response check.
 	// +kubebuilder:validation:Enum=DEFAULT;CUSTOM
@@ -93,6 +474,58 @@ type ExpectedResponseCheck struct {
 
 	// Logic specifies the custom logic for the expected response check.
 	Logic string `json:"logic,omitempty"`
+
+	// LogicRef references the key of a ConfigMap holding the custom logic for the expected response
+	// check, read at every reconcile, as an alternative to an inline Logic.
+	LogicRef *common.ConfigMapKeyRef `json:"logicRef,omitempty"`
+
+	// OnCheckError specifies how an error of the CUSTOM logic, e.g. a response of an unexpected shape, is
+	// handled. fail, the default, fails the reconcile. notSynced treats the check as false, i.e. the object
+	// is not up to date for expectedResponseCheck and not removed for isRemovedCheck. fallbackLogic
+	// evaluates the FallbackLogic instead.
+	// +kubebuilder:validation:Enum=fail;notSynced;fallbackLogic
+	OnCheckError string `json:"onCheckError,omitempty"`
+
+	// FallbackLogic is the jq logic evaluated when the CUSTOM logic errors and OnCheckError is fallbackLogic.
+	FallbackLogic string `json:"fallbackLogic,omitempty"`
+}
+
+// ErrorClassification maps the responses matching its status codes and condition to an error category.
+// +kubebuilder:validation:XValidation:rule="has(self.statusCodes) || has(self.condition)",message="either statusCodes or condition must be set"
+type ErrorClassification struct {
+	// StatusCodes are the status codes of the responses matched, any status code when omitted.
+	// +kubebuilder:validation:items:Minimum=100
+	// +kubebuilder:validation:items:Maximum=599
+	StatusCodes []int `json:"statusCodes,omitempty"`
+
+	// Condition is a jq filter evaluated against the response, e.g. .response.body.code == "RATE_LIMITED",
+	// that must return true for the response to be matched.
+	Condition string `json:"condition,omitempty"`
+
+	// Category is the error category of the matched responses. retryable responses are retried with
+	// backoff, terminal ones are recorded without being retried before the next poll, notFound ones mean
+	// the resource doesn't exist and conflict ones are retried without being recorded.
+	// +kubebuilder:validation:Enum=retryable;terminal;notFound;conflict
+	Category string `json:"category"`
+}
+
+// ResourceRef references a resource of the cluster exposed to the mappings.
+type ResourceRef struct {
+	// Name is the key of the resource under .resources in the mappings.
+	Name string `json:"name"`
+
+	// APIVersion is the API version of the resource, e.g. ec2.aws.upbound.io/v1beta1.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the resource, e.g. Instance.
+	Kind string `json:"kind"`
+
+	// ResourceName is the name of the resource.
+	ResourceName string `json:"resourceName"`
+
+	// Namespace is the namespace of the resource, empty for cluster-scoped resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 type Payload struct {
@@ -101,6 +534,11 @@ type Payload struct {
 
 	// Body specifies data to be used in the request body.
 	Body string `json:"body,omitempty"`
+
+	// Items is a list of JSON bodies of identical objects managed by the Request, one object per item.
+	// When set, every mapping is sent once per item with .payload.body set to the item, and the
+	// Request is up to date only when all the items are.
+	Items []string `json:"items,omitempty"`
 }
 
 // A RequestSpec defines the desired state of a Request.
@@ -109,11 +547,17 @@ type RequestSpec struct {
 	ForProvider       RequestParameters `json:"forProvider"`
 }
 
-// RequestObservation are the observable fields of a Request.
+// Response is the observed HTTP response of a Request.
 type Response struct {
 	StatusCode int                 `json:"statusCode,omitempty"`
 	Body       string              `json:"body,omitempty"`
 	Headers    map[string][]string `json:"headers,omitempty"`
+
+	// DurationMs is how long the request took, in milliseconds, until its response body was read.
+	DurationMs int64 `json:"durationMs,omitempty"`
+
+	// Proto is the HTTP protocol version of the response, e.g. HTTP/1.1 or HTTP/2.0.
+	Proto string `json:"proto,omitempty"`
 }
 
 // A RequestStatus represents the observed state of a Request.
@@ -124,6 +568,71 @@ type RequestStatus struct {
 	Failed              int32    `json:"failed,omitempty"`
 	Error               string   `json:"error,omitempty"`
 	RequestDetails      Mapping  `json:"requestDetails,omitempty"`
+
+	// Message is the status message rendered from the last response by the responseBodyTemplate.
+	Message string `json:"message,omitempty"`
+
+	// Items holds the observed state of each of the payload items, in the same order.
+	Items []ItemStatus `json:"items,omitempty"`
+
+	// AtProvider mirrors the last request and response when mirrorAtProvider is set.
+	AtProvider *RequestObservation `json:"atProvider,omitempty"`
+
+	// LastDriftCheck is the last drift check that found the Request up to date. It is only recorded
+	// when refreshInterval is set, so that the reconciles refreshing secrets can skip the drift check.
+	LastDriftCheck *DriftCheck `json:"lastDriftCheck,omitempty"`
+
+	// LastSyncedUpdate is the last UPDATE whose status code is one of updateConsideredSyncedOn. The Request
+	// is considered synced without drift comparison as long as its generation doesn't change.
+	LastSyncedUpdate *DriftCheck `json:"lastSyncedUpdate,omitempty"`
+
+	// LastTerminalError is the last response classified as terminal by the errorClassifications. No request
+	// is sent for the Request, other than to remove it, as long as its generation doesn't change.
+	LastTerminalError *DriftCheck `json:"lastTerminalError,omitempty"`
+
+	// RemoveRequested is when the REMOVE mapping was sent to delete the object of a Request with a
+	// deletionCheck. It is sent only once, and the deletionCheck is then polled until the object is gone.
+	RemoveRequested *DriftCheck `json:"removeRequested,omitempty"`
+
+	// ServerDryRunValidated is when the server dry-run of the CREATE request succeeded. The dry run isn't
+	// sent again as long as the generation of the Request doesn't change.
+	ServerDryRunValidated *DriftCheck `json:"serverDryRunValidated,omitempty"`
+}
+
+// DriftCheck is a drift check, or an UPDATE, that found a generation of a Request up to date, or the
+// response that stopped the requests for a generation.
+type DriftCheck struct {
+	Time       metav1.Time `json:"time"`
+	Generation int64       `json:"generation"`
+}
+
+// RequestObservation are the observable fields of a Request: the last request sent and the
+// response received, with their sensitive values masked.
+type RequestObservation struct {
+	Request  Mapping  `json:"request,omitempty"`
+	Response Response `json:"response,omitempty"`
+}
+
+// ItemStatus is the observed state of a payload item of a Request.
+type ItemStatus struct {
+	// Key is the identity of the item, see itemKey.
+	Key string `json:"key,omitempty"`
+
+	// Item is the payload item, or forEach value, kept to remove its object once it is no longer listed.
+	Item string `json:"item,omitempty"`
+
+	Response       Response `json:"response,omitempty"`
+	RequestDetails Mapping  `json:"requestDetails,omitempty"`
+	Synced         bool     `json:"synced,omitempty"`
+}
+
+// ForEachItem is a value of forEach, with its index.
+type ForEachItem struct {
+	// Value is the forEach value, JSON or a plain string.
+	Value string
+
+	// Index is the index of the value in forEach.
+	Index int
 }
 
 type Cache struct {
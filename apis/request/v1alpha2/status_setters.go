@@ -14,6 +14,14 @@ func (d *Request) SetBody(body string) {
 	d.Status.Response.Body = body
 }
 
+func (d *Request) SetDurationMs(durationMs int64) {
+	d.Status.Response.DurationMs = durationMs
+}
+
+func (d *Request) SetProto(proto string) {
+	d.Status.Response.Proto = proto
+}
+
 func (d *Request) SetError(err error) {
 	d.Status.Failed++
 	if err != nil {
@@ -26,6 +34,10 @@ func (d *Request) ResetFailures() {
 	d.Status.Error = ""
 }
 
+func (d *Request) SetMessage(message string) {
+	d.Status.Message = message
+}
+
 func (d *Request) SetRequestDetails(url, method, body string, headers map[string][]string) {
 	d.Status.RequestDetails.Body = body
 	d.Status.RequestDetails.URL = url
@@ -39,3 +51,19 @@ func (d *Request) SetCache(statusCode int, headers map[string][]string, body str
 	d.Status.Cache.Response.Body = body
 	d.Status.Cache.LastUpdated = time.Now().UTC().Format(time.RFC3339)
 }
+
+func (d *Request) SetAtProvider(method, url, requestBody string, requestHeaders map[string][]string, statusCode int, responseHeaders map[string][]string, responseBody string) {
+	d.Status.AtProvider = &RequestObservation{
+		Request: Mapping{
+			Method:  method,
+			URL:     url,
+			Body:    requestBody,
+			Headers: requestHeaders,
+		},
+		Response: Response{
+			StatusCode: statusCode,
+			Headers:    responseHeaders,
+			Body:       responseBody,
+		},
+	}
+}
@@ -26,6 +26,21 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BodyChecksum) DeepCopyInto(out *BodyChecksum) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodyChecksum.
+func (in *BodyChecksum) DeepCopy() *BodyChecksum {
+	if in == nil {
+		return nil
+	}
+	out := new(BodyChecksum)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cache) DeepCopyInto(out *Cache) {
 	*out = *in
@@ -42,9 +57,85 @@ func (in *Cache) DeepCopy() *Cache {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CreateSafeguard) DeepCopyInto(out *CreateSafeguard) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CreateSafeguard.
+func (in *CreateSafeguard) DeepCopy() *CreateSafeguard {
+	if in == nil {
+		return nil
+	}
+	out := new(CreateSafeguard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionCheck) DeepCopyInto(out *DeletionCheck) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionCheck.
+func (in *DeletionCheck) DeepCopy() *DeletionCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftCheck) DeepCopyInto(out *DriftCheck) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftCheck.
+func (in *DriftCheck) DeepCopy() *DriftCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorClassification) DeepCopyInto(out *ErrorClassification) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorClassification.
+func (in *ErrorClassification) DeepCopy() *ErrorClassification {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorClassification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExpectedResponseCheck) DeepCopyInto(out *ExpectedResponseCheck) {
 	*out = *in
+	if in.LogicRef != nil {
+		in, out := &in.LogicRef, &out.LogicRef
+		*out = new(common.ConfigMapKeyRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpectedResponseCheck.
@@ -57,9 +148,113 @@ func (in *ExpectedResponseCheck) DeepCopy() *ExpectedResponseCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForEachItem) DeepCopyInto(out *ForEachItem) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForEachItem.
+func (in *ForEachItem) DeepCopy() *ForEachItem {
+	if in == nil {
+		return nil
+	}
+	out := new(ForEachItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FormField) DeepCopyInto(out *FormField) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(common.BodyFrom)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FormField.
+func (in *FormField) DeepCopy() *FormField {
+	if in == nil {
+		return nil
+	}
+	out := new(FormField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ItemStatus) DeepCopyInto(out *ItemStatus) {
+	*out = *in
+	in.Response.DeepCopyInto(&out.Response)
+	in.RequestDetails.DeepCopyInto(&out.RequestDetails)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ItemStatus.
+func (in *ItemStatus) DeepCopy() *ItemStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ItemStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LateInitField) DeepCopyInto(out *LateInitField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LateInitField.
+func (in *LateInitField) DeepCopy() *LateInitField {
+	if in == nil {
+		return nil
+	}
+	out := new(LateInitField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LayeredBody) DeepCopyInto(out *LayeredBody) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LayeredBody.
+func (in *LayeredBody) DeepCopy() *LayeredBody {
+	if in == nil {
+		return nil
+	}
+	out := new(LayeredBody)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Mapping) DeepCopyInto(out *Mapping) {
 	*out = *in
+	if in.BodyFrom != nil {
+		in, out := &in.BodyFrom, &out.BodyFrom
+		*out = new(common.BodyFrom)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LayeredBody != nil {
+		in, out := &in.LayeredBody, &out.LayeredBody
+		*out = new(LayeredBody)
+		**out = **in
+	}
+	if in.BodyFragments != nil {
+		in, out := &in.BodyFragments, &out.BodyFragments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FormFields != nil {
+		in, out := &in.FormFields, &out.FormFields
+		*out = make([]FormField, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
 		*out = make(map[string][]string, len(*in))
@@ -76,6 +271,21 @@ func (in *Mapping) DeepCopyInto(out *Mapping) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BodyChecksums != nil {
+		in, out := &in.BodyChecksums, &out.BodyChecksums
+		*out = make([]BodyChecksum, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mapping.
@@ -91,6 +301,11 @@ func (in *Mapping) DeepCopy() *Mapping {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Payload) DeepCopyInto(out *Payload) {
 	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Payload.
@@ -162,6 +377,23 @@ func (in *RequestList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestObservation) DeepCopyInto(out *RequestObservation) {
+	*out = *in
+	in.Request.DeepCopyInto(&out.Request)
+	in.Response.DeepCopyInto(&out.Response)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestObservation.
+func (in *RequestObservation) DeepCopy() *RequestObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestParameters) DeepCopyInto(out *RequestParameters) {
 	*out = *in
@@ -172,7 +404,29 @@ func (in *RequestParameters) DeepCopyInto(out *RequestParameters) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.Payload = in.Payload
+	if in.ForEach != nil {
+		in, out := &in.ForEach, &out.ForEach
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MappingTemplate != nil {
+		in, out := &in.MappingTemplate, &out.MappingTemplate
+		*out = make([]Mapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ForEachItem != nil {
+		in, out := &in.ForEachItem, &out.ForEachItem
+		*out = new(ForEachItem)
+		**out = **in
+	}
+	in.Payload.DeepCopyInto(&out.Payload)
+	if in.ResourceRefs != nil {
+		in, out := &in.ResourceRefs, &out.ResourceRefs
+		*out = make([]ResourceRef, len(*in))
+		copy(*out, *in)
+	}
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
 		*out = make(map[string][]string, len(*in))
@@ -189,11 +443,51 @@ func (in *RequestParameters) DeepCopyInto(out *RequestParameters) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.UpdateConsideredSyncedOn != nil {
+		in, out := &in.UpdateConsideredSyncedOn, &out.UpdateConsideredSyncedOn
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
 	if in.WaitTimeout != nil {
 		in, out := &in.WaitTimeout, &out.WaitTimeout
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(common.ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(common.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CorrelationHeaders != nil {
+		in, out := &in.CorrelationHeaders, &out.CorrelationHeaders
+		*out = new(common.CorrelationHeaders)
+		**out = **in
+	}
+	if in.MaxResponseBodyBytes != nil {
+		in, out := &in.MaxResponseBodyBytes, &out.MaxResponseBodyBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CreateSafeguard != nil {
+		in, out := &in.CreateSafeguard, &out.CreateSafeguard
+		*out = new(CreateSafeguard)
+		**out = **in
+	}
+	if in.DeletionCheck != nil {
+		in, out := &in.DeletionCheck, &out.DeletionCheck
+		*out = new(DeletionCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseDelayTolerance != nil {
+		in, out := &in.ResponseDelayTolerance, &out.ResponseDelayTolerance
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.SecretInjectionConfigs != nil {
 		in, out := &in.SecretInjectionConfigs, &out.SecretInjectionConfigs
 		*out = make([]common.SecretInjectionConfig, len(*in))
@@ -201,8 +495,40 @@ func (in *RequestParameters) DeepCopyInto(out *RequestParameters) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.ExpectedResponseCheck = in.ExpectedResponseCheck
-	out.IsRemovedCheck = in.IsRemovedCheck
+	if in.ConnectionDetails != nil {
+		in, out := &in.ConnectionDetails, &out.ConnectionDetails
+		*out = make([]common.ConnectionDetail, len(*in))
+		copy(*out, *in)
+	}
+	in.ExpectedResponseCheck.DeepCopyInto(&out.ExpectedResponseCheck)
+	if in.OwnedFields != nil {
+		in, out := &in.OwnedFields, &out.OwnedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.IsRemovedCheck.DeepCopyInto(&out.IsRemovedCheck)
+	if in.ResourceAbsentStatusCodes != nil {
+		in, out := &in.ResourceAbsentStatusCodes, &out.ResourceAbsentStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.ErrorClassifications != nil {
+		in, out := &in.ErrorClassifications, &out.ErrorClassifications
+		*out = make([]ErrorClassification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LateInitFields != nil {
+		in, out := &in.LateInitFields, &out.LateInitFields
+		*out = make([]LateInitField, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestParameters.
@@ -239,6 +565,43 @@ func (in *RequestStatus) DeepCopyInto(out *RequestStatus) {
 	in.Response.DeepCopyInto(&out.Response)
 	in.Cache.DeepCopyInto(&out.Cache)
 	in.RequestDetails.DeepCopyInto(&out.RequestDetails)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AtProvider != nil {
+		in, out := &in.AtProvider, &out.AtProvider
+		*out = new(RequestObservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastDriftCheck != nil {
+		in, out := &in.LastDriftCheck, &out.LastDriftCheck
+		*out = new(DriftCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastSyncedUpdate != nil {
+		in, out := &in.LastSyncedUpdate, &out.LastSyncedUpdate
+		*out = new(DriftCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastTerminalError != nil {
+		in, out := &in.LastTerminalError, &out.LastTerminalError
+		*out = new(DriftCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoveRequested != nil {
+		in, out := &in.RemoveRequested, &out.RemoveRequested
+		*out = new(DriftCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServerDryRunValidated != nil {
+		in, out := &in.ServerDryRunValidated, &out.ServerDryRunValidated
+		*out = new(DriftCheck)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestStatus.
@@ -251,6 +614,21 @@ func (in *RequestStatus) DeepCopy() *RequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRef.
+func (in *ResourceRef) DeepCopy() *ResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Response) DeepCopyInto(out *Response) {
 	*out = *in
@@ -23,12 +23,199 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
 )
 
 // A ProviderConfigSpec defines the desired state of a ProviderConfig.
 type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// ResponseDefaults specifies response handling applied to every Request using this ProviderConfig,
+	// unless the Request overrides it.
+	// +optional
+	ResponseDefaults *ResponseDefaults `json:"responseDefaults,omitempty"`
+
+	// Retry configures the retries of requests that fail before a response is received.
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// ConnectTimeout is the maximum time to wait for a connection to the server to be established,
+	// so that unreachable hosts fail fast while slow responses can still use the full wait timeout.
+	// Defaults to 10s.
+	// +optional
+	ConnectTimeout *metav1.Duration `json:"connectTimeout,omitempty"`
+
+	// Tracing enables the collection of the latency breakdown (DNS, connect, TLS handshake and
+	// time to first byte) of every request, logged at debug level.
+	// +optional
+	Tracing *TracingConfig `json:"tracing,omitempty"`
+
+	// DuplicateHeaderPolicy specifies how response headers sent several times by the server are
+	// collapsed: first keeps the first value, last keeps the last one and combine joins them with
+	// a comma. When omitted, all the values are kept.
+	// +kubebuilder:validation:Enum=first;last;combine
+	// +optional
+	DuplicateHeaderPolicy string `json:"duplicateHeaderPolicy,omitempty"`
+
+	// RedactedHeaders are the request headers, matched case-insensitively, whose values are replaced by
+	// *** in the logs and in the status of the resources, in addition to Authorization,
+	// Proxy-Authorization and Cookie which are always redacted.
+	// +optional
+	RedactedHeaders []string `json:"redactedHeaders,omitempty"`
+
+	// RequestHedging hedges the GET and HEAD requests without a body that don't get a response within
+	// a delay, to reduce the tail latency of latency-sensitive APIs at the cost of duplicate requests.
+	// +optional
+	RequestHedging *RequestHedging `json:"requestHedging,omitempty"`
+
+	// Proxy is the proxy the requests are sent through, instead of the one of the environment
+	// (HTTP_PROXY, HTTPS_PROXY and NO_PROXY). Resources can override it.
+	// +optional
+	Proxy *common.ProxyConfig `json:"proxy,omitempty"`
+
+	// TLS sets the minimum TLS version and the cipher suites of the connections. Resources can override it.
+	// +optional
+	TLS *common.TLSConfig `json:"tls,omitempty"`
+
+	// MaxResponseBodyBytes is the maximum size of the response bodies, the requests whose response
+	// body is larger fail. Defaults to 10MiB, 0 means no limit. Resources can override it.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxResponseBodyBytes *int64 `json:"maxResponseBodyBytes,omitempty"`
+
+	// RetryConnectionDrops, when set to false, disables the transparent retry of the idempotent requests
+	// whose kept-alive connection is closed by the server. These retries don't count against the retry
+	// policy. Defaults to true.
+	// +optional
+	RetryConnectionDrops *bool `json:"retryConnectionDrops,omitempty"`
+
+	// FollowRedirects, when set to false, returns the redirect responses verbatim instead of following
+	// them, so that e.g. a redirect to a login page isn't recorded as a success. Defaults to true.
+	// +optional
+	FollowRedirects *bool `json:"followRedirects,omitempty"`
+
+	// MaxRedirects is the maximum number of redirects followed, the requests redirected more often fail.
+	// Defaults to 10, 0 means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRedirects *int `json:"maxRedirects,omitempty"`
+
+	// RoutingProfiles are named routes, selected with the routingProfile of a resource, that send its
+	// requests to a logical service name through a shared gateway.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	RoutingProfiles []RoutingProfile `json:"routingProfiles,omitempty"`
+
+	// TLSDiagnostics reports the certificate chain presented by the server, with the subject, issuer
+	// and subject alternative names of every certificate, in the errors of failed TLS verifications.
+	// +optional
+	TLSDiagnostics bool `json:"tlsDiagnostics,omitempty"`
+
+	// OAuth2 gets the bearer tokens of the requests from a token endpoint with the client credentials
+	// grant, instead of using the credentials. The requests setting their own Authorization header
+	// keep it.
+	// +optional
+	OAuth2 *OAuth2 `json:"oauth2,omitempty"`
+
+	// RequestInterceptorRef references the key of a ConfigMap holding a jq program transforming every
+	// request before it is sent. The program receives the fully assembled request as an object with
+	// its method, url, headers and body, and returns the request to send in the same format.
+	// +optional
+	RequestInterceptorRef *common.ConfigMapKeyRef `json:"requestInterceptorRef,omitempty"`
+}
+
+// OAuth2 configures the OAuth2 client credentials grant. The tokens are cached and refreshed shortly
+// before they expire.
+type OAuth2 struct {
+	// TokenURL is the URL of the token endpoint.
+	TokenURL string `json:"tokenURL"`
+
+	// ClientIDSecretRef references the key of the secret holding the client ID.
+	ClientIDSecretRef xpv1.SecretKeySelector `json:"clientIDSecretRef"`
+
+	// ClientSecretSecretRef references the key of the secret holding the client secret.
+	ClientSecretSecretRef xpv1.SecretKeySelector `json:"clientSecretSecretRef"`
+
+	// Scopes are the scopes requested for the tokens.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Audience is the audience requested for the tokens, for the token endpoints requiring one.
+	// +optional
+	Audience string `json:"audience,omitempty"`
+}
+
+// RoutingProfile routes the requests of the resources selecting it through a gateway: the connections
+// are established to the gateway address, while the Host header and the TLS server name identify
+// the service behind it.
+type RoutingProfile struct {
+	// Name identifies the profile in the routingProfile of the resources.
+	Name string `json:"name"`
+
+	// Address is the address the connections are established to, e.g. 10.0.0.10 or gateway.internal:8443.
+	// The port of the request URL is used when it has none.
+	Address string `json:"address"`
+
+	// Host overrides the Host header of the requests. Defaults to the host of the request URL.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// ServerName overrides the TLS server name (SNI) of the requests, against which the certificate
+	// of the server is also verified. Defaults to the host.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// RequestHedging configures the hedging of the GET and HEAD requests.
+type RequestHedging struct {
+	// Delay is the time after which a request without a response is hedged: a second, identical
+	// request is sent and the first response of the two is used.
+	Delay metav1.Duration `json:"delay"`
+}
+
+// TracingConfig configures the tracing of requests.
+type TracingConfig struct {
+	// Metrics, when set to true, also exposes the latency breakdown as histogram metrics.
+	// +optional
+	Metrics bool `json:"metrics,omitempty"`
+}
+
+// RetryPolicy configures the retries of requests that fail before a response is received.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of times a failed idempotent request is retried.
+	// Defaults to 0, which disables retries.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries int `json:"maxRetries,omitempty"`
+
+	// IdempotentMethods overrides the HTTP methods considered safe to retry.
+	// Defaults to GET, HEAD, OPTIONS, TRACE, PUT and DELETE.
+	// +optional
+	IdempotentMethods []string `json:"idempotentMethods,omitempty"`
+}
+
+// ResponseDefaults specifies default response handling for Requests.
+type ResponseDefaults struct {
+	// ResponseTransform is a jq filter applied to the JSON response body before it is checked or stored.
+	// +optional
+	ResponseTransform string `json:"responseTransform,omitempty"`
+
+	// ExpectedResponseCheck specifies the default mechanism to validate the OBSERVE response against expected value.
+	// +optional
+	ExpectedResponseCheck *ExpectedResponseCheck `json:"expectedResponseCheck,omitempty"`
+}
+
+// ExpectedResponseCheck specifies the mechanism to validate a response against expected value.
+type ExpectedResponseCheck struct {
+	// Type specifies the type of the expected response check.
+	// +kubebuilder:validation:Enum=DEFAULT;CUSTOM
+	Type string `json:"type,omitempty"`
+
+	// Logic specifies the custom logic for the expected response check.
+	Logic string `json:"logic,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -19,11 +19,15 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 
+	"github.com/pkg/errors"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -34,18 +38,37 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 
 	"github.com/crossplane-contrib/provider-http/apis"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	template "github.com/crossplane-contrib/provider-http/internal/controller"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/features"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
 func main() {
 	var (
-		app              = kingpin.New(filepath.Base(os.Args[0]), "Http support for Crossplane.").DefaultEnvars()
-		debug            = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
-		leaderElection   = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
-		timeout          = app.Flag("timeout", "Controls how long http requests may take before they are failed.").Default("10m").Duration()
-		syncInterval     = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
-		pollInterval     = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
-		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
+		app                                      = kingpin.New(filepath.Base(os.Args[0]), "Http support for Crossplane.").DefaultEnvars()
+		debug                                    = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
+		leaderElection                           = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		timeout                                  = app.Flag("timeout", "Controls how long http requests may take before they are failed.").Default("10m").Duration()
+		syncInterval                             = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
+		pollInterval                             = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
+		maxReconcileRate                         = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
+		maxConcurrentRequestReconciles           = app.Flag("max-concurrent-request-reconciles", "The maximum number of concurrent reconciles of Requests. Defaults to max-reconcile-rate.").Default("0").Int()
+		maxConcurrentDisposableRequestReconciles = app.Flag("max-concurrent-disposable-request-reconciles", "The maximum number of concurrent reconciles of DisposableRequests. Defaults to max-reconcile-rate.").Default("0").Int()
+		pauseConfigMap                           = app.Flag("pause-configmap", "Namespace and name (namespace/name) of a ConfigMap pausing the reconciles of all resources while its paused key is set to true.").Default("").String()
+		debugEndpointAddress                     = app.Flag("debug-endpoint-address", "Address (e.g. 127.0.0.1:8081) of a debug endpoint returning the effective configuration of a resource. Disabled by default.").Default("").String()
+		maxConcurrentSecretOperations            = app.Flag("max-concurrent-secret-operations", "The maximum number of concurrent secret reads and patches of all the resources injecting secrets. Unbounded by default.").Default("0").Int()
+		circuitBreakerFailureThreshold           = app.Flag("circuit-breaker-failure-threshold", "The number of consecutive failed requests, without response or with a server error, to a host after which no request is sent to it for the circuit breaker open duration. Disabled by default.").Default("0").Int()
+		circuitBreakerOpenDuration               = app.Flag("circuit-breaker-open-duration", "How long no request is sent to a host whose circuit breaker is open.").Default("1m").Duration()
+		maxBufferedBodyBytes                     = app.Flag("max-buffered-body-bytes", "The maximum memory, in bytes, of the request and response bodies buffered by all the requests in flight. Requests wait for memory before they are sent, and responses that don't fit fail. Unbounded by default.").Default("0").Int64()
+		maxMappings                              = app.Flag("max-mappings", "The maximum number of mappings, including the mapping template rendered for each forEach value, of a Request. Requests with more mappings are rejected with a ConfigError condition. 0 means unbounded.").Default(strconv.Itoa(utils.DefaultMaxMappings)).Int()
+		conflictRetryAttempts                    = app.Flag("conflict-retry-attempts", "The maximum number of attempts, the first one included, of the status updates and secret patches failing with a conflict.").Default(strconv.Itoa(utils.DefaultConflictRetry.Attempts)).Int()
+		conflictRetryBackoff                     = app.Flag("conflict-retry-backoff", "The delay before the first retry of an operation failing with a conflict, doubled after every retry.").Default(utils.DefaultConflictRetry.Backoff.String()).Duration()
+		maxResponseBodyDepth                     = app.Flag("max-response-body-depth", "The maximum nesting depth of the JSON and XML response bodies, and of the JSON strings they hold, exposed to the jq filters. Deeper responses fail the request.").Default(strconv.Itoa(json_util.DefaultResponseBodyMaxDepth)).Int()
+		resourceRefKinds                         = app.Flag("resource-ref-kinds", "The kinds the resourceRefs of the Requests can reference, as Kind.group, e.g. Instance.ec2.aws.upbound.io, or Kind for the core group, e.g. ConfigMap. *.group allows all the kinds of a group. Repeat the flag for several kinds. Secrets can never be referenced. None by default.").Strings()
+		enableTraceContextPropagation            = app.Flag("enable-trace-context-propagation", "Inject a W3C traceparent header in the HTTP requests that don't set one, for distributed tracing.").Default("false").Bool()
 
 		// namespace = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
 	)
@@ -91,6 +114,44 @@ func main() {
 		Features:                &feature.Flags{},
 	}
 
-	kingpin.FatalIfError(template.Setup(mgr, o, *timeout), "Cannot setup Template controllers")
+	if *enableTraceContextPropagation {
+		o.Features.Enable(features.EnableTraceContextPropagation)
+		log.Info("Feature enabled", "flag", features.EnableTraceContextPropagation)
+	}
+
+	datapatcher.SetMaxConcurrentSecretOperations(*maxConcurrentSecretOperations)
+	httpClient.SetCircuitBreaker(*circuitBreakerFailureThreshold, *circuitBreakerOpenDuration)
+	httpClient.SetMaxBufferedBodyBytes(*maxBufferedBodyBytes)
+	utils.SetMaxMappings(*maxMappings)
+	utils.SetResourceRefKinds(*resourceRefKinds)
+	json_util.SetResponseBodyMaxDepth(*maxResponseBodyDepth)
+	utils.SetConflictRetry(*conflictRetryAttempts, *conflictRetryBackoff)
+
+	pauseConfigMapName, err := parseNamespacedName(*pauseConfigMap)
+	kingpin.FatalIfError(err, "Cannot parse pause ConfigMap")
+
+	concurrency := template.Concurrency{
+		Request:           *maxConcurrentRequestReconciles,
+		DisposableRequest: *maxConcurrentDisposableRequestReconciles,
+	}
+
+	kingpin.FatalIfError(template.Setup(mgr, o, *timeout, pauseConfigMapName, concurrency), "Cannot setup Template controllers")
+	if *debugEndpointAddress != "" {
+		kingpin.FatalIfError(template.SetupDebugEndpoint(mgr, o, *debugEndpointAddress), "Cannot setup the debug endpoint")
+	}
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
+
+// parseNamespacedName parses a namespace/name reference. An empty reference is returned as is.
+func parseNamespacedName(ref string) (types.NamespacedName, error) {
+	if ref == "" {
+		return types.NamespacedName{}, nil
+	}
+
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, errors.Errorf("%s should be formatted as namespace/name", ref)
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
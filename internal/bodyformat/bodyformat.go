@@ -0,0 +1,180 @@
+// Package bodyformat converts the response bodies that are not JSON to the values exposed to jq filters.
+package bodyformat
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	errParseXML         = "cannot parse the response body as XML"
+	errParseYAML        = "cannot parse the response body as YAML"
+	errNoXMLElement     = "the response body has no XML element"
+	errMaxDepthExceeded = "XML nesting depth exceeds the maximum allowed depth of %d"
+
+	// xmlAttributePrefix prefixes the keys of the attributes of an XML element.
+	xmlAttributePrefix = "@"
+	// xmlTextKey is the key of the text of an XML element that also has attributes or child elements.
+	xmlTextKey = "#text"
+)
+
+// parsed returns true if the response bodies of the given format are converted by Parse rather than as JSON.
+func parsed(format string) bool {
+	return format != "" && format != common.ResponseBodyFormatJSON
+}
+
+// Parse converts a response body of the given format, xml, yaml or raw, to the value exposed to jq filters.
+// A raw body is returned as is.
+func Parse(body string, format string) (interface{}, error) {
+	switch format {
+	case common.ResponseBodyFormatXML:
+		value, err := parseXML(body)
+		return value, errors.Wrap(err, errParseXML)
+	case common.ResponseBodyFormatYAML:
+		value, err := parseYAML(body)
+		return value, errors.Wrap(err, errParseYAML)
+	default:
+		return body, nil
+	}
+}
+
+// SetBody replaces the body of a response converted to a map with the parsed body, when the body format
+// isn't JSON.
+func SetBody(responseMap map[string]interface{}, body string, format string) error {
+	if !parsed(format) {
+		return nil
+	}
+
+	value, err := Parse(body, format)
+	if err != nil {
+		return err
+	}
+
+	responseMap["body"] = value
+	return nil
+}
+
+// SetJSONBody replaces the body of a response converted to a map with the parsed body, when the body format
+// is explicitly set to json and the body is an array or a scalar, e.g. [1, 2] or 5, which are left as a
+// string when the response is converted to a map, as only JSON objects are parsed. Other bodies, and the
+// bodies of resources without body format, are left as they are, so that their checks keep working.
+func SetJSONBody(responseMap map[string]interface{}, body string, format string) {
+	if format != common.ResponseBodyFormatJSON {
+		return
+	}
+
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" || strings.HasPrefix(trimmed, "{") {
+		return
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(trimmed), &value); err != nil {
+		return
+	}
+
+	responseMap["body"] = value
+}
+
+// parseYAML converts a YAML document to its JSON equivalent.
+func parseYAML(body string) (interface{}, error) {
+	converted, err := yaml.YAMLToJSON([]byte(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(converted, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// parseXML converts an XML document to an object with the root element as its only key. The elements are
+// keyed by their local name, without namespace: their attributes are prefixed with @, their text is kept under #text when they
+// also have attributes or child elements, and child elements repeated under the same name become an array.
+// An element with only text is converted to its text.
+func parseXML(body string) (interface{}, error) {
+	decoder := xml.NewDecoder(strings.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, errors.New(errNoXMLElement)
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := xmlElement(decoder, start, 1)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{start.Name.Local: value}, nil
+		}
+	}
+}
+
+// xmlElement converts the XML element that starts with the given token, at the given nesting depth.
+func xmlElement(decoder *xml.Decoder, start xml.StartElement, depth int) (interface{}, error) {
+	if maxDepth := json_util.ResponseBodyMaxDepth(); depth > maxDepth {
+		return nil, errors.Errorf(errMaxDepthExceeded, maxDepth)
+	}
+
+	element := map[string]interface{}{}
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		element[xmlAttributePrefix+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := xmlElement(decoder, t, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			addXMLChild(element, t.Name.Local, child)
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(element) == 0 {
+				return content, nil
+			}
+			if content != "" {
+				element[xmlTextKey] = content
+			}
+			return element, nil
+		}
+	}
+}
+
+// addXMLChild adds a child element to an element, turning the children repeated under the same name into an array.
+func addXMLChild(element map[string]interface{}, name string, child interface{}) {
+	existing, ok := element[name]
+	if !ok {
+		element[name] = child
+		return
+	}
+
+	if children, ok := existing.([]interface{}); ok {
+		element[name] = append(children, child)
+		return
+	}
+
+	element[name] = []interface{}{existing, child}
+}
@@ -0,0 +1,237 @@
+package bodyformat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+func Test_Parse(t *testing.T) {
+	type args struct {
+		body   string
+		format string
+	}
+	type want struct {
+		value interface{}
+		err   error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"XMLElements": {
+			args: args{
+				body:   `<?xml version="1.0"?><user><name>john</name><email>john@example.com</email></user>`,
+				format: common.ResponseBodyFormatXML,
+			},
+			want: want{
+				value: map[string]interface{}{
+					"user": map[string]interface{}{"name": "john", "email": "john@example.com"},
+				},
+			},
+		},
+		"XMLAttributesAndText": {
+			args: args{
+				body:   `<price currency="EUR">12.50</price>`,
+				format: common.ResponseBodyFormatXML,
+			},
+			want: want{
+				value: map[string]interface{}{
+					"price": map[string]interface{}{"@currency": "EUR", "#text": "12.50"},
+				},
+			},
+		},
+		"XMLRepeatedElements": {
+			args: args{
+				body:   `<users><user>john</user><user>jane</user><user>jack</user></users>`,
+				format: common.ResponseBodyFormatXML,
+			},
+			want: want{
+				value: map[string]interface{}{
+					"users": map[string]interface{}{"user": []interface{}{"john", "jane", "jack"}},
+				},
+			},
+		},
+		"XMLNamespacesAndEmptyElements": {
+			args: args{
+				body: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <Result/>
+  </soap:Body>
+</soap:Envelope>`,
+				format: common.ResponseBodyFormatXML,
+			},
+			want: want{
+				value: map[string]interface{}{
+					"Envelope": map[string]interface{}{
+						"Body": map[string]interface{}{"Result": ""},
+					},
+				},
+			},
+		},
+		"InvalidXML": {
+			args: args{
+				body:   `<user><name>john</user>`,
+				format: common.ResponseBodyFormatXML,
+			},
+			want: want{
+				err: errors.Wrap(errors.New("XML syntax error on line 1: element <name> closed by </user>"), errParseXML),
+			},
+		},
+		"NoXMLElement": {
+			args: args{
+				body:   `not xml`,
+				format: common.ResponseBodyFormatXML,
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoXMLElement), errParseXML),
+			},
+		},
+		"XMLTooDeep": {
+			args: args{
+				body:   strings.Repeat("<a>", json_util.ResponseBodyMaxDepth()+1) + strings.Repeat("</a>", json_util.ResponseBodyMaxDepth()+1),
+				format: common.ResponseBodyFormatXML,
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errMaxDepthExceeded, json_util.ResponseBodyMaxDepth()), errParseXML),
+			},
+		},
+		"YAML": {
+			args: args{
+				body:   "user:\n  name: john\n  admin: true\n  groups:\n    - dev\n    - ops\n",
+				format: common.ResponseBodyFormatYAML,
+			},
+			want: want{
+				value: map[string]interface{}{
+					"user": map[string]interface{}{"name": "john", "admin": true, "groups": []interface{}{"dev", "ops"}},
+				},
+			},
+		},
+		"InvalidYAML": {
+			args: args{
+				body:   "user: [",
+				format: common.ResponseBodyFormatYAML,
+			},
+			want: want{
+				err: errors.Wrap(errors.New("yaml: line 1: did not find expected node content"), errParseYAML),
+			},
+		},
+		"Raw": {
+			args: args{
+				body:   `{"name": "john"}`,
+				format: common.ResponseBodyFormatRaw,
+			},
+			want: want{
+				value: `{"name": "john"}`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := Parse(tc.args.body, tc.args.format)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("Parse(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.value, got); diff != "" {
+				t.Fatalf("Parse(...): -want value, +got value: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SetBody(t *testing.T) {
+	cases := map[string]struct {
+		format string
+		want   interface{}
+	}{
+		"DefaultKeepsBody": {
+			want: map[string]interface{}{"name": "john"},
+		},
+		"JSONKeepsBody": {
+			format: common.ResponseBodyFormatJSON,
+			want:   map[string]interface{}{"name": "john"},
+		},
+		"RawReplacesBody": {
+			format: common.ResponseBodyFormatRaw,
+			want:   `{"name": "john"}`,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			responseMap := map[string]interface{}{"body": map[string]interface{}{"name": "john"}}
+			if err := SetBody(responseMap, `{"name": "john"}`, tc.format); err != nil {
+				t.Fatalf("SetBody(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, responseMap["body"]); diff != "" {
+				t.Fatalf("SetBody(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SetJSONBody(t *testing.T) {
+	type args struct {
+		body   string
+		format string
+	}
+
+	cases := map[string]struct {
+		args args
+		want interface{}
+	}{
+		"ArrayParsed": {
+			args: args{body: `[{"id": 1}, {"id": 2}]`, format: common.ResponseBodyFormatJSON},
+			want: []interface{}{map[string]interface{}{"id": float64(1)}, map[string]interface{}{"id": float64(2)}},
+		},
+		"NumberParsed": {
+			args: args{body: " 5\n", format: common.ResponseBodyFormatJSON},
+			want: float64(5),
+		},
+		"StringParsed": {
+			args: args{body: `"complete"`, format: common.ResponseBodyFormatJSON},
+			want: "complete",
+		},
+		"BooleanParsed": {
+			args: args{body: "true", format: common.ResponseBodyFormatJSON},
+			want: true,
+		},
+		"ObjectKept": {
+			args: args{body: `{"name": "john"}`, format: common.ResponseBodyFormatJSON},
+			want: "unchanged",
+		},
+		"TextKept": {
+			args: args{body: "complete", format: common.ResponseBodyFormatJSON},
+			want: "unchanged",
+		},
+		"DefaultFormatKept": {
+			args: args{body: "[1, 2]"},
+			want: "unchanged",
+		},
+		"RawKept": {
+			args: args{body: "[1, 2]", format: common.ResponseBodyFormatRaw},
+			want: "unchanged",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			responseMap := map[string]interface{}{"body": "unchanged"}
+			SetJSONBody(responseMap, tc.args.body, tc.args.format)
+			if diff := cmp.Diff(tc.want, responseMap["body"]); diff != "" {
+				t.Fatalf("SetJSONBody(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
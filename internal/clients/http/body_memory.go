@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errBodyLargerThanMemoryCap = "request body of %d bytes exceeds the buffered body memory cap of %d bytes"
+	errBodyMemoryWait          = "timed out waiting for %d bytes of buffered body memory"
+	errBodyMemoryExhausted     = "buffered body memory cap of %d bytes exceeded while reading the response body"
+)
+
+// bodyMemory accounts for the request and response bodies buffered by all the clients of the provider, so that
+// many large concurrent bodies can't exhaust the memory of the pod.
+type bodyMemory struct {
+	mu       sync.Mutex
+	maxBytes int64
+	used     int64
+	// released is closed, and replaced, whenever memory is released.
+	released chan struct{}
+}
+
+var bodies = &bodyMemory{released: make(chan struct{})}
+
+// SetMaxBufferedBodyBytes caps the memory of the bodies buffered by all the requests in flight. A request
+// waits for its body to fit before it is sent, and fails when its context is done first or when its body
+// alone exceeds the cap. A response whose body doesn't fit fails right away, since its request already holds
+// memory. Values that are not positive remove the cap.
+func SetMaxBufferedBodyBytes(maxBytes int64) {
+	bodies.mu.Lock()
+	defer bodies.mu.Unlock()
+
+	bodies.maxBytes = maxBytes
+}
+
+// reservation is the body memory held by a request in flight.
+type reservation struct {
+	memory *bodyMemory
+	bytes  int64
+}
+
+// reserve waits until the given number of bytes fits under the cap and reserves them.
+func (m *bodyMemory) reserve(ctx context.Context, n int64) (*reservation, error) {
+	r := &reservation{memory: m}
+	for {
+		m.mu.Lock()
+		if m.maxBytes <= 0 || n == 0 {
+			m.mu.Unlock()
+			return r, nil
+		}
+		if n > m.maxBytes {
+			m.mu.Unlock()
+			return nil, errors.Errorf(errBodyLargerThanMemoryCap, n, m.maxBytes)
+		}
+		if m.used+n <= m.maxBytes {
+			m.used += n
+			m.mu.Unlock()
+			r.bytes = n
+			return r, nil
+		}
+		released := m.released
+		m.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), errBodyMemoryWait, n)
+		}
+	}
+}
+
+// grow reserves more bytes without waiting, failing when they don't fit under the cap.
+func (r *reservation) grow(n int64) error {
+	m := r.memory
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.maxBytes <= 0 {
+		return nil
+	}
+	if m.used+n > m.maxBytes {
+		return errors.Errorf(errBodyMemoryExhausted, m.maxBytes)
+	}
+	m.used += n
+	r.bytes += n
+	return nil
+}
+
+// release gives the reserved bytes back and wakes up the requests waiting for memory.
+func (r *reservation) release() {
+	m := r.memory
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if r.bytes == 0 {
+		return
+	}
+	m.used -= r.bytes
+	r.bytes = 0
+	close(m.released)
+	m.released = make(chan struct{})
+}
+
+// reader returns a reader of the given body growing the reservation with the bytes read.
+func (r *reservation) reader(body io.Reader) io.Reader {
+	return &reservedReader{body: body, reservation: r}
+}
+
+// reservedReader grows its reservation with the bytes it reads.
+type reservedReader struct {
+	body        io.Reader
+	reservation *reservation
+}
+
+func (rr *reservedReader) Read(p []byte) (int, error) {
+	n, err := rr.body.Read(p)
+	if n > 0 {
+		if growErr := rr.reservation.grow(int64(n)); growErr != nil {
+			return 0, growErr
+		}
+	}
+	return n, err
+}
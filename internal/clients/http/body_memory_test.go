@@ -0,0 +1,186 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_bodyMemory_reserve(t *testing.T) {
+	type args struct {
+		maxBytes int64
+		held     int64
+		n        int64
+	}
+	type want struct {
+		bytes int64
+		err   error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Unbounded": {
+			args: args{held: 1000, n: 1000},
+			want: want{bytes: 0},
+		},
+		"Fits": {
+			args: args{maxBytes: 100, held: 40, n: 60},
+			want: want{bytes: 60},
+		},
+		"LargerThanCap": {
+			args: args{maxBytes: 100, n: 101},
+			want: want{err: errors.Errorf(errBodyLargerThanMemoryCap, 101, 100)},
+		},
+		"WaitTimedOut": {
+			args: args{maxBytes: 100, held: 80, n: 30},
+			want: want{err: errors.Wrapf(context.DeadlineExceeded, errBodyMemoryWait, 30)},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			m := &bodyMemory{maxBytes: tc.args.maxBytes, used: tc.args.held, released: make(chan struct{})}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			r, err := m.reserve(ctx, tc.args.n)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("reserve(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.bytes, r.bytes); diff != "" {
+				t.Errorf("reserve(...): -want reserved bytes, +got reserved bytes: %s", diff)
+			}
+			r.release()
+			if diff := cmp.Diff(tc.args.held, m.used); diff != "" {
+				t.Errorf("release(): -want used bytes, +got used bytes: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_bodyMemory_reserveWaitsForRelease(t *testing.T) {
+	m := &bodyMemory{maxBytes: 100, released: make(chan struct{})}
+	held, err := m.reserve(context.Background(), 80)
+	if err != nil {
+		t.Fatalf("reserve(...): unexpected error: %s", err)
+	}
+
+	reserved := make(chan error)
+	go func() {
+		_, err := m.reserve(context.Background(), 50)
+		reserved <- err
+	}()
+
+	select {
+	case <-reserved:
+		t.Fatalf("reserve(...): reserved memory held by another request")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	held.release()
+	select {
+	case err := <-reserved:
+		if err != nil {
+			t.Fatalf("reserve(...): unexpected error: %s", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("reserve(...): still waiting after the memory was released")
+	}
+}
+
+func Test_SendRequest_BufferedBodyMemoryCap(t *testing.T) {
+	type args struct {
+		held         int64
+		requestBody  string
+		responseBody string
+	}
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"BodiesFit": {
+			args: args{
+				held:         40,
+				requestBody:  strings.Repeat("a", 30),
+				responseBody: strings.Repeat("b", 30),
+			},
+		},
+		"RequestWaitsUnderPressure": {
+			args: args{
+				held:        80,
+				requestBody: strings.Repeat("a", 30),
+			},
+			want: want{
+				err: errors.Wrapf(context.DeadlineExceeded, errBodyMemoryWait, 30),
+			},
+		},
+		"ResponseFailsUnderPressure": {
+			args: args{
+				held:         80,
+				requestBody:  strings.Repeat("a", 10),
+				responseBody: strings.Repeat("b", 30),
+			},
+			want: want{
+				err: errors.Errorf(errBodyMemoryExhausted, 100),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			SetMaxBufferedBodyBytes(100)
+			defer SetMaxBufferedBodyBytes(0)
+
+			// Other requests in flight hold part of the memory.
+			held, err := bodies.reserve(context.Background(), tc.args.held)
+			if err != nil {
+				t.Fatalf("reserve(...): unexpected error: %s", err)
+			}
+			defer held.release()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(tc.args.responseBody))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "")
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			body := Data{Encrypted: tc.args.requestBody, Decrypted: tc.args.requestBody}
+			_, err = c.SendRequest(ctx, http.MethodPost, server.URL, body, emptyHeaders, false)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.args.held, bodies.used); diff != "" {
+				t.Errorf("SendRequest(...): -want used bytes after the request, +got used bytes: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCircuitOpenDuration is the time during which no request is sent to a host whose circuit
+	// is open, when no other duration is configured.
+	DefaultCircuitOpenDuration = time.Minute
+
+	errCircuitOpen = "circuit breaker of host %s is open, the request is not sent"
+)
+
+// circuit is the state of the circuit breaker of a host.
+type circuit struct {
+	failures int
+	openedAt time.Time
+}
+
+// circuitBreakers are the circuit breakers of the hosts, shared by all the clients of the provider.
+type circuitBreakers struct {
+	mu               sync.Mutex
+	failureThreshold int
+	openDuration     time.Duration
+	circuits         map[string]*circuit
+	now              func() time.Time
+}
+
+var breakers = &circuitBreakers{
+	openDuration: DefaultCircuitOpenDuration,
+	circuits:     map[string]*circuit{},
+	now:          time.Now,
+}
+
+// SetCircuitBreaker opens the circuit of a host after failureThreshold consecutive failed requests,
+// without response or with a server error, so that no request is sent to the host for openDuration.
+// Requests are sent again afterwards: a success closes the circuit and a failure opens it again.
+// The circuit breakers are disabled when the threshold is not positive.
+func SetCircuitBreaker(failureThreshold int, openDuration time.Duration) {
+	breakers.mu.Lock()
+	defer breakers.mu.Unlock()
+
+	breakers.failureThreshold = failureThreshold
+	breakers.openDuration = openDuration
+	breakers.circuits = map[string]*circuit{}
+}
+
+// CircuitOpen returns true if the circuit of the host of the given URL is open.
+func CircuitOpen(rawURL string) bool {
+	host, ok := circuitHost(rawURL)
+	return ok && breakers.open(host)
+}
+
+// ResetCircuit closes the circuit of the host of the given URL.
+func ResetCircuit(rawURL string) {
+	if host, ok := circuitHost(rawURL); ok {
+		breakers.reset(host)
+	}
+}
+
+func circuitHost(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+
+	return u.Host, true
+}
+
+func (b *circuitBreakers) open(host string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failureThreshold <= 0 {
+		return false
+	}
+
+	c, ok := b.circuits[host]
+	return ok && c.failures >= b.failureThreshold && b.now().Before(c.openedAt.Add(b.openDuration))
+}
+
+func (b *circuitBreakers) reset(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.circuits, host)
+}
+
+// record records the outcome of a request sent to the host.
+func (b *circuitBreakers) record(host string, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failureThreshold <= 0 {
+		return
+	}
+
+	if !failed {
+		delete(b.circuits, host)
+		return
+	}
+
+	c, ok := b.circuits[host]
+	if !ok {
+		c = &circuit{}
+		b.circuits[host] = c
+	}
+	c.failures++
+	if c.failures >= b.failureThreshold {
+		c.openedAt = b.now()
+	}
+}
+
+// requestFailed returns true if a request failed without response or with a server error.
+func requestFailed(response *http.Response, err error) bool {
+	return err != nil || response == nil || response.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_CircuitBreaker(t *testing.T) {
+	type want struct {
+		open     bool
+		attempts int
+		err      bool
+	}
+
+	cases := map[string]struct {
+		failureThreshold int
+		statusCodes      []int
+		reset            bool
+		elapsed          time.Duration
+		want             want
+	}{
+		"Disabled": {
+			failureThreshold: 0,
+			statusCodes:      []int{500, 500, 500},
+			want:             want{open: false, attempts: 4},
+		},
+		"ClosedBelowThreshold": {
+			failureThreshold: 3,
+			statusCodes:      []int{500, 500},
+			want:             want{open: false, attempts: 3},
+		},
+		"ClientErrorsDontOpen": {
+			failureThreshold: 2,
+			statusCodes:      []int{404, 404},
+			want:             want{open: false, attempts: 3},
+		},
+		"SuccessClosesAgain": {
+			failureThreshold: 2,
+			statusCodes:      []int{500, 200, 500},
+			want:             want{open: false, attempts: 4},
+		},
+		"OpenAfterThreshold": {
+			failureThreshold: 2,
+			statusCodes:      []int{500, 503},
+			want:             want{open: true, attempts: 2, err: true},
+		},
+		"ClosedByReset": {
+			failureThreshold: 2,
+			statusCodes:      []int{500, 500},
+			reset:            true,
+			want:             want{open: false, attempts: 3},
+		},
+		"SentAgainAfterOpenDuration": {
+			failureThreshold: 2,
+			statusCodes:      []int{500, 500},
+			elapsed:          2 * time.Minute,
+			want:             want{open: false, attempts: 3},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			breakers.now = func() time.Time { return now }
+			SetCircuitBreaker(tc.failureThreshold, time.Minute)
+			defer func() {
+				breakers.now = time.Now
+				SetCircuitBreaker(0, DefaultCircuitOpenDuration)
+			}()
+
+			attempts := 0
+			statusCode := http.StatusOK
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts++
+				w.WriteHeader(statusCode)
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "")
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			for _, code := range tc.statusCodes {
+				statusCode = code
+				if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, false); err != nil {
+					t.Fatalf("SendRequest(...): unexpected error: %s", err)
+				}
+			}
+
+			if tc.reset {
+				ResetCircuit(server.URL + "/path")
+			}
+			now = now.Add(tc.elapsed)
+
+			if diff := cmp.Diff(tc.want.open, CircuitOpen(server.URL+"/path")); diff != "" {
+				t.Errorf("CircuitOpen(...): -want open, +got open: %s", diff)
+			}
+
+			statusCode = http.StatusOK
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, false)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.attempts, attempts); diff != "" {
+				t.Errorf("SendRequest(...): -want attempts, +got attempts: %s", diff)
+			}
+		})
+	}
+}
@@ -4,34 +4,217 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptrace"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
 )
 
 const (
 	authKey = "Authorization"
+
+	// DefaultConnectTimeout is the maximum time to wait for a connection to be established
+	// when no other timeout is configured.
+	DefaultConnectTimeout = 10 * time.Second
+
+	// DefaultMaxResponseBodyBytes is the maximum size of the response bodies read when no other
+	// limit is configured.
+	DefaultMaxResponseBodyBytes = 10 << 20
+
+	errResponseBodyTooLarge = "response body exceeds the limit of %d bytes"
+
+	// idleConnTimeout is how long the kept-alive connections of a client stay open without being used, so
+	// that the connections of discarded clients are eventually closed.
+	idleConnTimeout = 90 * time.Second
+
+	errCreateCookieJar              = "failed to create cookie jar"
+	errUnknownDuplicateHeaderPolicy = "unknown duplicate header policy %s"
+	errUnknownTLSRenegotiation      = "unknown TLS renegotiation setting %s"
 )
 
 // Client is the interface to interact with Http
 type Client interface {
-	SendRequest(ctx context.Context, method string, url string, body Data, headers Data, skipTLSVerify bool) (resp HttpDetails, err error)
+	SendRequest(ctx context.Context, method string, url string, body Data, headers Data, skipTLSVerify bool, opts ...RequestOption) (resp HttpDetails, err error)
 }
 
 type client struct {
-	log                logging.Logger
-	timeout            time.Duration
-	authorizationToken string
+	log                  logging.Logger
+	timeout              time.Duration
+	authorizationToken   string
+	jar                  http.CookieJar
+	maxRetries           int
+	idempotentMethods    map[string]bool
+	connectTimeout       time.Duration
+	dial                 func(ctx context.Context, network, address string) (net.Conn, error)
+	onTrace              func(method string, timings RequestTimings)
+	duplicateHeaders     string
+	renegotiation        tls.RenegotiationSupport
+	minTLSVersion        uint16
+	cipherSuites         []uint16
+	tlsServerName        string
+	rootCAs              *x509.CertPool // nil verifies the servers against the system roots
+	clientCertificate    func(ctx context.Context) (*tls.Certificate, error)
+	tlsDiagnostics       bool
+	hedgeDelay           time.Duration
+	routeAddress         string
+	routeHost            string
+	routeServerName      string
+	proxyFunc            func(*http.Request) (*url.URL, error)
+	maxBodyBytes         int64
+	checkRedirect        func(request *http.Request, via []*http.Request) error
+	retryConnectionDrops bool
+	tokenSource          TokenSource
+	extraHeaders         map[string]string
+	traceID              string // empty when the trace context isn't propagated
+	redactedHeaders      map[string]bool
+	requestInterceptor   string
+
+	metricsKind           string
+	metricsProviderConfig string
+
+	// transports are the transports of the client by skipTLSVerify, shared by its requests so that they
+	// reuse their connections.
+	transportsMu sync.Mutex
+	transports   map[bool]*http.Transport
+}
+
+const (
+	// DuplicateHeaderPolicyFirst keeps the first value of a header sent several times.
+	DuplicateHeaderPolicyFirst = "first"
+	// DuplicateHeaderPolicyLast keeps the last value of a header sent several times.
+	DuplicateHeaderPolicyLast = "last"
+	// DuplicateHeaderPolicyCombine joins the values of a header sent several times with a comma.
+	DuplicateHeaderPolicyCombine = "combine"
+)
+
+const (
+	// TLSRenegotiationNever disables TLS renegotiation.
+	TLSRenegotiationNever = "never"
+	// TLSRenegotiationOnceAsClient allows the server to request renegotiation once per connection.
+	TLSRenegotiationOnceAsClient = "onceAsClient"
+	// TLSRenegotiationFreelyAsClient allows the server to repeatedly request renegotiation.
+	TLSRenegotiationFreelyAsClient = "freelyAsClient"
+)
+
+// DefaultIdempotentMethods are the HTTP methods considered safe to retry when
+// no other set is configured, as defined by RFC 9110.
+var DefaultIdempotentMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodTrace,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+// ClientOption configures the Http Client.
+type ClientOption func(*client) error
+
+// WithCookieJar keeps the cookies set by responses and sends them on subsequent
+// requests made by the same client. Since a client is created for each reconcile,
+// the cookies are scoped to a single reconcile of a single resource.
+func WithCookieJar() ClientOption {
+	return func(c *client) error {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return errors.Wrap(err, errCreateCookieJar)
+		}
+
+		c.jar = jar
+		return nil
+	}
+}
+
+// WithMaxResponseBodyBytes sets the maximum size of the response bodies, 0 meaning no limit.
+// Requests whose response body is larger fail.
+func WithMaxResponseBodyBytes(maxBytes int64) ClientOption {
+	return func(c *client) error {
+		c.maxBodyBytes = maxBytes
+		return nil
+	}
+}
+
+// WithConnectTimeout sets the maximum time to wait for a connection to be established,
+// independently of the overall timeout of the request.
+func WithConnectTimeout(timeout time.Duration) ClientOption {
+	return func(c *client) error {
+		c.connectTimeout = timeout
+		return nil
+	}
+}
+
+// WithDuplicateHeaderPolicy collapses the response headers sent several times by the server
+// according to the given policy.
+func WithDuplicateHeaderPolicy(policy string) ClientOption {
+	return func(c *client) error {
+		switch policy {
+		case DuplicateHeaderPolicyFirst, DuplicateHeaderPolicyLast, DuplicateHeaderPolicyCombine:
+			c.duplicateHeaders = policy
+			return nil
+		default:
+			return errors.Errorf(errUnknownDuplicateHeaderPolicy, policy)
+		}
+	}
+}
+
+// WithTLSRenegotiation sets whether the server may request TLS renegotiation, which Go
+// disables by default but some legacy servers require.
+func WithTLSRenegotiation(renegotiation string) ClientOption {
+	return func(c *client) error {
+		switch renegotiation {
+		case TLSRenegotiationNever:
+			c.renegotiation = tls.RenegotiateNever
+		case TLSRenegotiationOnceAsClient:
+			c.renegotiation = tls.RenegotiateOnceAsClient
+		case TLSRenegotiationFreelyAsClient:
+			c.renegotiation = tls.RenegotiateFreelyAsClient
+		default:
+			return errors.Errorf(errUnknownTLSRenegotiation, renegotiation)
+		}
+		return nil
+	}
+}
+
+// WithRetryPolicy retries requests that fail before a response is received up to
+// maxRetries times, as long as their method is idempotent. The given idempotent
+// methods override DefaultIdempotentMethods when not empty.
+func WithRetryPolicy(maxRetries int, idempotentMethods []string) ClientOption {
+	return func(c *client) error {
+		if len(idempotentMethods) == 0 {
+			idempotentMethods = DefaultIdempotentMethods
+		}
+
+		c.maxRetries = maxRetries
+		c.idempotentMethods = make(map[string]bool, len(idempotentMethods))
+		for _, method := range idempotentMethods {
+			c.idempotentMethods[strings.ToUpper(method)] = true
+		}
+		return nil
+	}
 }
 
 type HttpResponse struct {
 	Body       string              `json:"body"`
 	Headers    map[string][]string `json:"headers"`
 	StatusCode int                 `json:"statusCode"`
+
+	// DurationMs is how long the request took, in milliseconds, until its response body was read.
+	DurationMs int64 `json:"durationMs,omitempty"`
+
+	// Proto is the HTTP protocol version of the response, e.g. HTTP/1.1 or HTTP/2.0.
+	Proto string `json:"proto,omitempty"`
 }
 
 type Data struct {
@@ -49,10 +232,15 @@ type HttpRequest struct {
 type HttpDetails struct {
 	HttpResponse HttpResponse
 	HttpRequest  HttpRequest
+
+	// Redirected is true when the response was received from another URL than the requested one,
+	// after following redirects.
+	Redirected bool
 }
 
 // SendRequest sends an HTTP request to the specified URL with the given method, body, headers and skipTLSVerify.
-func (hc *client) SendRequest(ctx context.Context, method string, url string, body Data, headers Data, skipTLSVerify bool) (details HttpDetails, err error) {
+// The options override the configuration of the client for this request only.
+func (hc *client) SendRequest(ctx context.Context, method string, url string, body Data, headers Data, skipTLSVerify bool, opts ...RequestOption) (details HttpDetails, err error) {
 	requestBody := []byte(body.Decrypted.(string))
 
 	// request contains the HTTP request that will be sent.
@@ -62,7 +250,7 @@ func (hc *client) SendRequest(ctx context.Context, method string, url string, bo
 	requestDetails := HttpRequest{
 		URL:     url,
 		Body:    body.Encrypted.(string),
-		Headers: headers.Encrypted.(map[string][]string),
+		Headers: hc.redactHeaders(headers.Encrypted.(map[string][]string)),
 		Method:  method,
 	}
 
@@ -77,39 +265,89 @@ func (hc *client) SendRequest(ctx context.Context, method string, url string, bo
 			request.Header.Add(key, value)
 		}
 	}
+	hc.addExtraHeaders(request)
+	hc.addTraceParent(request)
+
+	if hc.routeHost != "" {
+		request.Host = hc.routeHost
+	}
 
 	// Add the authorization token to the request if it doesn't already exist.
-	if _, exists := request.Header[authKey]; !exists && hc.authorizationToken != "" {
-		request.Header[authKey] = []string{hc.authorizationToken}
+	if _, exists := request.Header[authKey]; !exists {
+		authorization, err := hc.authorization(ctx)
+		if err != nil {
+			return HttpDetails{
+				HttpRequest: requestDetails,
+			}, err
+		}
+		if authorization != "" {
+			request.Header[authKey] = []string{authorization}
+		}
+	}
+
+	if request, err = hc.interceptRequest(request); err != nil {
+		return HttpDetails{
+			HttpRequest: requestDetails,
+		}, err
+	}
+
+	if breakers.open(request.URL.Host) {
+		return HttpDetails{
+			HttpRequest: requestDetails,
+		}, errors.Errorf(errCircuitOpen, request.URL.Host)
 	}
 
 	client := &http.Client{
-		Transport: &http.Transport{
-			// #nosec G402
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: skipTLSVerify},
-			Proxy:           http.ProxyFromEnvironment, // Use proxy settings from environment
-		},
-		Timeout: hc.timeout,
+		Transport:     hc.transport(skipTLSVerify),
+		CheckRedirect: hc.checkRedirect,
+		Timeout:       hc.requestConfig(opts).timeout,
+		Jar:           hc.jar,
 	}
 
-	response, err := client.Do(request)
+	var trace *requestTrace
+	if hc.onTrace != nil {
+		trace = newRequestTrace()
+		request = request.WithContext(httptrace.WithClientTrace(request.Context(), trace.clientTrace()))
+	}
+
+	memory, err := bodies.reserve(ctx, int64(len(requestBody)))
 	if err != nil {
 		return HttpDetails{
 			HttpRequest: requestDetails,
 		}, err
 	}
+	defer memory.release()
 
-	responsebody, err := io.ReadAll(response.Body)
+	start := time.Now()
+	response, err := hc.do(client, request)
+	hc.observeRequest(method, responseStatusCode(response), time.Since(start))
+	breakers.record(request.URL.Host, requestFailed(response, err))
+	if trace != nil {
+		hc.onTrace(method, trace.result())
+	}
+	if err != nil && hc.tlsDiagnostics {
+		err = diagnoseTLSError(err)
+	}
 	if err != nil {
 		return HttpDetails{
 			HttpRequest: requestDetails,
 		}, err
 	}
 
+	responsebody, err := hc.readBody(memory.reader(response.Body))
+	if err != nil {
+		_ = response.Body.Close()
+		return HttpDetails{
+			HttpRequest: requestDetails,
+		}, err
+	}
+
 	beautifiedResponse := HttpResponse{
 		Body:       string(responsebody),
-		Headers:    response.Header,
+		Headers:    collapseHeaders(response.Header, hc.duplicateHeaders),
 		StatusCode: response.StatusCode,
+		DurationMs: time.Since(start).Milliseconds(),
+		Proto:      response.Proto,
 	}
 
 	err = response.Body.Close()
@@ -121,19 +359,176 @@ func (hc *client) SendRequest(ctx context.Context, method string, url string, bo
 
 	hc.log.Info(fmt.Sprint("http request sent: ", toJSON(requestDetails)))
 
+	redirected := response.Request != nil && response.Request.URL.String() != request.URL.String()
+	if redirected {
+		hc.log.Debug("http request redirected", "method", response.Request.Method, "url", response.Request.URL.String())
+	}
+
 	return HttpDetails{
 		HttpResponse: beautifiedResponse,
 		HttpRequest:  requestDetails,
+		Redirected:   redirected,
 	}, nil
 }
 
+// responseStatusCode returns the status code of a response, zero when there is none.
+func responseStatusCode(response *http.Response) int {
+	if response == nil {
+		return 0
+	}
+	return response.StatusCode
+}
+
+// readBody reads the response body, failing when it is larger than the maximum size of the
+// response bodies, so that a misbehaving server can't exhaust the memory of the provider.
+func (hc *client) readBody(body io.Reader) ([]byte, error) {
+	if hc.maxBodyBytes <= 0 {
+		return io.ReadAll(body)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, hc.maxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > hc.maxBodyBytes {
+		return nil, errors.Errorf(errResponseBodyTooLarge, hc.maxBodyBytes)
+	}
+
+	return data, nil
+}
+
+// transport returns the transport of the requests sent by the client, created on first use and then shared
+// by the requests with the same skipTLSVerify, so that they reuse the kept-alive connections.
+func (hc *client) transport(skipTLSVerify bool) *http.Transport {
+	hc.transportsMu.Lock()
+	defer hc.transportsMu.Unlock()
+
+	if transport, ok := hc.transports[skipTLSVerify]; ok {
+		return transport
+	}
+
+	// HTTP/2 is only attempted by default without custom TLS configuration nor dialer, which the transport
+	// always has.
+	transport := &http.Transport{
+		TLSClientConfig:   hc.tlsConfig(skipTLSVerify),
+		Proxy:             hc.proxy(),
+		DialContext:       hc.dialContext,
+		IdleConnTimeout:   idleConnTimeout,
+		ForceAttemptHTTP2: true,
+	}
+	if hc.transports == nil {
+		hc.transports = map[bool]*http.Transport{}
+	}
+	hc.transports[skipTLSVerify] = transport
+	return transport
+}
+
+// tlsConfig returns the TLS configuration of the requests sent by the client.
+func (hc *client) tlsConfig(skipTLSVerify bool) *tls.Config {
+	// #nosec G402
+	return &tls.Config{
+		InsecureSkipVerify:   skipTLSVerify,
+		Renegotiation:        hc.renegotiation,
+		ServerName:           hc.serverName(),
+		RootCAs:              hc.rootCAs,
+		MinVersion:           hc.minTLSVersion,
+		CipherSuites:         hc.cipherSuites,
+		GetClientCertificate: hc.getClientCertificate(),
+	}
+}
+
+// dialContext dials the given address, failing fast when the connection isn't established
+// within the connect timeout.
+func (hc *client) dialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if hc.connectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, hc.connectTimeout)
+		defer cancel()
+	}
+
+	return hc.dial(ctx, network, hc.routedAddress(address))
+}
+
+// do sends the request, retrying it according to the retry policy of the client
+// when it fails before a response is received.
+func (hc *client) do(httpClient *http.Client, request *http.Request) (*http.Response, error) {
+	response, err := hc.sendRetryingDrops(httpClient, request)
+	for attempt := 1; err != nil && attempt <= hc.maxRetries && hc.isIdempotent(request.Method); attempt++ {
+		if request.Context().Err() != nil {
+			break
+		}
+
+		if request.GetBody != nil {
+			body, bodyErr := request.GetBody()
+			if bodyErr != nil {
+				break
+			}
+			request.Body = body
+		}
+
+		hc.log.Debug("retrying http request", "method", request.Method, "url", request.URL.String(), "attempt", attempt, "error", err.Error())
+		response, err = hc.sendRetryingDrops(httpClient, request)
+	}
+
+	return response, err
+}
+
+// isIdempotent returns true if the given method is safe to retry, according to the retry policy of the
+// client or else to the default idempotent methods.
+func (hc *client) isIdempotent(method string) bool {
+	if hc.idempotentMethods == nil {
+		return slices.Contains(DefaultIdempotentMethods, strings.ToUpper(method))
+	}
+
+	return hc.idempotentMethods[strings.ToUpper(method)]
+}
+
 // NewClient returns a new Http Client
-func NewClient(log logging.Logger, timeout time.Duration, authorizationToken string) (Client, error) {
-	return &client{
-		log:                log,
-		timeout:            timeout,
-		authorizationToken: authorizationToken,
-	}, nil
+func NewClient(log logging.Logger, timeout time.Duration, authorizationToken string, opts ...ClientOption) (Client, error) {
+	c := &client{
+		log:                  log,
+		timeout:              timeout,
+		authorizationToken:   authorizationToken,
+		connectTimeout:       DefaultConnectTimeout,
+		maxBodyBytes:         DefaultMaxResponseBodyBytes,
+		retryConnectionDrops: true,
+		dial:                 (&net.Dialer{}).DialContext,
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// collapseHeaders collapses the values of the headers sent several times according to the given
+// policy. The headers are returned as is when no policy is set.
+func collapseHeaders(headers http.Header, policy string) map[string][]string {
+	if policy == "" {
+		return headers
+	}
+
+	collapsed := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		if len(values) <= 1 {
+			collapsed[key] = values
+			continue
+		}
+
+		switch policy {
+		case DuplicateHeaderPolicyFirst:
+			collapsed[key] = []string{values[0]}
+		case DuplicateHeaderPolicyLast:
+			collapsed[key] = []string{values[len(values)-1]}
+		case DuplicateHeaderPolicyCombine:
+			collapsed[key] = []string{strings.Join(values, ", ")}
+		}
+	}
+
+	return collapsed
 }
 
 // toJSON converts the request to a JSON string.
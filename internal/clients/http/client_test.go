@@ -0,0 +1,774 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+var (
+	emptyBody    = Data{Encrypted: "", Decrypted: ""}
+	emptyHeaders = Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+)
+
+func Test_SendRequest_CookieJar(t *testing.T) {
+	type args struct {
+		opts []ClientOption
+	}
+	type want struct {
+		cookie string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"CookieSentOnSubsequentRequest": {
+			args: args{
+				opts: []ClientOption{WithCookieJar()},
+			},
+			want: want{
+				cookie: "session=abc123",
+			},
+		},
+		"CookieNotSentWithoutJar": {
+			args: args{},
+			want: want{
+				cookie: "",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var gotCookie string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/login" {
+					http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123"})
+					return
+				}
+				gotCookie = r.Header.Get("Cookie")
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			for _, path := range []string{"/login", "/users"} {
+				if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL+path, emptyBody, emptyHeaders, false); err != nil {
+					t.Fatalf("SendRequest(...): unexpected error: %s", err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.cookie, gotCookie); diff != "" {
+				t.Fatalf("SendRequest(...): -want cookie, +got cookie: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_RetryPolicy(t *testing.T) {
+	type args struct {
+		method string
+		opts   []ClientOption
+	}
+	type want struct {
+		attempts int
+		err      bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"PostMarkedIdempotentIsRetried": {
+			args: args{
+				method: http.MethodPost,
+				opts:   []ClientOption{WithRetryPolicy(1, []string{"post", http.MethodGet})},
+			},
+			want: want{
+				attempts: 2,
+			},
+		},
+		"DeleteNotMarkedIdempotentIsNotRetried": {
+			args: args{
+				method: http.MethodDelete,
+				opts:   []ClientOption{WithRetryPolicy(1, []string{http.MethodPost})},
+			},
+			want: want{
+				attempts: 1,
+				err:      true,
+			},
+		},
+		"PostNotRetriedByDefault": {
+			args: args{
+				method: http.MethodPost,
+				opts:   []ClientOption{WithRetryPolicy(1, nil)},
+			},
+			want: want{
+				attempts: 1,
+				err:      true,
+			},
+		},
+		"DeleteRetriedByDefault": {
+			args: args{
+				method: http.MethodDelete,
+				opts:   []ClientOption{WithRetryPolicy(1, nil)},
+			},
+			want: want{
+				attempts: 2,
+			},
+		},
+		"NoRetriesWithoutPolicy": {
+			args: args{
+				method: http.MethodGet,
+			},
+			want: want{
+				attempts: 1,
+				err:      true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts == 1 {
+					// Drop the connection so that the first attempt fails before a response is received.
+					conn, _, err := w.(http.Hijacker).Hijack()
+					if err != nil {
+						t.Fatalf("Hijack(): unexpected error: %s", err)
+					}
+					conn.Close()
+				}
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			body := Data{Encrypted: "{}", Decrypted: "{}"}
+			_, err = c.SendRequest(context.Background(), tc.args.method, server.URL, body, emptyHeaders, false)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.attempts, attempts); diff != "" {
+				t.Fatalf("SendRequest(...): -want attempts, +got attempts: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_ConnectTimeout(t *testing.T) {
+	type args struct {
+		unreachable  bool
+		responseWait time.Duration
+	}
+	type want struct {
+		err         bool
+		maxDuration time.Duration
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UnreachableHostFailsFast": {
+			args: args{
+				unreachable: true,
+			},
+			want: want{
+				err:         true,
+				maxDuration: time.Second,
+			},
+		},
+		"SlowResponseUsesFullTimeout": {
+			args: args{
+				responseWait: 200 * time.Millisecond,
+			},
+			want: want{
+				err:         false,
+				maxDuration: 5 * time.Second,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(tc.args.responseWait)
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "", WithConnectTimeout(100*time.Millisecond))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+			if tc.args.unreachable {
+				// Simulate a host that never completes the connection.
+				c.(*client).dial = func(ctx context.Context, _, _ string) (net.Conn, error) {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				}
+			}
+
+			start := time.Now()
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, false)
+			elapsed := time.Since(start)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if elapsed > tc.want.maxDuration {
+				t.Fatalf("SendRequest(...): took %s, expected at most %s", elapsed, tc.want.maxDuration)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_DuplicateHeaderPolicy(t *testing.T) {
+	type args struct {
+		opts []ClientOption
+	}
+	type want struct {
+		values []string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"First": {
+			args: args{
+				opts: []ClientOption{WithDuplicateHeaderPolicy(DuplicateHeaderPolicyFirst)},
+			},
+			want: want{
+				values: []string{"a"},
+			},
+		},
+		"Last": {
+			args: args{
+				opts: []ClientOption{WithDuplicateHeaderPolicy(DuplicateHeaderPolicyLast)},
+			},
+			want: want{
+				values: []string{"c"},
+			},
+		},
+		"Combine": {
+			args: args{
+				opts: []ClientOption{WithDuplicateHeaderPolicy(DuplicateHeaderPolicyCombine)},
+			},
+			want: want{
+				values: []string{"a, b, c"},
+			},
+		},
+		"NoPolicyKeepsAllValues": {
+			args: args{},
+			want: want{
+				values: []string{"a", "b", "c"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for _, value := range []string{"a", "b", "c"} {
+					w.Header().Add("X-Duplicate", value)
+				}
+				w.Header().Set("X-Single", "single")
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want.values, details.HttpResponse.Headers["X-Duplicate"]); diff != "" {
+				t.Fatalf("SendRequest(...): -want header values, +got header values: %s", diff)
+			}
+			if diff := cmp.Diff([]string{"single"}, details.HttpResponse.Headers["X-Single"]); diff != "" {
+				t.Fatalf("SendRequest(...): -want header values, +got header values: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_WithDuplicateHeaderPolicy(t *testing.T) {
+	_, err := NewClient(logging.NewNopLogger(), time.Minute, "", WithDuplicateHeaderPolicy("unknown"))
+	if diff := cmp.Diff(errors.Errorf(errUnknownDuplicateHeaderPolicy, "unknown"), err, test.EquateErrors()); diff != "" {
+		t.Fatalf("NewClient(...): -want error, +got error: %s", diff)
+	}
+}
+
+func Test_WithTLSRenegotiation(t *testing.T) {
+	type args struct {
+		opts []ClientOption
+	}
+	type want struct {
+		renegotiation tls.RenegotiationSupport
+		err           error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Default": {
+			args: args{},
+			want: want{
+				renegotiation: tls.RenegotiateNever,
+			},
+		},
+		"Never": {
+			args: args{
+				opts: []ClientOption{WithTLSRenegotiation(TLSRenegotiationNever)},
+			},
+			want: want{
+				renegotiation: tls.RenegotiateNever,
+			},
+		},
+		"OnceAsClient": {
+			args: args{
+				opts: []ClientOption{WithTLSRenegotiation(TLSRenegotiationOnceAsClient)},
+			},
+			want: want{
+				renegotiation: tls.RenegotiateOnceAsClient,
+			},
+		},
+		"FreelyAsClient": {
+			args: args{
+				opts: []ClientOption{WithTLSRenegotiation(TLSRenegotiationFreelyAsClient)},
+			},
+			want: want{
+				renegotiation: tls.RenegotiateFreelyAsClient,
+			},
+		},
+		"Unknown": {
+			args: args{
+				opts: []ClientOption{WithTLSRenegotiation("always")},
+			},
+			want: want{
+				err: errors.Errorf(errUnknownTLSRenegotiation, "always"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", tc.args.opts...)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("NewClient(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.want.renegotiation, c.(*client).tlsConfig(false).Renegotiation); diff != "" {
+				t.Fatalf("tlsConfig(...): -want renegotiation, +got renegotiation: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_ChunkedResponse(t *testing.T) {
+	chunks := []string{`{"items":[`, `"a",`, `"b",`, `"c"`, `]}`}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Flushing each chunk before the handler returns makes the server use chunked
+		// encoding without a Content-Length.
+		for _, chunk := range chunks {
+			if _, err := w.Write([]byte(chunk)); err != nil {
+				t.Errorf("Write(...): unexpected error: %s", err)
+				return
+			}
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(logging.NewNopLogger(), time.Minute, "")
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %s", err)
+	}
+
+	details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, false)
+	if err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(strings.Join(chunks, ""), details.HttpResponse.Body); diff != "" {
+		t.Fatalf("SendRequest(...): -want body, +got body: %s", diff)
+	}
+	if _, ok := details.HttpResponse.Headers["Content-Length"]; ok {
+		t.Fatalf("SendRequest(...): expected a chunked response without Content-Length, got %v", details.HttpResponse.Headers)
+	}
+}
+
+func Test_SendRequest_DurationAndProto(t *testing.T) {
+	delay := 50 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(logging.NewNopLogger(), time.Minute, "")
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %s", err)
+	}
+
+	details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, false)
+	if err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff("HTTP/1.1", details.HttpResponse.Proto); diff != "" {
+		t.Errorf("SendRequest(...): -want proto, +got proto: %s", diff)
+	}
+	if details.HttpResponse.DurationMs < delay.Milliseconds() {
+		t.Errorf("SendRequest(...): want a duration of at least %dms, got %dms", delay.Milliseconds(), details.HttpResponse.DurationMs)
+	}
+}
+
+func Test_SendRequest_HTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	c, err := NewClient(logging.NewNopLogger(), time.Minute, "")
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %s", err)
+	}
+
+	details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, true)
+	if err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff("HTTP/2.0", details.HttpResponse.Proto); diff != "" {
+		t.Errorf("SendRequest(...): -want proto, +got proto: %s", diff)
+	}
+}
+
+func Test_SendRequest_MaxResponseBodyBytes(t *testing.T) {
+	type args struct {
+		opts     []ClientOption
+		bodySize int
+	}
+	type want struct {
+		err      error
+		bodySize int
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UnderLimit": {
+			args: args{
+				opts:     []ClientOption{WithMaxResponseBodyBytes(1024)},
+				bodySize: 1024,
+			},
+			want: want{
+				bodySize: 1024,
+			},
+		},
+		"OverLimit": {
+			args: args{
+				opts:     []ClientOption{WithMaxResponseBodyBytes(1024)},
+				bodySize: 1025,
+			},
+			want: want{
+				err: errors.Errorf(errResponseBodyTooLarge, 1024),
+			},
+		},
+		"OverDefaultLimit": {
+			args: args{
+				bodySize: DefaultMaxResponseBodyBytes + 1,
+			},
+			want: want{
+				err: errors.Errorf(errResponseBodyTooLarge, DefaultMaxResponseBodyBytes),
+			},
+		},
+		"Unlimited": {
+			args: args{
+				opts:     []ClientOption{WithMaxResponseBodyBytes(0)},
+				bodySize: DefaultMaxResponseBodyBytes + 1,
+			},
+			want: want{
+				bodySize: DefaultMaxResponseBodyBytes + 1,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(strings.Repeat("a", tc.args.bodySize)))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, false)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.bodySize, len(details.HttpResponse.Body)); diff != "" {
+				t.Fatalf("SendRequest(...): -want body size, +got body size: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_TLSServerName(t *testing.T) {
+	type want struct {
+		err        bool
+		serverName string
+	}
+
+	cases := map[string]struct {
+		serverName string
+		want       want
+	}{
+		"ServerNameMatchingTheCertificate": {
+			serverName: "example.com",
+			want: want{
+				serverName: "example.com",
+			},
+		},
+		"ServerNameNotMatchingTheCertificate": {
+			serverName: "other.example.org",
+			want: want{
+				err:        true,
+				serverName: "other.example.org",
+			},
+		},
+		"IPAddressWithoutServerName": {
+			want: want{
+				// The certificate is issued for 127.0.0.1, which is verified but not sent as SNI.
+				serverName: "",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			// The certificate of the test server is issued for example.com and 127.0.0.1.
+			var gotServerName string
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+			server.TLS = &tls.Config{
+				GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+					gotServerName = hello.ServerName
+					return nil, nil
+				},
+			}
+			server.StartTLS()
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "", WithRootCAs(certificatePEM(server.Certificate())), WithTLSConfig(TLSConfigData{ServerName: tc.serverName}))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			// The URL host is an IP address, the certificate is verified against the server name.
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, false)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s (error: %v)", diff, err)
+			}
+			if tc.want.err && !strings.Contains(err.Error(), tc.serverName) {
+				t.Errorf("SendRequest(...): want a verification error for %s, got: %s", tc.serverName, err)
+			}
+			if diff := cmp.Diff(tc.want.serverName, gotServerName); diff != "" {
+				t.Errorf("SendRequest(...): -want SNI, +got SNI: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_ExtraHeaders(t *testing.T) {
+	type args struct {
+		opts    []ClientOption
+		headers map[string][]string
+	}
+	type want struct {
+		attempt    string
+		generation string
+	}
+
+	extraHeaders := map[string]string{"X-Reconcile-Attempt": "2", "X-Resource-Generation": "5"}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ExtraHeadersSent": {
+			args: args{
+				opts:    []ClientOption{WithExtraHeaders(extraHeaders)},
+				headers: map[string][]string{},
+			},
+			want: want{
+				attempt:    "2",
+				generation: "5",
+			},
+		},
+		"RequestHeadersTakePrecedence": {
+			args: args{
+				opts:    []ClientOption{WithExtraHeaders(extraHeaders)},
+				headers: map[string][]string{"X-Reconcile-Attempt": {"manual"}},
+			},
+			want: want{
+				attempt:    "manual",
+				generation: "5",
+			},
+		},
+		"NoExtraHeaders": {
+			args: args{
+				headers: map[string][]string{},
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var got want
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = want{attempt: r.Header.Get("X-Reconcile-Attempt"), generation: r.Header.Get("X-Resource-Generation")}
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			headers := Data{Encrypted: tc.args.headers, Decrypted: tc.args.headers}
+			if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, headers, false); err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Fatalf("SendRequest(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_GetWithBody(t *testing.T) {
+	const query = `{"query":{"match":{"user":"{{es-creds:default:user}}"}}}`
+	const sensitiveQuery = `{"query":{"match":{"user":"john"}}}`
+
+	type args struct {
+		opts         []ClientOption
+		dropAttempts int
+	}
+
+	cases := map[string]struct {
+		args args
+	}{
+		"BodySent": {
+			args: args{},
+		},
+		"BodySentAgainOnRetry": {
+			args: args{
+				opts:         []ClientOption{WithRetryPolicy(1, nil)},
+				dropAttempts: 1,
+			},
+		},
+		"BodySentWithHedging": {
+			args: args{
+				opts: []ClientOption{WithHedging(time.Minute)},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			var received, method string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts <= tc.args.dropAttempts {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					if err != nil {
+						t.Fatalf("Hijack(): unexpected error: %s", err)
+					}
+					conn.Close()
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				received, method = string(body), r.Method
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			body := Data{Encrypted: query, Decrypted: sensitiveQuery}
+			details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL+"/users/_search", body, emptyHeaders, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(http.MethodGet, method); diff != "" {
+				t.Errorf("SendRequest(...): -want method, +got method: %s", diff)
+			}
+			if diff := cmp.Diff(sensitiveQuery, received); diff != "" {
+				t.Errorf("SendRequest(...): -want received body, +got received body: %s", diff)
+			}
+			if diff := cmp.Diff(query, details.HttpRequest.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want recorded body, +got recorded body: %s", diff)
+			}
+		})
+	}
+}
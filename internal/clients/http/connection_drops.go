@@ -0,0 +1,55 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+	"syscall"
+)
+
+// WithConnectionDropRetries sets whether the idempotent requests whose kept-alive connection is closed by
+// the server are retried once. These retries are transparent: they don't count against the retry policy.
+func WithConnectionDropRetries(enabled bool) ClientOption {
+	return func(c *client) error {
+		c.retryConnectionDrops = enabled
+		return nil
+	}
+}
+
+// sendRetryingDrops sends the request, retrying it once when it is idempotent and the server closed the
+// reused connection it was sent on, e.g. after a Connection: close or an idle timeout on the server side.
+func (hc *client) sendRetryingDrops(httpClient *http.Client, request *http.Request) (*http.Response, error) {
+	if !hc.retryConnectionDrops || !hc.isIdempotent(request.Method) {
+		return hc.send(httpClient, request)
+	}
+
+	var reused atomic.Bool
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			reused.Store(info.Reused)
+		},
+	}
+
+	response, err := hc.send(httpClient, request.WithContext(httptrace.WithClientTrace(request.Context(), trace)))
+	if err == nil || !reused.Load() || !isConnectionDrop(err) || request.Context().Err() != nil {
+		return response, err
+	}
+
+	if request.GetBody != nil {
+		body, bodyErr := request.GetBody()
+		if bodyErr != nil {
+			return response, err
+		}
+		request.Body = body
+	}
+
+	hc.log.Debug("retrying http request dropped by the server", "method", request.Method, "url", request.URL.String(), "error", err.Error())
+	return hc.send(httpClient, request)
+}
+
+// isConnectionDrop returns true if the error is caused by the server closing the connection.
+func isConnectionDrop(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE)
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_ConnectionDrops(t *testing.T) {
+	type args struct {
+		method string
+		opts   []ClientOption
+	}
+	type want struct {
+		err      bool
+		attempts int32
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DropOnReusedConnectionIsRetried": {
+			args: args{
+				method: http.MethodPut,
+			},
+			want: want{
+				attempts: 2,
+			},
+		},
+		"RetryDisabled": {
+			args: args{
+				method: http.MethodPut,
+				opts:   []ClientOption{WithConnectionDropRetries(false)},
+			},
+			want: want{
+				err:      true,
+				attempts: 1,
+			},
+		},
+		"NonIdempotentRequestIsNotRetried": {
+			args: args{
+				method: http.MethodPost,
+			},
+			want: want{
+				err:      true,
+				attempts: 1,
+			},
+		},
+		"RetriedWithoutCountingAgainstRetryPolicy": {
+			args: args{
+				method: http.MethodPut,
+				opts:   []ClientOption{WithRetryPolicy(0, nil)},
+			},
+			want: want{
+				attempts: 2,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			// The server redirects /first to /second on the same kept-alive connection, then closes that
+			// connection without responding the first time /second is requested.
+			var attempts, drops int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/first":
+					atomic.AddInt32(&attempts, 1)
+					http.Redirect(w, r, "/second", http.StatusTemporaryRedirect)
+				case "/second":
+					if atomic.AddInt32(&drops, 1) == 1 {
+						conn, _, err := w.(http.Hijacker).Hijack()
+						if err != nil {
+							t.Errorf("Hijack(): unexpected error: %s", err)
+							return
+						}
+						conn.Close()
+					}
+				}
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			body := Data{Encrypted: "{}", Decrypted: "{}"}
+			_, err = c.SendRequest(context.Background(), tc.args.method, server.URL+"/first", body, emptyHeaders, false)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s (error: %v)", diff, err)
+			}
+			if diff := cmp.Diff(tc.want.attempts, atomic.LoadInt32(&attempts)); diff != "" {
+				t.Fatalf("SendRequest(...): -want attempts, +got attempts: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_ReusesConnections(t *testing.T) {
+	var remoteAddrs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteAddrs = append(remoteAddrs, r.RemoteAddr)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(logging.NewNopLogger(), time.Minute, "")
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, false); err != nil {
+			t.Fatalf("SendRequest(...): unexpected error: %s", err)
+		}
+	}
+
+	// The second request is sent on the connection kept alive by the first one, which lets the connection
+	// drops be retried across requests.
+	if len(remoteAddrs) != 2 || remoteAddrs[0] != remoteAddrs[1] {
+		t.Errorf("SendRequest(...): the requests of a client must share their connections, got %v", remoteAddrs)
+	}
+}
+
+func Test_SendRequest_ConnectionDroppedBetweenRequests(t *testing.T) {
+	// The server closes the connection kept alive by the first request without responding to the second one.
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 2 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("Hijack(): unexpected error: %s", err)
+				return
+			}
+			conn.Close()
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "")
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, false); err != nil {
+			t.Fatalf("SendRequest(...): unexpected error: %s", err)
+		}
+	}
+
+	// The second request is retried once on a new connection.
+	if diff := cmp.Diff(int32(3), atomic.LoadInt32(&requests)); diff != "" {
+		t.Errorf("SendRequest(...): -want requests, +got requests: %s", diff)
+	}
+}
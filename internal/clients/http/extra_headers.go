@@ -0,0 +1,20 @@
+package http
+
+import "net/http"
+
+// WithExtraHeaders sets the given headers on every request sent, unless the request already sets them.
+func WithExtraHeaders(headers map[string]string) ClientOption {
+	return func(c *client) error {
+		c.extraHeaders = headers
+		return nil
+	}
+}
+
+// addExtraHeaders sets the extra headers the request doesn't set.
+func (hc *client) addExtraHeaders(request *http.Request) {
+	for key, value := range hc.extraHeaders {
+		if request.Header.Get(key) == "" {
+			request.Header.Set(key, value)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WithHedging hedges the GET and HEAD requests without a body that didn't get a response within the given delay: a second,
+// identical request is sent and the response of whichever returns first is used, the other one is cancelled.
+// This trades a few duplicate requests for a lower tail latency.
+func WithHedging(delay time.Duration) ClientOption {
+	return func(c *client) error {
+		c.hedgeDelay = delay
+		return nil
+	}
+}
+
+// hedgeable returns true if the request can be hedged, which requires it to be a GET or HEAD request
+// without a body.
+func hedgeable(request *http.Request) bool {
+	if request.Body != nil && request.Body != http.NoBody {
+		return false
+	}
+
+	return request.Method == http.MethodGet || request.Method == http.MethodHead
+}
+
+// hedgeResult is the outcome of one of the requests of a hedge.
+type hedgeResult struct {
+	attempt  int
+	response *http.Response
+	err      error
+}
+
+// send sends the request, hedging it when hedging is enabled for its method.
+func (hc *client) send(httpClient *http.Client, request *http.Request) (*http.Response, error) {
+	if hc.hedgeDelay <= 0 || !hedgeable(request) {
+		return httpClient.Do(request)
+	}
+
+	return hc.hedge(httpClient, request)
+}
+
+// hedge sends the request and, if it didn't get a response within the hedge delay, a second one. The first
+// response is returned and the other request is cancelled. An error is only returned when all the requests sent
+// failed, the retries of the client apply on top of it.
+func (hc *client) hedge(httpClient *http.Client, request *http.Request) (*http.Response, error) {
+	results := make(chan hedgeResult, 2)
+	var cancels []context.CancelFunc
+	start := func() {
+		ctx, cancel := context.WithCancel(request.Context())
+		attempt := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			response, err := httpClient.Do(request.Clone(ctx))
+			results <- hedgeResult{attempt: attempt, response: response, err: err}
+		}()
+	}
+
+	start()
+	timer := time.NewTimer(hc.hedgeDelay)
+	defer timer.Stop()
+
+	pending := 1
+	for {
+		select {
+		case <-timer.C:
+			hc.log.Debug("hedging http request", "method", request.Method, "url", request.URL.String(), "delay", hc.hedgeDelay.String())
+			pending++
+			start()
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				for attempt, cancel := range cancels {
+					if attempt != result.attempt {
+						cancel()
+					}
+				}
+				go discardResults(results, pending)
+
+				result.response.Body = &cancelOnClose{ReadCloser: result.response.Body, cancel: cancels[result.attempt]}
+				return result.response, nil
+			}
+
+			// A failed request waits for the other one of the hedge, if any.
+			cancels[result.attempt]()
+			if pending == 0 {
+				return nil, result.err
+			}
+		}
+	}
+}
+
+// discardResults closes the responses of the requests that lost a hedge.
+func discardResults(results <-chan hedgeResult, pending int) {
+	for ; pending > 0; pending-- {
+		if result := <-results; result.err == nil {
+			_ = result.response.Body.Close()
+		}
+	}
+}
+
+// cancelOnClose releases the context of the request that won a hedge once its response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_Hedging(t *testing.T) {
+	type args struct {
+		method    string
+		firstWait time.Duration
+	}
+	type want struct {
+		requests    int32
+		body        string
+		loserCancel bool
+		minDuration time.Duration
+		maxDuration time.Duration
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SlowRequestIsHedgedAndFasterResponseWins": {
+			args: args{
+				method:    http.MethodGet,
+				firstWait: 5 * time.Second,
+			},
+			want: want{
+				requests:    2,
+				body:        "2",
+				loserCancel: true,
+				minDuration: 100 * time.Millisecond,
+				maxDuration: 2 * time.Second,
+			},
+		},
+		"FastRequestIsNotHedged": {
+			args: args{
+				method: http.MethodGet,
+			},
+			want: want{
+				requests:    1,
+				body:        "1",
+				maxDuration: time.Second,
+			},
+		},
+		"NonIdempotentRequestIsNotHedged": {
+			args: args{
+				method:    http.MethodPost,
+				firstWait: 300 * time.Millisecond,
+			},
+			want: want{
+				requests:    1,
+				body:        "1",
+				maxDuration: 2 * time.Second,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var requests int32
+			cancelled := make(chan struct{}, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&requests, 1)
+				if n == 1 {
+					select {
+					case <-time.After(tc.args.firstWait):
+					case <-r.Context().Done():
+						cancelled <- struct{}{}
+						return
+					}
+				}
+				_, _ = w.Write([]byte{byte('0' + n)})
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 10*time.Second, "", WithHedging(100*time.Millisecond))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			start := time.Now()
+			got, err := c.SendRequest(context.Background(), tc.args.method, server.URL, emptyBody, emptyHeaders, false)
+			elapsed := time.Since(start)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.body, got.HttpResponse.Body); diff != "" {
+				t.Fatalf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, atomic.LoadInt32(&requests)); diff != "" {
+				t.Fatalf("SendRequest(...): -want requests, +got requests: %s", diff)
+			}
+			if elapsed < tc.want.minDuration {
+				t.Fatalf("SendRequest(...): took %s, the hedge was expected after %s", elapsed, tc.want.minDuration)
+			}
+			if elapsed > tc.want.maxDuration {
+				t.Fatalf("SendRequest(...): took %s, expected at most %s", elapsed, tc.want.maxDuration)
+			}
+			if tc.want.loserCancel {
+				select {
+				case <-cancelled:
+				case <-time.After(2 * time.Second):
+					t.Fatalf("SendRequest(...): the slower request of the hedge was not cancelled")
+				}
+			}
+		})
+	}
+}
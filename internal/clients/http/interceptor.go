@@ -0,0 +1,165 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/itchyny/gojq"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+)
+
+const (
+	errInvalidInterceptor = "invalid request interceptor"
+	errInterceptRequest   = "cannot intercept the request"
+	errInterceptedField   = "the request interceptor returned an invalid %s"
+	errReadRequestBody    = "cannot read the request body"
+)
+
+// WithRequestInterceptor transforms every request with the given jq program before it is sent. The
+// program receives the fully assembled request as an object with its method, url, headers and body,
+// and returns the request to send, in the same format.
+func WithRequestInterceptor(program string) ClientOption {
+	return func(c *client) error {
+		if _, err := gojq.Parse(program); err != nil {
+			return errors.Wrap(err, errInvalidInterceptor)
+		}
+		c.requestInterceptor = program
+		return nil
+	}
+}
+
+// interceptRequest returns the request transformed by the request interceptor, or the request
+// itself when there is none.
+func (hc *client) interceptRequest(request *http.Request) (*http.Request, error) {
+	if hc.requestInterceptor == "" {
+		return request, nil
+	}
+
+	body, err := requestBody(request)
+	if err != nil {
+		return nil, errors.Wrap(err, errInterceptRequest)
+	}
+
+	headers := make(map[string]interface{}, len(request.Header))
+	for key, values := range request.Header {
+		headers[key] = stringsToInterfaces(values)
+	}
+
+	intercepted, err := jq.ParseMapInterface(hc.requestInterceptor, map[string]interface{}{
+		"method":  request.Method,
+		"url":     request.URL.String(),
+		"headers": headers,
+		"body":    body,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errInterceptRequest)
+	}
+
+	method, err := interceptedString(intercepted, "method", request.Method)
+	if err != nil {
+		return nil, err
+	}
+	url, err := interceptedString(intercepted, "url", request.URL.String())
+	if err != nil {
+		return nil, err
+	}
+	body, err = interceptedString(intercepted, "body", body)
+	if err != nil {
+		return nil, err
+	}
+
+	transformed, err := http.NewRequestWithContext(request.Context(), method, url, bytes.NewBufferString(body))
+	if err != nil {
+		return nil, errors.Wrap(err, errInterceptRequest)
+	}
+	// The Host of the request is only kept when set by the routing profile, otherwise it follows the URL
+	// returned by the interceptor.
+	if hc.routeHost != "" {
+		transformed.Host = hc.routeHost
+	}
+	if transformed.Header, err = interceptedHeaders(intercepted, request.Header); err != nil {
+		return nil, err
+	}
+
+	return transformed, nil
+}
+
+// requestBody returns the body of the request without consuming it.
+func requestBody(request *http.Request) (string, error) {
+	if request.GetBody == nil {
+		return "", nil
+	}
+
+	reader, err := request.GetBody()
+	if err != nil {
+		return "", errors.Wrap(err, errReadRequestBody)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return "", errors.Wrap(err, errReadRequestBody)
+	}
+
+	return string(body), nil
+}
+
+// interceptedString returns the string field of the intercepted request, or the given value when
+// the interceptor dropped it.
+func interceptedString(intercepted map[string]interface{}, field string, value string) (string, error) {
+	v, ok := intercepted[field]
+	if !ok {
+		return value, nil
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return "", errors.Errorf(errInterceptedField, field)
+	}
+
+	return s, nil
+}
+
+// interceptedHeaders returns the headers of the intercepted request, whose values are either a
+// string or an array of strings, or the given headers when the interceptor dropped them.
+func interceptedHeaders(intercepted map[string]interface{}, headers http.Header) (http.Header, error) {
+	v, ok := intercepted["headers"]
+	if !ok {
+		return headers, nil
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf(errInterceptedField, "headers")
+	}
+
+	result := make(http.Header, len(m))
+	for key, value := range m {
+		switch value := value.(type) {
+		case string:
+			result.Add(key, value)
+		case []interface{}:
+			for _, item := range value {
+				s, ok := item.(string)
+				if !ok {
+					return nil, errors.Errorf(errInterceptedField, "headers")
+				}
+				result.Add(key, s)
+			}
+		default:
+			return nil, errors.Errorf(errInterceptedField, "headers")
+		}
+	}
+
+	return result, nil
+}
+
+func stringsToInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+
+	return result
+}
@@ -0,0 +1,179 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_SendRequest_RequestInterceptor(t *testing.T) {
+	type args struct {
+		interceptor string
+		method      string
+		path        string
+		body        string
+	}
+	type received struct {
+		method string
+		path   string
+		tenant string
+		body   string
+	}
+	type want struct {
+		request received
+		err     error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"HeaderInjectedAndPathRewritten": {
+			args: args{
+				interceptor: `.headers["X-Tenant"] = ["acme"] | .url |= sub("/v1/"; "/v2/")`,
+				method:      http.MethodPost,
+				path:        "/v1/users",
+				body:        `{"name":"john"}`,
+			},
+			want: want{
+				request: received{method: http.MethodPost, path: "/v2/users", tenant: "acme", body: `{"name":"john"}`},
+			},
+		},
+		"MethodAndBodyTransformed": {
+			args: args{
+				interceptor: `.method = "PUT" | .body = (.body | fromjson | .source = "provider-http" | tojson) | .headers["X-Tenant"] = "acme"`,
+				method:      http.MethodPost,
+				path:        "/v1/users",
+				body:        `{"name":"john"}`,
+			},
+			want: want{
+				request: received{method: http.MethodPut, path: "/v1/users", tenant: "acme", body: `{"name":"john","source":"provider-http"}`},
+			},
+		},
+		"NoInterceptor": {
+			args: args{
+				method: http.MethodGet,
+				path:   "/v1/users",
+			},
+			want: want{
+				request: received{method: http.MethodGet, path: "/v1/users"},
+			},
+		},
+		"InvalidResult": {
+			args: args{
+				interceptor: `.url = 42`,
+				method:      http.MethodGet,
+				path:        "/v1/users",
+			},
+			want: want{
+				err: errors.Errorf(errInterceptedField, "url"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var got received
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				got = received{method: r.Method, path: r.URL.Path, tenant: r.Header.Get("X-Tenant"), body: string(body)}
+			}))
+			defer server.Close()
+
+			var opts []ClientOption
+			if tc.args.interceptor != "" {
+				opts = append(opts, WithRequestInterceptor(tc.args.interceptor))
+			}
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			body := Data{Encrypted: tc.args.body, Decrypted: tc.args.body}
+			_, err = c.SendRequest(context.Background(), tc.args.method, server.URL+tc.args.path, body, emptyHeaders, false)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.request, got, cmp.AllowUnexported(received{})); diff != "" {
+				t.Errorf("SendRequest(...): -want request, +got request: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_RequestInterceptor_Host(t *testing.T) {
+	type args struct {
+		routeHost string
+	}
+	type want struct {
+		host string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"HostFollowsInterceptedURL": {
+			args: args{},
+		},
+		"RoutedHostKept": {
+			args: args{
+				routeHost: "api.internal",
+			},
+			want: want{
+				host: "api.internal",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var host string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				host = r.Host
+			}))
+			defer server.Close()
+
+			// The interceptor sends the request to another host than the one of the requested URL.
+			opts := []ClientOption{WithRequestInterceptor(`.url |= sub("http://original.example"; "` + server.URL + `")`)}
+			if tc.args.routeHost != "" {
+				opts = append(opts, WithRouting("", tc.args.routeHost, ""))
+			}
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			if _, err := c.SendRequest(context.Background(), http.MethodGet, "http://original.example/v1/users", emptyBody, emptyHeaders, false); err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+
+			want := tc.want.host
+			if want == "" {
+				want = server.Listener.Addr().String()
+			}
+			if diff := cmp.Diff(want, host); diff != "" {
+				t.Errorf("SendRequest(...): -want host, +got host: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_WithRequestInterceptor_Invalid(t *testing.T) {
+	_, err := NewClient(logging.NewNopLogger(), time.Minute, "", WithRequestInterceptor(`.headers[`))
+	if err == nil {
+		t.Fatal("NewClient(...): want an invalid request interceptor error, got none")
+	}
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// StatusCodeClassError is the status code class of the requests that got no response.
+const StatusCodeClassError = "error"
+
+// requestsTotal counts the requests sent by the clients. Only low cardinality labels are used, the URLs and
+// headers of the requests, which may be sensitive, are never emitted.
+var requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "provider_http_requests_total",
+	Help: "Number of HTTP requests sent, by resource kind, ProviderConfig, method and status code class (1xx to 5xx, or error when no response was received).",
+}, []string{"kind", "provider_config", "method", "status_class"})
+
+// requestDuration records the duration of the requests sent by the clients, until their response headers.
+var requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "provider_http_request_duration_seconds",
+	Help:    "Duration of the HTTP requests, by resource kind, ProviderConfig and method.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"kind", "provider_config", "method"})
+
+func init() {
+	metrics.Registry.MustRegister(requestsTotal, requestDuration)
+}
+
+// WithMetricsLabels labels the metrics of the requests sent by the client with the kind of the resource sending
+// them, e.g. Request, and the name of its ProviderConfig.
+func WithMetricsLabels(kind string, providerConfig string) ClientOption {
+	return func(c *client) error {
+		c.metricsKind = kind
+		c.metricsProviderConfig = providerConfig
+		return nil
+	}
+}
+
+// observeRequest records a request sent with the given method in the metrics. The status code is zero when
+// the request failed without a response.
+func (hc *client) observeRequest(method string, statusCode int, duration time.Duration) {
+	requestsTotal.WithLabelValues(hc.metricsKind, hc.metricsProviderConfig, method, StatusCodeClass(statusCode, nil)).Inc()
+	requestDuration.WithLabelValues(hc.metricsKind, hc.metricsProviderConfig, method).Observe(duration.Seconds())
+}
+
+// StatusCodeClass returns the class of the status code, e.g. 2xx, or error when no response was received.
+func StatusCodeClass(statusCode int, err error) string {
+	if err != nil || statusCode < 100 || statusCode > 599 {
+		return StatusCodeClassError
+	}
+
+	return strconv.Itoa(statusCode/100) + "xx"
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_SendRequest_Metrics(t *testing.T) {
+	type args struct {
+		statusCode int
+		closed     bool
+	}
+	type want struct {
+		statusClass string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SuccessfulRequest": {
+			args: args{
+				statusCode: http.StatusCreated,
+			},
+			want: want{
+				statusClass: "2xx",
+			},
+		},
+		"ServerError": {
+			args: args{
+				statusCode: http.StatusServiceUnavailable,
+			},
+			want: want{
+				statusClass: "5xx",
+			},
+		},
+		"RequestFailedWithoutResponse": {
+			args: args{
+				closed: true,
+			},
+			want: want{
+				statusClass: StatusCodeClassError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			requestsTotal.Reset()
+			requestDuration.Reset()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.args.statusCode)
+			}))
+			defer server.Close()
+			if tc.args.closed {
+				server.Close()
+			}
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", WithMetricsLabels("Request", "default"))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			headers := Data{
+				Encrypted: map[string][]string{"X-Api-Key": {"{{api:default:key}}"}},
+				Decrypted: map[string][]string{"X-Api-Key": {"sensitive-token"}},
+			}
+			_, _ = c.SendRequest(context.Background(), http.MethodPost, server.URL+"/users/sensitive-path", emptyBody, headers, false)
+
+			if diff := cmp.Diff(float64(1), testutil.ToFloat64(requestsTotal.WithLabelValues("Request", "default", http.MethodPost, tc.want.statusClass))); diff != "" {
+				t.Fatalf("SendRequest(...): -want requests, +got requests: %s", diff)
+			}
+			if diff := cmp.Diff(1, testutil.CollectAndCount(requestDuration)); diff != "" {
+				t.Fatalf("SendRequest(...): -want durations, +got durations: %s", diff)
+			}
+
+			registry := prometheus.NewPedanticRegistry()
+			registry.MustRegister(requestsTotal, requestDuration)
+			families, err := registry.Gather()
+			if err != nil {
+				t.Fatalf("Gather(): unexpected error: %s", err)
+			}
+			for _, family := range families {
+				for _, metric := range family.GetMetric() {
+					for _, label := range metric.GetLabel() {
+						if value := label.GetValue(); strings.Contains(value, "sensitive") || strings.Contains(value, "/") {
+							t.Errorf("SendRequest(...): metric %s emits the sensitive label %s=%q", family.GetName(), label.GetName(), value)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestStatusCodeClass(t *testing.T) {
+	cases := map[string]struct {
+		statusCode int
+		err        error
+		want       string
+	}{
+		"Success":       {statusCode: 204, want: "2xx"},
+		"ClientError":   {statusCode: 404, want: "4xx"},
+		"ServerError":   {statusCode: 502, want: "5xx"},
+		"NoResponse":    {err: errors.New("boom"), want: StatusCodeClassError},
+		"InvalidStatus": {statusCode: 42, want: StatusCodeClassError},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, StatusCodeClass(tc.statusCode, tc.err)); diff != "" {
+				t.Errorf("StatusCodeClass(...): -want class, +got class: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+)
+
+const (
+	// oauth2RefreshMargin is how long before its expiry a token is refreshed, so that it doesn't
+	// expire while a request is in flight.
+	oauth2RefreshMargin = 30 * time.Second
+
+	// oauth2TokenTimeout is the maximum time to wait for the token endpoint.
+	oauth2TokenTimeout = 30 * time.Second
+
+	// oauth2MaxTokenResponseBytes is the maximum size of the responses of the token endpoint.
+	oauth2MaxTokenResponseBytes = 1 << 20
+
+	errFetchOAuth2Token       = "cannot fetch OAuth2 token"
+	errOAuth2TokenStatus      = "token endpoint returned status code %d: %s"
+	errOAuth2TokenResponse    = "cannot parse the response of the token endpoint"
+	errOAuth2TokenMissing     = "token endpoint returned no access_token"
+	errOAuth2TokenRequest     = "cannot create the token request"
+	errOAuth2TokenEndpointURL = "cannot reach the token endpoint"
+)
+
+// OAuth2Config configures the OAuth2 client credentials grant used to get the bearer tokens of the requests.
+type OAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	Audience     string
+
+	// TransportOptions configure the connections to the token endpoint, e.g. their TLS and proxy. They
+	// are the ones of the ProviderConfig, since its tokens are shared by all its resources.
+	TransportOptions []ClientOption
+	// TransportKey identifies the transport options, so that the token source is replaced when they change.
+	TransportKey string
+}
+
+// key identifies the configuration, without exposing the client secret.
+func (c OAuth2Config) key() string {
+	secret := sha256.Sum256([]byte(c.ClientSecret))
+	return strings.Join([]string{c.TokenURL, c.ClientID, hex.EncodeToString(secret[:]), strings.Join(c.Scopes, " "), c.Audience, c.TransportKey}, "\n")
+}
+
+// TokenSource returns the bearer tokens of the requests.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// WithOAuth2 sets the Authorization header of the requests that don't set one to a bearer token of the
+// given source, instead of the credentials of the ProviderConfig.
+func WithOAuth2(source TokenSource) ClientOption {
+	return func(c *client) error {
+		c.tokenSource = source
+		return nil
+	}
+}
+
+// authorization returns the Authorization header of the requests that don't set one.
+func (hc *client) authorization(ctx context.Context) (string, error) {
+	if hc.tokenSource == nil {
+		return hc.authorizationToken, nil
+	}
+
+	token, err := hc.tokenSource.Token(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, errFetchOAuth2Token)
+	}
+
+	return "Bearer " + token, nil
+}
+
+// oauth2TokenSource gets tokens from a token endpoint with the client credentials grant. The tokens are
+// cached and refreshed shortly before they expire, according to their expires_in.
+type oauth2TokenSource struct {
+	config OAuth2Config
+	client *http.Client
+	now    func() time.Time
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewOAuth2TokenSource returns a TokenSource getting its tokens with the given configuration. The token
+// endpoint is reached with a transport of its own, configured by the transport options of the
+// configuration only, so that the TLS and proxy settings of a resource never apply to it.
+func NewOAuth2TokenSource(config OAuth2Config) (TokenSource, error) {
+	c, err := NewClient(logging.NewNopLogger(), oauth2TokenTimeout, "", config.TransportOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2TokenSource{
+		config: config,
+		client: &http.Client{Transport: c.(*client).transport(false), Timeout: oauth2TokenTimeout},
+		now:    time.Now,
+	}, nil
+}
+
+// Token returns the cached token, or a new one when there is none or when it is about to expire.
+func (s *oauth2TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || s.now().Before(s.expiry.Add(-oauth2RefreshMargin))) {
+		return s.token, nil
+	}
+
+	token, expiresIn, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	s.expiry = time.Time{}
+	if expiresIn > 0 {
+		s.expiry = s.now().Add(time.Duration(expiresIn) * time.Second)
+	}
+
+	return s.token, nil
+}
+
+// tokenResponse is the response of a token endpoint.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// fetch requests a new token from the token endpoint, returning it with its lifetime in seconds.
+func (s *oauth2TokenSource) fetch(ctx context.Context) (string, int64, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+	if s.config.Audience != "" {
+		form.Set("audience", s.config.Audience)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, errors.Wrap(err, errOAuth2TokenRequest)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.SetBasicAuth(url.QueryEscape(s.config.ClientID), url.QueryEscape(s.config.ClientSecret))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return "", 0, errors.Wrap(err, errOAuth2TokenEndpointURL)
+	}
+	defer response.Body.Close() //nolint:errcheck // The body is only read.
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, oauth2MaxTokenResponseBytes))
+	if err != nil {
+		return "", 0, errors.Wrap(err, errOAuth2TokenResponse)
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return "", 0, errors.Errorf(errOAuth2TokenStatus, response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", 0, errors.Wrap(err, errOAuth2TokenResponse)
+	}
+	if token.AccessToken == "" {
+		return "", 0, errors.New(errOAuth2TokenMissing)
+	}
+
+	return token.AccessToken, token.ExpiresIn, nil
+}
+
+// OAuth2TokenCache keeps the token sources of the ProviderConfigs across reconciles, so that their
+// tokens are reused until they expire.
+type OAuth2TokenCache struct {
+	mu      sync.Mutex
+	sources map[string]cachedTokenSource
+}
+
+// cachedTokenSource is the token source of a ProviderConfig with the configuration it was created for.
+type cachedTokenSource struct {
+	key    string
+	source TokenSource
+}
+
+// NewOAuth2TokenCache returns an empty OAuth2TokenCache.
+func NewOAuth2TokenCache() *OAuth2TokenCache {
+	return &OAuth2TokenCache{sources: map[string]cachedTokenSource{}}
+}
+
+// TokenSource returns the token source of the named ProviderConfig, which is replaced when its
+// configuration changes, e.g. when its client secret is rotated. A nil cache returns a new source.
+func (c *OAuth2TokenCache) TokenSource(name string, config OAuth2Config) (TokenSource, error) {
+	if c == nil {
+		return NewOAuth2TokenSource(config)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := config.key()
+	if cached, ok := c.sources[name]; ok && cached.key == key {
+		return cached.source, nil
+	}
+
+	source, err := NewOAuth2TokenSource(config)
+	if err != nil {
+		return nil, err
+	}
+	c.sources[name] = cachedTokenSource{key: key, source: source}
+	return source, nil
+}
@@ -0,0 +1,258 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_OAuth2(t *testing.T) {
+	type args struct {
+		tokenStatus   int
+		expiresIn     int64
+		advance       time.Duration
+		headers       Data
+		tlsTokens     bool
+		trustTokens   bool
+		skipTLSVerify bool
+	}
+	type want struct {
+		err           []string
+		authorization []string
+		tokenRequests int32
+	}
+
+	callerHeaders := Data{
+		Encrypted: map[string][]string{"Authorization": {"Bearer caller"}},
+		Decrypted: map[string][]string{"Authorization": {"Bearer caller"}},
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"TokenIsReused": {
+			args: args{
+				tokenStatus: http.StatusOK,
+				expiresIn:   3600,
+				advance:     time.Minute,
+				headers:     emptyHeaders,
+			},
+			want: want{
+				authorization: []string{"Bearer token-1", "Bearer token-1"},
+				tokenRequests: 1,
+			},
+		},
+		"TokenIsRefreshedBeforeExpiry": {
+			args: args{
+				tokenStatus: http.StatusOK,
+				expiresIn:   60,
+				advance:     45 * time.Second,
+				headers:     emptyHeaders,
+			},
+			want: want{
+				authorization: []string{"Bearer token-1", "Bearer token-2"},
+				tokenRequests: 2,
+			},
+		},
+		"TokenWithoutExpiryIsReused": {
+			args: args{
+				tokenStatus: http.StatusOK,
+				advance:     24 * time.Hour,
+				headers:     emptyHeaders,
+			},
+			want: want{
+				authorization: []string{"Bearer token-1", "Bearer token-1"},
+				tokenRequests: 1,
+			},
+		},
+		"CallerAuthorizationIsKept": {
+			args: args{
+				tokenStatus: http.StatusOK,
+				expiresIn:   3600,
+				headers:     callerHeaders,
+			},
+			want: want{
+				authorization: []string{"Bearer caller", "Bearer caller"},
+				tokenRequests: 0,
+			},
+		},
+		"TokenEndpointUsesTransportOptions": {
+			args: args{
+				tokenStatus: http.StatusOK,
+				expiresIn:   3600,
+				headers:     emptyHeaders,
+				tlsTokens:   true,
+				trustTokens: true,
+			},
+			want: want{
+				authorization: []string{"Bearer token-1", "Bearer token-1"},
+				tokenRequests: 1,
+			},
+		},
+		"TokenEndpointIgnoresSkipTLSVerify": {
+			args: args{
+				tokenStatus:   http.StatusOK,
+				expiresIn:     3600,
+				headers:       emptyHeaders,
+				tlsTokens:     true,
+				skipTLSVerify: true,
+			},
+			want: want{
+				err:           []string{errFetchOAuth2Token, "certificate"},
+				tokenRequests: 0,
+			},
+		},
+		"TokenEndpointFailure": {
+			args: args{
+				tokenStatus: http.StatusUnauthorized,
+				headers:     emptyHeaders,
+			},
+			want: want{
+				err:           []string{errFetchOAuth2Token, "401"},
+				tokenRequests: 2,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var tokenRequests atomic.Int32
+			tokens := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := tokenRequests.Add(1)
+				id, secret, _ := r.BasicAuth()
+				if err := r.ParseForm(); err != nil || id != "client" || secret != "s3cr3t" ||
+					r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("scope") != "read write" ||
+					r.PostForm.Get("audience") != "api" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if tc.args.tokenStatus != http.StatusOK {
+					w.WriteHeader(tc.args.tokenStatus)
+					_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"access_token": "token-" + string(rune('0'+n)),
+					"expires_in":   tc.args.expiresIn,
+				})
+			}))
+			if tc.args.tlsTokens {
+				tokens.StartTLS()
+			} else {
+				tokens.Start()
+			}
+			defer tokens.Close()
+
+			var authorization []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = append(authorization, r.Header.Get("Authorization"))
+			}))
+			defer server.Close()
+
+			var transportOptions []ClientOption
+			if tc.args.trustTokens {
+				transportOptions = append(transportOptions, WithRootCAs(certificatePEM(tokens.Certificate())))
+			}
+
+			now := time.Now()
+			tokenSource, err := NewOAuth2TokenSource(OAuth2Config{
+				TokenURL:         tokens.URL,
+				ClientID:         "client",
+				ClientSecret:     "s3cr3t",
+				Scopes:           []string{"read", "write"},
+				Audience:         "api",
+				TransportOptions: transportOptions,
+			})
+			if err != nil {
+				t.Fatalf("NewOAuth2TokenSource(...): unexpected error: %s", err)
+			}
+			source := tokenSource.(*oauth2TokenSource)
+			source.now = func() time.Time { return now }
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "Basic credentials", WithOAuth2(source))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			var errs []string
+			for i := 0; i < 2; i++ {
+				if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, tc.args.headers, tc.args.skipTLSVerify); err != nil {
+					errs = append(errs, err.Error())
+				}
+				now = now.Add(tc.args.advance)
+			}
+
+			if len(tc.want.err) != 0 {
+				if len(errs) != 2 {
+					t.Fatalf("SendRequest(...): want 2 errors containing %q, got %v", tc.want.err, errs)
+				}
+				for _, want := range tc.want.err {
+					if !strings.Contains(errs[0], want) {
+						t.Errorf("SendRequest(...): want an error containing %q, got %q", want, errs[0])
+					}
+				}
+			} else if len(errs) != 0 {
+				t.Fatalf("SendRequest(...): unexpected errors: %v", errs)
+			}
+			if diff := cmp.Diff(tc.want.authorization, authorization); diff != "" {
+				t.Errorf("SendRequest(...): -want Authorization, +got Authorization: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.tokenRequests, tokenRequests.Load()); diff != "" {
+				t.Errorf("SendRequest(...): -want token requests, +got token requests: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_OAuth2TokenCache(t *testing.T) {
+	config := OAuth2Config{TokenURL: "https://auth.example.com/token", ClientID: "client", ClientSecret: "s3cr3t"}
+	rotated := config
+	rotated.ClientSecret = "r0tated"
+
+	proxied := config
+	proxied.TransportOptions = []ClientOption{WithProxy("http://proxy.internal:3128", nil)}
+	proxied.TransportKey = "proxied"
+	invalid := config
+	invalid.TransportOptions = []ClientOption{WithTLSConfig(TLSConfigData{MinVersion: "1.0"})}
+	invalid.TransportKey = "invalid"
+
+	cache := NewOAuth2TokenCache()
+	tokenSource := func(name string, config OAuth2Config) TokenSource {
+		source, err := cache.TokenSource(name, config)
+		if err != nil {
+			t.Fatalf("TokenSource(...): unexpected error: %s", err)
+		}
+		return source
+	}
+	first := tokenSource("default", config)
+
+	if tokenSource("default", config) != first {
+		t.Errorf("TokenSource(...): want the cached source for an unchanged configuration")
+	}
+	if tokenSource("other", config) == first {
+		t.Errorf("TokenSource(...): want a distinct source for another ProviderConfig")
+	}
+	if tokenSource("default", rotated) == first {
+		t.Errorf("TokenSource(...): want a new source once the client secret is rotated")
+	}
+	if tokenSource("default", proxied) == tokenSource("default", rotated) {
+		t.Errorf("TokenSource(...): want a new source once the transport options change")
+	}
+	if _, err := cache.TokenSource("default", invalid); err == nil {
+		t.Errorf("TokenSource(...): want an error for invalid transport options")
+	}
+	if strings.Contains(rotated.key(), rotated.ClientSecret) {
+		t.Errorf("key(): the key must not contain the client secret")
+	}
+}
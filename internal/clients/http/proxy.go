@@ -0,0 +1,60 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const errInvalidProxyURL = "invalid proxy URL %q, expected e.g. http://proxy.internal:3128"
+
+// WithProxy sends the requests through the proxy with the given URL instead of the proxy of the
+// environment, except for the hosts matching the noProxy list, in the format of NO_PROXY: host names,
+// domain suffixes, IP addresses and CIDR ranges, optionally with a port.
+func WithProxy(proxyURL string, noProxy []string) ClientOption {
+	return func(c *client) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") {
+			return errors.Errorf(errInvalidProxyURL, RedactProxyURL(proxyURL))
+		}
+
+		proxyFunc := (&httpproxy.Config{
+			HTTPProxy:  proxyURL,
+			HTTPSProxy: proxyURL,
+			NoProxy:    strings.Join(noProxy, ","),
+		}).ProxyFunc()
+		c.proxyFunc = func(request *http.Request) (*url.URL, error) {
+			return proxyFunc(request.URL)
+		}
+		return nil
+	}
+}
+
+// proxy returns the proxy of the requests sent by the client: none when the requests are routed through
+// a gateway, which they reach directly, the configured proxy if any, or else the proxy of the environment.
+func (hc *client) proxy() func(*http.Request) (*url.URL, error) {
+	switch {
+	case hc.routeAddress != "":
+		return nil
+	case hc.proxyFunc != nil:
+		return hc.proxyFunc
+	default:
+		return http.ProxyFromEnvironment
+	}
+}
+
+// RedactProxyURL removes the credentials of a proxy URL, so that it can be reported in errors and
+// debug output.
+func RedactProxyURL(proxyURL string) string {
+	if at := strings.LastIndex(proxyURL, "@"); at >= 0 {
+		if scheme := strings.Index(proxyURL, "://"); scheme >= 0 && scheme < at {
+			return proxyURL[:scheme+3] + "xxxxx" + proxyURL[at:]
+		}
+		return "xxxxx" + proxyURL[at:]
+	}
+
+	return proxyURL
+}
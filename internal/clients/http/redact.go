@@ -0,0 +1,50 @@
+package http
+
+import "net/textproto"
+
+// RedactedHeaderValue replaces the values of the redacted headers.
+const RedactedHeaderValue = "***"
+
+// defaultRedactedHeaders are the headers carrying credentials, always redacted.
+var defaultRedactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+}
+
+// WithRedactedHeaders redacts the given headers, in addition to the ones carrying credentials, in the
+// logged requests and in the request details reported in the status of the resources.
+func WithRedactedHeaders(headers []string) ClientOption {
+	return func(c *client) error {
+		c.redactedHeaders = make(map[string]bool, len(headers))
+		for _, header := range headers {
+			c.redactedHeaders[textproto.CanonicalMIMEHeaderKey(header)] = true
+		}
+		return nil
+	}
+}
+
+// redactHeaders returns a copy of the headers where the values of the redacted headers are replaced
+// by RedactedHeaderValue. Header names are matched case-insensitively.
+func (hc *client) redactHeaders(headers map[string][]string) map[string][]string {
+	if headers == nil {
+		return nil
+	}
+
+	redacted := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		canonicalKey := textproto.CanonicalMIMEHeaderKey(key)
+		if !defaultRedactedHeaders[canonicalKey] && !hc.redactedHeaders[canonicalKey] {
+			redacted[key] = values
+			continue
+		}
+
+		masked := make([]string, len(values))
+		for i := range values {
+			masked[i] = RedactedHeaderValue
+		}
+		redacted[key] = masked
+	}
+
+	return redacted
+}
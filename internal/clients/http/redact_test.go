@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_redactHeaders(t *testing.T) {
+	type args struct {
+		opts    []ClientOption
+		headers map[string][]string
+	}
+
+	cases := map[string]struct {
+		args args
+		want map[string][]string
+	}{
+		"CredentialsRedacted": {
+			args: args{
+				headers: map[string][]string{
+					"Authorization": {"Bearer token"},
+					"cookie":        {"session=abc", "theme=dark"},
+					"Content-Type":  {"application/json"},
+				},
+			},
+			want: map[string][]string{
+				"Authorization": {RedactedHeaderValue},
+				"cookie":        {RedactedHeaderValue, RedactedHeaderValue},
+				"Content-Type":  {"application/json"},
+			},
+		},
+		"DenylistRedacted": {
+			args: args{
+				opts: []ClientOption{WithRedactedHeaders([]string{"x-api-key"})},
+				headers: map[string][]string{
+					"X-Api-Key":     {"key"},
+					"Authorization": {"Basic dXNlcjpwYXNz"},
+					"Content-Type":  {"application/json"},
+				},
+			},
+			want: map[string][]string{
+				"X-Api-Key":     {RedactedHeaderValue},
+				"Authorization": {RedactedHeaderValue},
+				"Content-Type":  {"application/json"},
+			},
+		},
+		"NoHeaders": {
+			args: args{},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			got := c.(*client).redactHeaders(tc.args.headers)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("redactHeaders(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_RedactedHeaders(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	c, err := NewClient(logging.NewNopLogger(), time.Minute, "")
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %s", err)
+	}
+
+	headers := map[string][]string{"Authorization": {"Bearer token"}, "Content-Type": {"application/json"}}
+	details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, Data{Encrypted: headers, Decrypted: headers}, false)
+	if err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %s", err)
+	}
+
+	want := map[string][]string{"Authorization": {RedactedHeaderValue}, "Content-Type": {"application/json"}}
+	if diff := cmp.Diff(want, details.HttpRequest.Headers); diff != "" {
+		t.Errorf("SendRequest(...): -want request details headers, +got request details headers: %s", diff)
+	}
+	if diff := cmp.Diff("Bearer token", received.Get("Authorization")); diff != "" {
+		t.Errorf("SendRequest(...): -want sent Authorization, +got sent Authorization: %s", diff)
+	}
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultMaxRedirects is the maximum number of redirects followed when no other limit is configured,
+// the one of the Go HTTP client.
+const DefaultMaxRedirects = 10
+
+const errTooManyRedirects = "stopped after %d redirects"
+
+// WithRedirects configures the redirects of the requests. When follow is false, the redirect responses
+// are returned verbatim, so that e.g. a redirect to a login page can be told apart from a successful
+// response. Otherwise at most maxRedirects redirects are followed, 0 meaning no limit.
+func WithRedirects(follow bool, maxRedirects int) ClientOption {
+	return func(c *client) error {
+		c.checkRedirect = func(request *http.Request, via []*http.Request) error {
+			if !follow {
+				return http.ErrUseLastResponse
+			}
+			if maxRedirects > 0 && len(via) > maxRedirects {
+				return errors.Errorf(errTooManyRedirects, maxRedirects)
+			}
+			return nil
+		}
+		return nil
+	}
+}
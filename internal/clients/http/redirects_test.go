@@ -0,0 +1,219 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_Redirects(t *testing.T) {
+	type args struct {
+		opts      []ClientOption
+		redirects int
+	}
+	type want struct {
+		err        bool
+		statusCode int
+		location   string
+		body       string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DisabledReturnsRedirectVerbatim": {
+			args: args{
+				opts:      []ClientOption{WithRedirects(false, 0)},
+				redirects: 3,
+			},
+			want: want{
+				statusCode: http.StatusFound,
+				location:   "/hop/1",
+			},
+		},
+		"CappedFollowsUpToTheLimit": {
+			args: args{
+				opts:      []ClientOption{WithRedirects(true, 3)},
+				redirects: 3,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       "landed",
+			},
+		},
+		"CappedFailsOverTheLimit": {
+			args: args{
+				opts:      []ClientOption{WithRedirects(true, 2)},
+				redirects: 3,
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"UnlimitedFollowsLongChains": {
+			args: args{
+				opts:      []ClientOption{WithRedirects(true, 0)},
+				redirects: 15,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       "landed",
+			},
+		},
+		"DefaultStopsLongChains": {
+			args: args{
+				redirects: 15,
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			// The server redirects /hop/0 to /hop/1 and so on, until the given number of redirects is reached.
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hop, _ := strconv.Atoi(r.URL.Path[len("/hop/"):])
+				if hop < tc.args.redirects {
+					http.Redirect(w, r, "/hop/"+strconv.Itoa(hop+1), http.StatusFound)
+					return
+				}
+				_, _ = w.Write([]byte("landed"))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL+"/hop/0", emptyBody, emptyHeaders, false)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s (error: %v)", diff, err)
+			}
+			if diff := cmp.Diff(tc.want.statusCode, details.HttpResponse.StatusCode); diff != "" {
+				t.Fatalf("SendRequest(...): -want status code, +got status code: %s", diff)
+			}
+			if tc.want.location != "" {
+				if diff := cmp.Diff([]string{tc.want.location}, details.HttpResponse.Headers["Location"]); diff != "" {
+					t.Fatalf("SendRequest(...): -want Location, +got Location: %s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.body, details.HttpResponse.Body); diff != "" && tc.want.statusCode == http.StatusOK {
+				t.Fatalf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_RedirectedMutatingRequest(t *testing.T) {
+	type args struct {
+		method     string
+		statusCode int
+		opts       []ClientOption
+	}
+	type want struct {
+		received   string
+		redirected bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"PermanentRedirectResendsPOSTBody": {
+			args: args{
+				method:     http.MethodPost,
+				statusCode: http.StatusPermanentRedirect,
+			},
+			want: want{
+				received:   `POST /new {"username":"john_doe"}`,
+				redirected: true,
+			},
+		},
+		"TemporaryRedirectResendsPUTBody": {
+			args: args{
+				method:     http.MethodPut,
+				statusCode: http.StatusTemporaryRedirect,
+			},
+			want: want{
+				received:   `PUT /new {"username":"john_doe"}`,
+				redirected: true,
+			},
+		},
+		"PermanentRedirectResendsInterceptedBody": {
+			args: args{
+				method:     http.MethodPost,
+				statusCode: http.StatusPermanentRedirect,
+				opts:       []ClientOption{WithRequestInterceptor(`.body |= ascii_upcase`)},
+			},
+			want: want{
+				received:   `POST /new {"USERNAME":"JOHN_DOE"}`,
+				redirected: true,
+			},
+		},
+		"MovedPermanentlyTurnsPOSTIntoGET": {
+			args: args{
+				method:     http.MethodPost,
+				statusCode: http.StatusMovedPermanently,
+			},
+			want: want{
+				received:   "GET /new ",
+				redirected: true,
+			},
+		},
+		"NotRedirected": {
+			args: args{
+				method: http.MethodPost,
+			},
+			want: want{
+				received: `POST /old {"username":"john_doe"}`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			// The server redirects /old to /new, and echoes the method, path and body of the requests it serves.
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/old" && tc.args.statusCode != 0 {
+					http.Redirect(w, r, "/new", tc.args.statusCode)
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				_, _ = w.Write([]byte(r.Method + " " + r.URL.Path + " " + string(body)))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			body := Data{Encrypted: `{"username":"john_doe"}`, Decrypted: `{"username":"john_doe"}`}
+			details, err := c.SendRequest(context.Background(), tc.args.method, server.URL+"/old", body, emptyHeaders, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.received, details.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want received request, +got received request: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.redirected, details.Redirected); diff != "" {
+				t.Errorf("SendRequest(...): -want redirected, +got redirected: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net"
+)
+
+// WithRouting routes the requests through a gateway: the connections are established to the given
+// address, keeping the port of the request URL when it has none, and the requests are sent with the
+// given Host header and TLS server name. An empty host or server name keeps the one of the URL, the
+// server name defaulting to the host.
+func WithRouting(address, host, serverName string) ClientOption {
+	return func(c *client) error {
+		if serverName == "" {
+			serverName = host
+		}
+
+		c.routeAddress = address
+		c.routeHost = host
+		c.routeServerName = serverName
+		return nil
+	}
+}
+
+// routedAddress returns the address the connection to the given address is established to.
+func (hc *client) routedAddress(address string) string {
+	if hc.routeAddress == "" {
+		return address
+	}
+
+	if _, _, err := net.SplitHostPort(hc.routeAddress); err == nil {
+		return hc.routeAddress
+	}
+
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return hc.routeAddress
+	}
+
+	return net.JoinHostPort(hc.routeAddress, port)
+}
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_SendRequest_Routing(t *testing.T) {
+	type args struct {
+		host       string
+		serverName string
+	}
+	type want struct {
+		host       string
+		serverName string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"HostAndServerName": {
+			args: args{
+				host:       "orders.example.com",
+				serverName: "example.com",
+			},
+			want: want{
+				host:       "orders.example.com",
+				serverName: "example.com",
+			},
+		},
+		"ServerNameDefaultsToHost": {
+			args: args{
+				host: "orders.example.com",
+			},
+			want: want{
+				host:       "orders.example.com",
+				serverName: "orders.example.com",
+			},
+		},
+		"URLHostByDefault": {
+			want: want{
+				host:       "orders.internal:8443",
+				serverName: "orders.internal",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var gotHost, gotServerName string
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHost = r.Host
+				gotServerName = r.TLS.ServerName
+			}))
+			defer server.Close()
+
+			// The gateway is the test server, reached by IP without a port so that the port of the URL is kept.
+			gatewayIP, gatewayPort, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "", WithRouting(gatewayIP, tc.args.host, tc.args.serverName))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			var gotAddress string
+			c.(*client).dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+				gotAddress = address
+				return (&net.Dialer{}).DialContext(ctx, network, net.JoinHostPort(gatewayIP, gatewayPort))
+			}
+
+			_, err = c.SendRequest(context.Background(), http.MethodGet, "https://orders.internal:8443/orders", emptyBody, emptyHeaders, true)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(net.JoinHostPort(gatewayIP, "8443"), gotAddress); diff != "" {
+				t.Fatalf("SendRequest(...): -want dial address, +got dial address: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.host, gotHost); diff != "" {
+				t.Fatalf("SendRequest(...): -want Host header, +got Host header: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.serverName, gotServerName); diff != "" {
+				t.Fatalf("SendRequest(...): -want SNI, +got SNI: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_routedAddress(t *testing.T) {
+	cases := map[string]struct {
+		route   string
+		address string
+		want    string
+	}{
+		"NoRoute": {
+			address: "api.example.com:443",
+			want:    "api.example.com:443",
+		},
+		"RouteWithoutPortKeepsPort": {
+			route:   "10.0.0.10",
+			address: "api.example.com:443",
+			want:    "10.0.0.10:443",
+		},
+		"RouteWithPort": {
+			route:   "gateway.internal:8443",
+			address: "api.example.com:443",
+			want:    "gateway.internal:8443",
+		},
+		"IPv6RouteWithoutPort": {
+			route:   "fd00::10",
+			address: "api.example.com:80",
+			want:    "[fd00::10]:80",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			hc := &client{routeAddress: tc.route}
+			if diff := cmp.Diff(tc.want, hc.routedAddress(tc.address)); diff != "" {
+				t.Fatalf("routedAddress(...): -want address, +got address: %s", diff)
+			}
+		})
+	}
+}
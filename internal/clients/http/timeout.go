@@ -0,0 +1,32 @@
+package http
+
+import (
+	"time"
+)
+
+// A RequestOption configures a single request sent by the client.
+type RequestOption func(*requestConfig)
+
+// requestConfig is the configuration of a single request, defaulting to the one of the client.
+type requestConfig struct {
+	timeout time.Duration
+}
+
+// WithRequestTimeout overrides the timeout of the client for the request, which can be longer or shorter,
+// e.g. for the steps of a Request that take longer than the others. Timeouts that are not positive are ignored.
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(c *requestConfig) {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+// requestConfig returns the configuration of a request sent with the given options.
+func (hc *client) requestConfig(opts []RequestOption) requestConfig {
+	config := requestConfig{timeout: hc.timeout}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errUnknownTLSVersion     = "unknown TLS version %q, expected 1.2 or 1.3"
+	errUnknownCipherSuite    = "unknown or insecure cipher suite %s"
+	errTLS13CipherSuite      = "cipher suite %s is a TLS 1.3 cipher suite, which can't be configured"
+	errCipherSuitesWithTLS13 = "cipher suites can't be configured when the minimum TLS version is 1.3"
+	errNoCACertificates      = "the CA bundle doesn't contain any PEM encoded certificate"
+)
+
+// tlsVersions are the supported minimum TLS versions.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSConfigData is the TLS configuration of the connections to the servers.
+type TLSConfigData struct {
+	// MinVersion is the minimum TLS version, 1.2 or 1.3. Go defaults to 1.2 when empty.
+	MinVersion string
+	// CipherSuites are the names of the cipher suites of the TLS 1.2 connections, as in crypto/tls.
+	CipherSuites []string
+	// ServerName overrides the server name the TLS connections are established and verified for.
+	ServerName string
+	// ClientCertificate loads the client certificate presented to the servers requesting one, on every
+	// handshake so that rotated certificates are picked up. No certificate is presented when nil.
+	ClientCertificate func(ctx context.Context) (*tls.Certificate, error)
+}
+
+// WithTLSConfig sets the minimum TLS version, the cipher suites and the server name of the connections.
+// Unknown versions, and cipher suites that are unknown, insecure or can't be configured, are rejected.
+func WithTLSConfig(data TLSConfigData) ClientOption {
+	return func(c *client) error {
+		if data.MinVersion != "" {
+			version, ok := tlsVersions[data.MinVersion]
+			if !ok {
+				return errors.Errorf(errUnknownTLSVersion, data.MinVersion)
+			}
+			c.minTLSVersion = version
+		}
+
+		cipherSuites, err := cipherSuiteIDs(data.CipherSuites)
+		if err != nil {
+			return err
+		}
+		if len(cipherSuites) > 0 && c.minTLSVersion == tls.VersionTLS13 {
+			return errors.New(errCipherSuitesWithTLS13)
+		}
+		c.cipherSuites = cipherSuites
+		c.tlsServerName = data.ServerName
+		c.clientCertificate = data.ClientCertificate
+		return nil
+	}
+}
+
+// WithRootCAs verifies the certificates of the servers against the PEM encoded certificate authorities of
+// the bundle, instead of the system roots. Bundles without any certificate are rejected.
+func WithRootCAs(bundle []byte) ClientOption {
+	return func(c *client) error {
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(bundle) {
+			return errors.New(errNoCACertificates)
+		}
+		c.rootCAs = roots
+		return nil
+	}
+}
+
+// getClientCertificate returns the GetClientCertificate callback of the TLS connections, nil when the client
+// has no client certificate.
+func (hc *client) getClientCertificate() func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	if hc.clientCertificate == nil {
+		return nil
+	}
+
+	return func(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		return hc.clientCertificate(info.Context())
+	}
+}
+
+// cipherSuiteIDs maps the names of secure TLS 1.2 cipher suites to their IDs.
+func cipherSuiteIDs(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	suites := make(map[string]*tls.CipherSuite, len(tls.CipherSuites()))
+	for _, suite := range tls.CipherSuites() {
+		suites[suite.Name] = suite
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		suite, ok := suites[name]
+		if !ok {
+			return nil, errors.Errorf(errUnknownCipherSuite, name)
+		}
+		if !supportsTLS12(suite) {
+			return nil, errors.Errorf(errTLS13CipherSuite, name)
+		}
+		ids = append(ids, suite.ID)
+	}
+
+	return ids, nil
+}
+
+// supportsTLS12 returns whether the cipher suite can be used by TLS 1.2 connections.
+func supportsTLS12(suite *tls.CipherSuite) bool {
+	for _, version := range suite.SupportedVersions {
+		if version == tls.VersionTLS12 {
+			return true
+		}
+	}
+	return false
+}
+
+// serverName returns the server name of the TLS connections: the configured one, or else the one of the
+// routing profile, empty meaning the host of the request URL.
+func (hc *client) serverName() string {
+	if hc.tlsServerName != "" {
+		return hc.tlsServerName
+	}
+	return hc.routeServerName
+}
@@ -0,0 +1,217 @@
+package http
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_WithTLSConfig(t *testing.T) {
+	type want struct {
+		err          error
+		minVersion   uint16
+		cipherSuites []uint16
+	}
+
+	cases := map[string]struct {
+		data TLSConfigData
+		want want
+	}{
+		"Default": {
+			data: TLSConfigData{},
+			want: want{},
+		},
+		"MinVersionAndCipherSuites": {
+			data: TLSConfigData{
+				MinVersion:   "1.2",
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"},
+			},
+			want: want{
+				minVersion:   tls.VersionTLS12,
+				cipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+			},
+		},
+		"TLS13": {
+			data: TLSConfigData{MinVersion: "1.3"},
+			want: want{
+				minVersion: tls.VersionTLS13,
+			},
+		},
+		"UnknownVersion": {
+			data: TLSConfigData{MinVersion: "1.1"},
+			want: want{
+				err: errors.Errorf(errUnknownTLSVersion, "1.1"),
+			},
+		},
+		"UnknownCipherSuite": {
+			data: TLSConfigData{CipherSuites: []string{"TLS_MADE_UP_SUITE"}},
+			want: want{
+				err: errors.Errorf(errUnknownCipherSuite, "TLS_MADE_UP_SUITE"),
+			},
+		},
+		"InsecureCipherSuite": {
+			data: TLSConfigData{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}},
+			want: want{
+				err: errors.Errorf(errUnknownCipherSuite, "TLS_RSA_WITH_RC4_128_SHA"),
+			},
+		},
+		"TLS13CipherSuite": {
+			data: TLSConfigData{CipherSuites: []string{"TLS_AES_128_GCM_SHA256"}},
+			want: want{
+				err: errors.Errorf(errTLS13CipherSuite, "TLS_AES_128_GCM_SHA256"),
+			},
+		},
+		"CipherSuitesWithTLS13": {
+			data: TLSConfigData{MinVersion: "1.3", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+			want: want{
+				err: errors.New(errCipherSuitesWithTLS13),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			c := &client{}
+			err := WithTLSConfig(tc.data)(c)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("WithTLSConfig(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			config := c.tlsConfig(false)
+			if diff := cmp.Diff(tc.want.minVersion, config.MinVersion); diff != "" {
+				t.Errorf("tlsConfig(...): -want MinVersion, +got MinVersion: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cipherSuites, config.CipherSuites); diff != "" {
+				t.Errorf("tlsConfig(...): -want CipherSuites, +got CipherSuites: %s", diff)
+			}
+		})
+	}
+}
+
+// certificatePEM returns the PEM encoding of the certificate.
+func certificatePEM(certificate *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+}
+
+// selfSignedCertificatePEM returns the PEM encoding of a self-signed certificate authority.
+func selfSignedCertificatePEM(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(...): unexpected error: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "other-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate(...): unexpected error: %s", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func Test_SendRequest_RootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	cases := map[string]struct {
+		bundle    []byte
+		wantErr   error
+		wantFails bool
+	}{
+		"ServerIssuedByTheBundle": {
+			bundle: certificatePEM(server.Certificate()),
+		},
+		"ServerNotIssuedByTheBundle": {
+			bundle:    selfSignedCertificatePEM(t),
+			wantFails: true,
+		},
+		"NoCertificateInTheBundle": {
+			bundle:  []byte("not a certificate"),
+			wantErr: errors.New(errNoCACertificates),
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "", WithRootCAs(tc.bundle))
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("NewClient(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, false)
+			if diff := cmp.Diff(tc.wantFails, err != nil); diff != "" {
+				t.Errorf("SendRequest(...): -want error, +got error: %s (error: %v)", diff, err)
+			}
+		})
+	}
+}
+
+func Test_SendRequest_TLSMinVersion(t *testing.T) {
+	cases := map[string]struct {
+		minVersion string
+		wantErr    bool
+	}{
+		"ServerMeetsMinVersion": {
+			minVersion: "1.2",
+		},
+		"ServerBelowMinVersion": {
+			minVersion: "1.3",
+			wantErr:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+			server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12} // #nosec G402
+			server.StartTLS()
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "", WithTLSConfig(TLSConfigData{MinVersion: tc.minVersion}))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, true)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Errorf("SendRequest(...): -want error, +got error: %s (error: %v)", diff, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const errTLSVerification = "TLS verification failed, the server presented the certificate chain %s"
+
+// WithTLSDiagnostics enriches the errors of the requests whose TLS verification fails with the
+// certificate chain presented by the server: the subject, issuer and subject alternative names of
+// every certificate, so that the trusted CAs or the server name can be fixed.
+func WithTLSDiagnostics() ClientOption {
+	return func(c *client) error {
+		c.tlsDiagnostics = true
+		return nil
+	}
+}
+
+// diagnoseTLSError returns the error enriched with the certificate chain presented by the server when
+// it is a TLS verification error, and the error unchanged otherwise.
+func diagnoseTLSError(err error) error {
+	var verificationErr *tls.CertificateVerificationError
+	if !errors.As(err, &verificationErr) || len(verificationErr.UnverifiedCertificates) == 0 {
+		return err
+	}
+
+	return errors.Wrapf(err, errTLSVerification, describeChain(verificationErr.UnverifiedCertificates))
+}
+
+// describeChain describes the certificates of a chain, starting with the leaf certificate.
+func describeChain(certs []*x509.Certificate) string {
+	descriptions := make([]string, len(certs))
+	for i, cert := range certs {
+		descriptions[i] = fmt.Sprintf("[subject %q, issuer %q, SANs %s]", cert.Subject.String(), cert.Issuer.String(), describeSANs(cert))
+	}
+
+	return strings.Join(descriptions, ", ")
+}
+
+// describeSANs lists the subject alternative names of the certificate.
+func describeSANs(cert *x509.Certificate) string {
+	sans := make([]string, 0, len(cert.DNSNames)+len(cert.IPAddresses)+len(cert.URIs)+len(cert.EmailAddresses))
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+
+	return "[" + strings.Join(sans, " ") + "]"
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_SendRequest_TLSDiagnostics(t *testing.T) {
+	type args struct {
+		opts          []ClientOption
+		skipTLSVerify bool
+	}
+	type want struct {
+		err         bool
+		diagnostics bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UnknownAuthorityReportsServerChain": {
+			args: args{
+				opts: []ClientOption{WithTLSDiagnostics()},
+			},
+			want: want{
+				err:         true,
+				diagnostics: true,
+			},
+		},
+		"DiagnosticsDisabled": {
+			want: want{
+				err:         true,
+				diagnostics: false,
+			},
+		},
+		"SkippedVerificationSucceeds": {
+			args: args{
+				opts:          []ClientOption{WithTLSDiagnostics()},
+				skipTLSVerify: true,
+			},
+			want: want{
+				err:         false,
+				diagnostics: false,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			// The certificate of the test server is self-signed by "O=Acme Co" for example.com among others.
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, tc.args.skipTLSVerify)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+
+			got := err != nil && strings.Contains(err.Error(), `subject "O=Acme Co", issuer "O=Acme Co", SANs [example.com`)
+			if diff := cmp.Diff(tc.want.diagnostics, got); diff != "" {
+				t.Fatalf("SendRequest(...): -want diagnostics, +got diagnostics: %s (error: %v)", diff, err)
+			}
+		})
+	}
+}
+
+func Test_diagnoseTLSError(t *testing.T) {
+	errBoom := errors.New("boom")
+	if diff := cmp.Diff(errBoom.Error(), diagnoseTLSError(errBoom).Error()); diff != "" {
+		t.Fatalf("diagnoseTLSError(...): -want error, +got error: %s", diff)
+	}
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	phaseDNS             = "dns"
+	phaseConnect         = "connect"
+	phaseTLSHandshake    = "tls_handshake"
+	phaseTimeToFirstByte = "time_to_first_byte"
+)
+
+// requestPhaseDuration records the latency breakdown of the requests sent by traced clients.
+var requestPhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "provider_http_request_phase_duration_seconds",
+	Help:    "Duration of the phases (DNS, connect, TLS handshake, time to first byte) of the HTTP requests.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"method", "phase"})
+
+func init() {
+	metrics.Registry.MustRegister(requestPhaseDuration)
+}
+
+// RequestTimings holds the latency breakdown of a request. Phases that didn't happen,
+// e.g. DNS or connect when a connection is reused, are zero.
+type RequestTimings struct {
+	DNS             time.Duration
+	Connect         time.Duration
+	TLSHandshake    time.Duration
+	TimeToFirstByte time.Duration
+}
+
+// WithTracing collects the latency breakdown of every request and logs it at debug level.
+// When metrics is true, the breakdown is also recorded as histogram metrics.
+func WithTracing(metrics bool) ClientOption {
+	return func(c *client) error {
+		c.onTrace = func(method string, timings RequestTimings) {
+			c.log.Debug("http request timings", "method", method, "dns", timings.DNS.String(), "connect", timings.Connect.String(), "tlsHandshake", timings.TLSHandshake.String(), "timeToFirstByte", timings.TimeToFirstByte.String())
+			if metrics {
+				observeTimings(method, timings)
+			}
+		}
+		return nil
+	}
+}
+
+// observeTimings records the phases of a request that happened in the histogram metrics.
+func observeTimings(method string, timings RequestTimings) {
+	for phase, duration := range map[string]time.Duration{
+		phaseDNS:             timings.DNS,
+		phaseConnect:         timings.Connect,
+		phaseTLSHandshake:    timings.TLSHandshake,
+		phaseTimeToFirstByte: timings.TimeToFirstByte,
+	} {
+		if duration > 0 {
+			requestPhaseDuration.WithLabelValues(method, phase).Observe(duration.Seconds())
+		}
+	}
+}
+
+// requestTrace collects the timings of a single request. The callbacks of the
+// trace may be called concurrently, hence the mutex.
+type requestTrace struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	timings      RequestTimings
+}
+
+func newRequestTrace() *requestTrace {
+	return &requestTrace{start: time.Now()}
+}
+
+// clientTrace returns the httptrace hooks recording the timings of the request.
+func (t *requestTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.DNS = time.Since(t.dnsStart)
+		},
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.connectStart = time.Now()
+		},
+		ConnectDone: func(string, string, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.Connect = time.Since(t.connectStart)
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.TLSHandshake = time.Since(t.tlsStart)
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.TimeToFirstByte = time.Since(t.start)
+		},
+	}
+}
+
+// result returns the timings recorded so far.
+func (t *requestTrace) result() RequestTimings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.timings
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// traceParentHeader is the W3C trace context header identifying the trace and the parent span of a request.
+const traceParentHeader = "traceparent"
+
+// WithTraceContextPropagation injects a W3C traceparent header in the requests that don't set one. The requests
+// sent by the client, i.e. during a reconcile, share a trace ID, and each of them gets its own parent ID.
+func WithTraceContextPropagation() ClientOption {
+	return func(c *client) error {
+		traceID, err := randomHex(16)
+		if err != nil {
+			return err
+		}
+		c.traceID = traceID
+		return nil
+	}
+}
+
+// addTraceParent sets the traceparent header of the request, unless it already sets one or the client doesn't
+// propagate the trace context. The header is skipped when no parent ID can be generated.
+func (hc *client) addTraceParent(request *http.Request) {
+	if hc.traceID == "" || request.Header.Get(traceParentHeader) != "" {
+		return
+	}
+
+	parentID, err := randomHex(8)
+	if err != nil {
+		hc.log.Debug("cannot generate the parent ID of the traceparent header", "error", err.Error())
+		return
+	}
+
+	// Version 00, with the sampled flag set.
+	request.Header.Set(traceParentHeader, "00-"+hc.traceID+"-"+parentID+"-01")
+}
+
+// randomHex returns n random bytes, hex encoded.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
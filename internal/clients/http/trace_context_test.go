@@ -0,0 +1,103 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+var traceParentPattern = regexp.MustCompile(`^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`)
+
+func Test_SendRequest_TraceContextPropagation(t *testing.T) {
+	const resourceTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	type args struct {
+		opts    []ClientOption
+		headers map[string][]string
+	}
+	type want struct {
+		generated   bool
+		traceParent string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"PropagationEnabled": {
+			args: args{
+				opts: []ClientOption{WithTraceContextPropagation()},
+			},
+			want: want{
+				generated: true,
+			},
+		},
+		"ResourceTraceParentKept": {
+			args: args{
+				opts:    []ClientOption{WithTraceContextPropagation()},
+				headers: map[string][]string{"Traceparent": {resourceTraceParent}},
+			},
+			want: want{
+				traceParent: resourceTraceParent,
+			},
+		},
+		"PropagationDisabled": {
+			args: args{},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var received []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = append(received, r.Header.Get(traceParentHeader))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			headers := emptyHeaders
+			if tc.args.headers != nil {
+				headers = Data{Encrypted: tc.args.headers, Decrypted: tc.args.headers}
+			}
+			for i := 0; i < 2; i++ {
+				if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, headers, false); err != nil {
+					t.Fatalf("SendRequest(...): unexpected error: %s", err)
+				}
+			}
+
+			if !tc.want.generated {
+				if diff := cmp.Diff([]string{tc.want.traceParent, tc.want.traceParent}, received); diff != "" {
+					t.Errorf("SendRequest(...): -want traceparent, +got traceparent: %s", diff)
+				}
+				return
+			}
+
+			for _, traceParent := range received {
+				if !traceParentPattern.MatchString(traceParent) {
+					t.Fatalf("SendRequest(...): malformed traceparent header %q", traceParent)
+				}
+			}
+			first, second := strings.Split(received[0], "-"), strings.Split(received[1], "-")
+			if diff := cmp.Diff(first[1], second[1]); diff != "" {
+				t.Errorf("SendRequest(...): -want shared trace ID, +got trace ID: %s", diff)
+			}
+			if first[2] == second[2] {
+				t.Errorf("SendRequest(...): expected a parent ID per request, got %s twice", first[2])
+			}
+		})
+	}
+}
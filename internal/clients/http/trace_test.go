@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_SendRequest_Tracing(t *testing.T) {
+	type args struct {
+		opts []ClientOption
+	}
+	type want struct {
+		traced  bool
+		metrics bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"TracingWithMetrics": {
+			args: args{
+				opts: []ClientOption{WithTracing(true)},
+			},
+			want: want{
+				traced:  true,
+				metrics: true,
+			},
+		},
+		"TracingWithoutMetrics": {
+			args: args{
+				opts: []ClientOption{WithTracing(false)},
+			},
+			want: want{
+				traced: true,
+			},
+		},
+		"TracingDisabled": {
+			args: args{},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			requestPhaseDuration.Reset()
+
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", tc.args.opts...)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+
+			var timings *RequestTimings
+			if onTrace := c.(*client).onTrace; onTrace != nil {
+				c.(*client).onTrace = func(method string, got RequestTimings) {
+					timings = &got
+					onTrace(method, got)
+				}
+			}
+
+			if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, true); err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want.traced, timings != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want traced, +got traced: %s", diff)
+			}
+			if timings != nil && (timings.Connect <= 0 || timings.TLSHandshake <= 0 || timings.TimeToFirstByte <= 0) {
+				t.Fatalf("SendRequest(...): expected connect, TLS handshake and time to first byte to be recorded, got %+v", *timings)
+			}
+			if diff := cmp.Diff(tc.want.metrics, testutil.CollectAndCount(requestPhaseDuration) > 0); diff != "" {
+				t.Fatalf("SendRequest(...): -want metrics, +got metrics: %s", diff)
+			}
+		})
+	}
+}
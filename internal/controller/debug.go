@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	disposablerequestv1alpha2 "github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	requestv1alpha2 "github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	disposablerequest "github.com/crossplane-contrib/provider-http/internal/controller/disposablerequest"
+	request "github.com/crossplane-contrib/provider-http/internal/controller/request"
+	"github.com/crossplane-contrib/provider-http/internal/features"
+)
+
+const (
+	// EffectiveConfigPath is the path of the debug endpoint returning the effective configuration of a resource.
+	EffectiveConfigPath = "/debug/effective-config"
+
+	debugShutdownTimeout = 5 * time.Second
+)
+
+type effectiveConfigFn func(ctx context.Context, kube client.Client, name string, propagateTraceContext bool) (any, error)
+
+// NewDebugHandler returns the handler of the debug endpoints. GET /debug/effective-config?kind=<kind>&name=<name>
+// returns the configuration a Request or DisposableRequest is reconciled with, once merged with its
+// ProviderConfig and the defaults, without any secret value. propagateTraceContext tells whether the
+// provider was started with trace context propagation.
+func NewDebugHandler(kube client.Client, propagateTraceContext bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(EffectiveConfigPath, effectiveConfigHandler(kube, propagateTraceContext, map[string]effectiveConfigFn{
+		strings.ToLower(requestv1alpha2.RequestKind):                     request.EffectiveConfig,
+		strings.ToLower(disposablerequestv1alpha2.DisposableRequestKind): disposablerequest.EffectiveConfig,
+	}))
+	return mux
+}
+
+// effectiveConfigHandler serves the effective configurations returned by the function of the requested kind.
+func effectiveConfigHandler(kube client.Client, propagateTraceContext bool, kinds map[string]effectiveConfigFn) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := r.URL.Query()
+		fn, ok := kinds[strings.ToLower(query.Get("kind"))]
+		if !ok {
+			http.Error(w, fmt.Sprintf("kind must be one of %s or %s", requestv1alpha2.RequestKind, disposablerequestv1alpha2.DisposableRequestKind), http.StatusBadRequest)
+			return
+		}
+		name := query.Get("name")
+		if name == "" {
+			http.Error(w, "name is required", http.StatusBadRequest)
+			return
+		}
+		if query.Get("namespace") != "" {
+			http.Error(w, "namespace must be empty, the resources are cluster-scoped", http.StatusBadRequest)
+			return
+		}
+
+		config, err := fn(r.Context(), kube, name, propagateTraceContext)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if kerrors.IsNotFound(err) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(config)
+	}
+}
+
+// SetupDebugEndpoint serves the debug endpoints on the given address, e.g. 127.0.0.1:8081, as long as
+// the manager runs. The endpoints are served by every replica, whether it is the leader or not.
+func SetupDebugEndpoint(mgr ctrl.Manager, o controller.Options, address string) error {
+	return mgr.Add(&debugServer{server: &http.Server{
+		Addr:              address,
+		Handler:           NewDebugHandler(mgr.GetClient(), o.Features.Enabled(features.EnableTraceContextPropagation)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}})
+}
+
+// debugServer runs the server of the debug endpoints until the manager stops.
+type debugServer struct {
+	server *http.Server
+}
+
+// NeedLeaderElection returns false, so that the debug endpoints are served by every replica.
+func (s *debugServer) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the debug endpoints until the context is done.
+func (s *debugServer) Start(ctx context.Context) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), debugShutdownTimeout)
+		defer cancel()
+		return s.server.Shutdown(shutdownCtx)
+	}
+}
@@ -19,6 +19,7 @@ package disposablerequest
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -40,7 +41,10 @@ import (
 
 	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/bodyformat"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/cron"
+	"github.com/crossplane-contrib/provider-http/internal/features"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
@@ -48,6 +52,7 @@ const (
 	errNotDisposableRequest              = "managed resource is not a DisposableRequest custom resource"
 	errTrackPCUsage                      = "cannot track ProviderConfig usage"
 	errNewHttpClient                     = "cannot create new Http client"
+	errSchedule                          = "invalid schedule"
 	errProviderNotRetrieved              = "provider could not be retrieved"
 	errFailedToSendHttpDisposableRequest = "failed to send http request"
 	errFailedUpdateStatusConditions      = "failed updating status conditions"
@@ -60,26 +65,38 @@ const (
 	errGetLatestVersion                  = "failed to get the latest version of the resource"
 	errResponseFormat                    = "Response does not match the expected format, retries limit "
 	errExtractCredentials                = "cannot extract credentials"
+	errOAuth2Config                      = "cannot read the OAuth2 client credentials"
+	errRequestInterceptor                = "cannot read the request interceptor"
+	errCABundle                          = "cannot read the CA bundle"
 )
 
 // Setup adds a controller that reconciles DisposableRequest managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error {
+func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration, pause *utils.PauseSwitch) error {
 	name := managed.ControllerName(v1alpha2.DisposableRequestGroupKind)
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 
-	r := managed.NewReconciler(mgr,
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	// The status updates of the managed reconciler itself are tracked too.
+	statusUpdates := utils.NewStatusUpdateTracker(recorder, utils.DefaultStatusUpdateFailureThreshold, utils.DefaultStatusUpdateBackoff)
+
+	r := managed.NewReconciler(statusUpdates.Manager(mgr),
 		resource.ManagedKind(v1alpha2.DisposableRequestGroupVersionKind),
 		managed.WithExternalConnecter(&connector{
-			logger:          o.Logger,
-			kube:            mgr.GetClient(),
-			usage:           resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newHttpClientFn: httpClient.NewClient,
+			logger:                o.Logger,
+			kube:                  mgr.GetClient(),
+			usage:                 resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newHttpClientFn:       httpClient.NewClient,
+			statusUpdates:         statusUpdates,
+			pause:                 pause,
+			outcomes:              utils.NewOutcomeTracker(v1alpha2.DisposableRequestKind, utils.DefaultOutcomeWindow),
+			tokens:                httpClient.NewOAuth2TokenCache(),
+			propagateTraceContext: o.Features.Enabled(features.EnableTraceContextPropagation),
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
 		WithCustomPollIntervalHook(),
 		managed.WithTimeout(timeout),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -91,10 +108,15 @@ func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error
 }
 
 type connector struct {
-	logger          logging.Logger
-	kube            client.Client
-	usage           resource.Tracker
-	newHttpClientFn func(log logging.Logger, timeout time.Duration, creds string) (httpClient.Client, error)
+	logger                logging.Logger
+	kube                  client.Client
+	usage                 resource.Tracker
+	newHttpClientFn       func(log logging.Logger, timeout time.Duration, creds string, opts ...httpClient.ClientOption) (httpClient.Client, error)
+	statusUpdates         *utils.StatusUpdateTracker
+	pause                 *utils.PauseSwitch
+	outcomes              *utils.OutcomeTracker
+	tokens                *httpClient.OAuth2TokenCache
+	propagateTraceContext bool
 }
 
 // Connect returns a new ExternalClient.
@@ -106,12 +128,13 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	l := c.logger.WithValues("disposableRequest", cr.Name)
 
-	if err := c.usage.Track(ctx, mg); err != nil {
+	pcRef := utils.ProviderConfigReference(cr)
+	if err := c.usage.Track(ctx, utils.WithProviderConfigReference(cr, pcRef)); err != nil {
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
 	pc := &apisv1alpha1.ProviderConfig{}
-	n := types.NamespacedName{Name: cr.GetProviderConfigReference().Name}
+	n := types.NamespacedName{Name: pcRef.Name}
 	if err := c.kube.Get(ctx, n, pc); err != nil {
 		return nil, errors.Wrap(err, errProviderNotRetrieved)
 	}
@@ -126,84 +149,210 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		creds = string(data)
 	}
 
-	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout), creds)
+	// Invalid configurations can't be fixed by retrying, they are reported with a ConfigError
+	// condition and the reconciles back off until the configuration is fixed.
+	ec, err := utils.NewEffectiveConfig(cr, v1alpha2.DisposableRequestKind, connectionParameters(cr, c.propagateTraceContext), pc)
 	if err != nil {
-		return nil, errors.Wrap(err, errNewHttpClient)
+		utils.SetConfigErrorCondition(cr, err)
+		return nil, err
+	}
+	if schedule := cr.Spec.ForProvider.Schedule; schedule != "" {
+		if _, err := cron.Parse(schedule); err != nil {
+			err = errors.Wrap(err, errSchedule)
+			utils.SetConfigErrorCondition(cr, err)
+			return nil, err
+		}
 	}
 
+	opts := ec.ClientOptions(c.kube)
+	if tlsConfig := ec.TLS; tlsConfig != nil && tlsConfig.CABundleSecretRef != nil {
+		bundle, err := utils.CABundle(ctx, c.kube, tlsConfig.CABundleSecretRef)
+		if err != nil {
+			err = errors.Wrap(err, errCABundle)
+			utils.SetConfigErrorCondition(cr, err)
+			return nil, err
+		}
+		opts = append(opts, httpClient.WithRootCAs(bundle))
+	}
+	if ec.OAuth2 != nil {
+		config, err := utils.OAuth2Config(ctx, c.kube, (*apisv1alpha1.OAuth2)(ec.OAuth2), pc)
+		if err != nil {
+			return nil, errors.Wrap(err, errOAuth2Config)
+		}
+		source, err := c.tokens.TokenSource(pc.GetName(), config)
+		if err != nil {
+			err = errors.Wrap(err, errOAuth2Config)
+			utils.SetConfigErrorCondition(cr, err)
+			return nil, err
+		}
+		opts = append(opts, httpClient.WithOAuth2(source))
+	}
+	if ref := ec.RequestInterceptorRef; ref != nil {
+		program, err := utils.RequestInterceptor(ctx, c.kube, ref)
+		if err != nil {
+			err = errors.Wrap(err, errRequestInterceptor)
+			utils.SetConfigErrorCondition(cr, err)
+			return nil, err
+		}
+		opts = append(opts, httpClient.WithRequestInterceptor(program))
+	}
+	l.Debug("Resolved wait timeout", "waitTimeout", ec.WaitTimeout)
+
+	h, err := c.newHttpClientFn(l, ec.WaitTimeoutDuration(), creds, opts...)
+	if err != nil {
+		err = errors.Wrap(err, errNewHttpClient)
+		utils.SetConfigErrorCondition(cr, err)
+		return nil, err
+	}
+	utils.SetConfigErrorCondition(cr, nil)
+
 	return &external{
-		localKube: c.kube,
-		logger:    l,
-		http:      h,
+		localKube:     c.statusUpdates.Client(c.kube),
+		logger:        l,
+		http:          h,
+		statusUpdates: c.statusUpdates,
+		pause:         c.pause,
+		outcomes:      c.outcomes,
 	}, nil
 }
 
 type external struct {
-	localKube client.Client
-	logger    logging.Logger
-	http      httpClient.Client
+	localKube     client.Client
+	logger        logging.Logger
+	http          httpClient.Client
+	statusUpdates *utils.StatusUpdateTracker
+	pause         *utils.PauseSwitch
+	outcomes      *utils.OutcomeTracker
+
+	// response is the last response received during the reconcile, before its injected values are masked.
+	response *httpClient.HttpResponse
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	observation, err := c.observe(ctx, mg)
+	// The condition is set once the resource is observed, since fetching its latest version drops it.
+	if cr, ok := mg.(*v1alpha2.DisposableRequest); ok {
+		utils.SetUpstreamCircuitCondition(cr, cr.Spec.ForProvider.URL)
+	}
+	if err != nil || !observation.ResourceExists {
+		return observation, err
+	}
+
+	// The connection details are published at every reconcile, they replace the whole connection secret.
+	observation.ConnectionDetails = c.connectionDetails(ctx, mg.(*v1alpha2.DisposableRequest))
+	return observation, nil
+}
+
+func (c *external) observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
 	cr, ok := mg.(*v1alpha2.DisposableRequest)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotDisposableRequest)
 	}
 
+	paused, err := c.pause.Paused(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	utils.SetPausedCondition(cr, paused)
+	if paused {
+		// Report the resource as up to date so that no request is sent until the provider is resumed.
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	if err := c.statusUpdates.BackOff(cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if err := utils.ResetUpstreamCircuit(ctx, c.localKube, cr, cr.Spec.ForProvider.URL); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	if !cr.Status.Synced {
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
 	}
 
-	// Get the latest version of the resource before updating
-	if err := c.localKube.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr); err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errGetLatestVersion)
-	}
+	// Get the latest version of the resource before updating, again after conflicts
+	var updateErr error
+	err = utils.RetryOnConflict(ctx, utils.DefaultConflictRetry, func() error {
+		updateErr = nil
+		if err := c.localKube.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr); err != nil {
+			return errors.Wrap(err, errGetLatestVersion)
+		}
 
-	cr.Status.SetConditions(xpv1.Available())
-	if err := c.localKube.Status().Update(ctx, cr); err != nil {
+		cr.Status.SetConditions(xpv1.Available())
+		updateErr = c.localKube.Status().Update(ctx, cr)
+		return updateErr
+	})
+	if updateErr != nil {
 		return managed.ExternalObservation{}, errors.New(errFailedUpdateStatusConditions)
 	}
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
 
 	isUpToDate := !(utils.ShouldRetry(cr.Spec.ForProvider.RollbackRetriesLimit, cr.Status.Failed) && !utils.RetriesLimitReached(cr.Status.Failed, cr.Spec.ForProvider.RollbackRetriesLimit))
 
-	// If shouldLoopInfinitely is true, the resource should never be considered up-to-date
-	if cr.Spec.ForProvider.ShouldLoopInfinitely {
-		if cr.Spec.ForProvider.RollbackRetriesLimit == nil {
+	// A failed request is only retried once its backoff has elapsed
+	if remaining, backingOff := retryBackoffRemaining(cr, time.Now()); backingOff {
+		c.logger.Debug("backing off before retrying the failed request", "remaining", remaining.String())
+		isUpToDate = true
+	}
+
+	switch {
+	// A scheduled resource is up to date until its next fire time
+	case cr.Spec.ForProvider.Schedule != "":
+		next, err := nextScheduledTime(cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if !next.IsZero() && !time.Now().Before(next) {
+			isUpToDate = false
+		}
+	// If shouldLoopInfinitely is true, the resource should never be considered up-to-date, until the
+	// response matches its loopUntil expression
+	case cr.Spec.ForProvider.ShouldLoopInfinitely:
+		if cr.Spec.ForProvider.RollbackRetriesLimit == nil && !cr.Status.LoopCompleted {
 			isUpToDate = false
 		}
 	}
 
 	return managed.ExternalObservation{
-		ResourceExists:    true,
-		ResourceUpToDate:  isUpToDate,
-		ConnectionDetails: nil,
+		ResourceExists:   true,
+		ResourceUpToDate: isUpToDate,
 	}, nil
 }
 
 func (c *external) deployAction(ctx context.Context, cr *v1alpha2.DisposableRequest) error {
-	sensitiveBody, err := datapatcher.PatchSecretsIntoString(ctx, c.localKube, cr.Spec.ForProvider.Body, c.logger)
+	bodyData, err := c.body(ctx, cr)
 	if err != nil {
 		return err
 	}
 
-	sensitiveHeaders, err := datapatcher.PatchSecretsIntoHeaders(ctx, c.localKube, cr.Spec.ForProvider.Headers, c.logger)
+	sensitiveHeaders, err := datapatcher.PatchSecretsIntoHeaders(ctx, c.localKube, cr.Spec.ForProvider.Headers, cr.Spec.ForProvider.UnresolvedSecretPolicy, c.logger)
 	if err != nil {
 		return err
 	}
 
-	bodyData := httpClient.Data{Encrypted: cr.Spec.ForProvider.Body, Decrypted: sensitiveBody}
 	headersData := httpClient.Data{Encrypted: cr.Spec.ForProvider.Headers, Decrypted: sensitiveHeaders}
 	details, err := c.http.SendRequest(ctx, cr.Spec.ForProvider.Method, cr.Spec.ForProvider.URL, bodyData, headersData, cr.Spec.ForProvider.InsecureSkipTLSVerify)
+	c.outcomes.Record(cr, details.HttpResponse.StatusCode, err)
 
 	sensitiveResponse := details.HttpResponse
+	c.keepResponse(sensitiveResponse)
 	resource := &utils.RequestResource{
 		Resource:       cr,
 		RequestContext: ctx,
 		HttpResponse:   details.HttpResponse,
 		LocalClient:    c.localKube,
 		HttpRequest:    details.HttpRequest,
+
+		MaxStatusBodyBytes: cr.Spec.ForProvider.MaxStatusBodyBytes,
 	}
 
 	// Get the latest version of the resource before updating
@@ -213,16 +362,16 @@ func (c *external) deployAction(ctx context.Context, cr *v1alpha2.DisposableRequ
 
 	if err != nil {
 		setErr := resource.SetError(err)
-		datapatcher.ApplyResponseDataToSecrets(ctx, c.localKube, c.logger, &resource.HttpResponse, cr.Spec.ForProvider.SecretInjectionConfigs, cr)
-		if settingError := utils.SetRequestResourceStatus(*resource, setErr, resource.SetLastReconcileTime(), resource.SetRequestDetails()); settingError != nil {
+		utils.SetSecretInjectionCondition(cr, datapatcher.ApplyResponseDataToSecrets(ctx, c.localKube, c.logger, &resource.HttpResponse, cr.Spec.ForProvider.SecretInjectionConfigs, cr.Spec.ForProvider.SecretInjectionDiscriminator, cr.Spec.ForProvider.AtomicSecretInjection, false, cr))
+		if settingError := utils.SetRequestResourceStatus(*resource, setErr, resource.SetLastReconcileTime(), resource.SetRequestDetails(), recordHistory(cr, resource.HttpResponse, err)); settingError != nil {
 			return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 		}
 		return err
 	}
 
 	if utils.IsHTTPError(resource.HttpResponse.StatusCode) {
-		datapatcher.ApplyResponseDataToSecrets(ctx, c.localKube, c.logger, &resource.HttpResponse, cr.Spec.ForProvider.SecretInjectionConfigs, cr)
-		if settingError := utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetHeaders(), resource.SetBody(), resource.SetRequestDetails(), resource.SetError(nil)); settingError != nil {
+		utils.SetSecretInjectionCondition(cr, datapatcher.ApplyResponseDataToSecrets(ctx, c.localKube, c.logger, &resource.HttpResponse, cr.Spec.ForProvider.SecretInjectionConfigs, cr.Spec.ForProvider.SecretInjectionDiscriminator, cr.Spec.ForProvider.AtomicSecretInjection, false, cr))
+		if settingError := utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetHeaders(), resource.SetBody(), resource.SetDurationMs(), resource.SetProto(), resource.SetRequestDetails(), resource.SetError(nil), recordHistory(cr, resource.HttpResponse, nil)); settingError != nil {
 			return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 		}
 
@@ -234,15 +383,20 @@ func (c *external) deployAction(ctx context.Context, cr *v1alpha2.DisposableRequ
 		return err
 	}
 
+	loopCompleted, err := isLoopCompleted(cr, sensitiveResponse)
+	if err != nil {
+		return err
+	}
+
 	if isExpectedResponse {
-		datapatcher.ApplyResponseDataToSecrets(ctx, c.localKube, c.logger, &resource.HttpResponse, cr.Spec.ForProvider.SecretInjectionConfigs, cr)
+		utils.SetSecretInjectionCondition(cr, datapatcher.ApplyResponseDataToSecrets(ctx, c.localKube, c.logger, &resource.HttpResponse, cr.Spec.ForProvider.SecretInjectionConfigs, cr.Spec.ForProvider.SecretInjectionDiscriminator, cr.Spec.ForProvider.AtomicSecretInjection, false, cr))
 	} else {
 		limit := utils.GetRollbackRetriesLimit(cr.Spec.ForProvider.RollbackRetriesLimit)
-		return utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetHeaders(), resource.SetBody(),
-			resource.SetError(errors.New(errResponseFormat+fmt.Sprint(limit))), resource.SetRequestDetails())
+		return utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetHeaders(), resource.SetBody(), resource.SetDurationMs(), resource.SetProto(),
+			resource.SetError(errors.New(errResponseFormat+fmt.Sprint(limit))), resource.SetRequestDetails(), recordHistory(cr, resource.HttpResponse, nil))
 	}
 
-	return utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetHeaders(), resource.SetBody(), resource.SetSynced(), resource.SetRequestDetails())
+	return utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetHeaders(), resource.SetBody(), resource.SetDurationMs(), resource.SetProto(), resource.SetSynced(), resource.SetRequestDetails(), recordHistory(cr, resource.HttpResponse, nil), setLoopCompleted(cr, loopCompleted))
 }
 
 func (c *external) isResponseAsExpected(cr *v1alpha2.DisposableRequest, res httpClient.HttpResponse) (bool, error) {
@@ -255,19 +409,31 @@ func (c *external) isResponseAsExpected(cr *v1alpha2.DisposableRequest, res http
 		return false, nil
 	}
 
+	return evaluateResponse(cr, res, cr.Spec.ForProvider.ExpectedResponse)
+}
+
+// evaluateResponse returns the result of the jq filter, which should return a boolean, on the response.
+func evaluateResponse(cr *v1alpha2.DisposableRequest, res httpClient.HttpResponse, filter string) (bool, error) {
 	responseMap, err := json_util.StructToMap(res)
 	if err != nil {
 		return false, errors.Wrap(err, errConvertResToMap)
 	}
 
-	json_util.ConvertJSONStringsToMaps(&responseMap)
+	if err := json_util.ConvertJSONStringsToMaps(&responseMap); err != nil {
+		return false, errors.Wrap(err, errConvertResToMap)
+	}
 
-	isExpected, err := jq.ParseBool(cr.Spec.ForProvider.ExpectedResponse, responseMap)
+	if err := bodyformat.SetBody(responseMap, res.Body, cr.Spec.ForProvider.ResponseBodyFormat); err != nil {
+		return false, errors.Wrap(err, errConvertResToMap)
+	}
+	bodyformat.SetJSONBody(responseMap, res.Body, cr.Spec.ForProvider.ResponseBodyFormat)
+
+	result, err := jq.ParseBool(filter, responseMap)
 	if err != nil {
 		return false, errors.Errorf(ErrExpectedFormat, err.Error())
 	}
 
-	return isExpected, nil
+	return result, nil
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -280,7 +446,11 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, err
 	}
 
-	return managed.ExternalCreation{}, errors.Wrap(c.deployAction(ctx, cr), errFailedToSendHttpDisposableRequest)
+	if err := c.deployAction(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedToSendHttpDisposableRequest)
+	}
+
+	return managed.ExternalCreation{ConnectionDetails: c.connectionDetails(ctx, cr)}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -293,10 +463,16 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, err
 	}
 
-	return managed.ExternalUpdate{}, errors.Wrap(c.deployAction(ctx, cr), errFailedToSendHttpDisposableRequest)
+	if err := c.deployAction(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedToSendHttpDisposableRequest)
+	}
+
+	return managed.ExternalUpdate{ConnectionDetails: c.connectionDetails(ctx, cr)}, nil
 }
 
-func (c *external) Delete(_ context.Context, _ resource.Managed) error {
+func (c *external) Delete(_ context.Context, mg resource.Managed) error {
+	c.outcomes.Forget(mg)
+	c.statusUpdates.Forget(mg)
 	return nil
 }
 
@@ -310,6 +486,14 @@ func WithCustomPollIntervalHook() managed.ReconcilerOption {
 			return defaultPollInterval
 		}
 
+		if remaining, backingOff := retryBackoffRemaining(cr, time.Now()); backingOff {
+			return remaining
+		}
+
+		if cr.Spec.ForProvider.Schedule != "" {
+			return scheduledPollInterval(cr, defaultPollInterval, time.Now())
+		}
+
 		if cr.Spec.ForProvider.NextReconcile == nil {
 			return defaultPollInterval
 		}
@@ -330,3 +514,62 @@ func WithCustomPollIntervalHook() managed.ReconcilerOption {
 		return defaultPollInterval
 	})
 }
+
+// nextScheduledTime returns the first fire time of the schedule of the DisposableRequest after the
+// request was last sent, or the zero time when the schedule never fires.
+func nextScheduledTime(cr *v1alpha2.DisposableRequest) (time.Time, error) {
+	schedule, err := cron.Parse(cr.Spec.ForProvider.Schedule)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, errSchedule)
+	}
+
+	return schedule.Next(cr.Status.LastReconcileTime.Time), nil
+}
+
+// scheduledPollInterval returns the time left until the next fire time of the schedule of the
+// DisposableRequest, or the default poll interval when it is already due or never fires.
+func scheduledPollInterval(cr *v1alpha2.DisposableRequest, defaultPollInterval time.Duration, now time.Time) time.Duration {
+	next, err := nextScheduledTime(cr)
+	if err != nil || next.IsZero() || !now.Before(next) {
+		return defaultPollInterval
+	}
+
+	return next.Sub(now)
+}
+
+// body returns the body of the request with its secret placeholders resolved, read from the bodyFrom
+// reference when no inline body is set.
+func (c *external) body(ctx context.Context, cr *v1alpha2.DisposableRequest) (httpClient.Data, error) {
+	if cr.Spec.ForProvider.Body == "" && cr.Spec.ForProvider.BodyFrom != nil {
+		return utils.BodyFrom(ctx, c.localKube, cr.Spec.ForProvider.BodyFrom)
+	}
+
+	sensitiveBody, err := datapatcher.PatchSecretsIntoString(ctx, c.localKube, cr.Spec.ForProvider.Body, cr.Spec.ForProvider.UnresolvedSecretPolicy, c.logger)
+	if err != nil {
+		return httpClient.Data{}, err
+	}
+
+	return httpClient.Data{Encrypted: cr.Spec.ForProvider.Body, Decrypted: sensitiveBody}, nil
+}
+
+// connectionDetails returns the connection details extracted from the last response received during the
+// reconcile, or from the last response recorded in the status when no request was sent.
+func (c *external) connectionDetails(ctx context.Context, cr *v1alpha2.DisposableRequest) managed.ConnectionDetails {
+	if c.response != nil {
+		return datapatcher.ConnectionDetails(c.logger, c.response, cr.Spec.ForProvider.ConnectionDetails, false)
+	}
+
+	response := cr.Status.Response
+	return datapatcher.StoredConnectionDetails(ctx, c.localKube, c.logger, httpClient.HttpResponse{
+		StatusCode: response.StatusCode,
+		Body:       response.Body,
+		Headers:    response.Headers,
+	}, cr.Spec.ForProvider.ConnectionDetails, false, cr.Spec.ForProvider.UnresolvedSecretPolicy)
+}
+
+// keepResponse keeps a copy of the response, before the injected values are masked in it, to extract the
+// connection details from.
+func (c *external) keepResponse(response httpClient.HttpResponse) {
+	response.Headers = http.Header(response.Headers).Clone()
+	c.response = &response
+}
@@ -18,20 +18,25 @@ package disposablerequest
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 	"time"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 )
@@ -109,7 +114,7 @@ type MockHttpClient struct {
 	MockSendRequest MockSendRequestFn
 }
 
-func (c *MockHttpClient) SendRequest(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+func (c *MockHttpClient) SendRequest(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool, opts ...httpClient.RequestOption) (resp httpClient.HttpDetails, err error) {
 	return c.MockSendRequest(ctx, method, url, body, headers, skipTLSVerify)
 }
 
@@ -432,3 +437,480 @@ func Test_deployAction(t *testing.T) {
 		})
 	}
 }
+
+func Test_deployAction_MaxStatusBodyBytes(t *testing.T) {
+	const (
+		responseBody = `{"token":"s3cr3t","items":["first","second","third"]}`
+		// The injected value is masked in the status with a reference to the secret.
+		maskedBody = `{"token":"s3cr3t","items":["first","second","{{item:testns:item}}"]}`
+	)
+
+	type want struct {
+		statusBody string
+		injected   string
+	}
+	cases := map[string]struct {
+		maxStatusBodyBytes int
+		want               want
+	}{
+		"StatusBodyTruncated": {
+			maxStatusBodyBytes: 18,
+			want: want{
+				statusBody: `{"token":"s3cr3t",...[truncated, 68 bytes in total]`,
+				injected:   "third",
+			},
+		},
+		"BodyUnderCap": {
+			maxStatusBodyBytes: 1024,
+			want: want{
+				statusBody: maskedBody,
+				injected:   "third",
+			},
+		},
+		"NoCap": {
+			want: want{
+				statusBody: maskedBody,
+				injected:   "third",
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var injected string
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						if secret, ok := obj.(*corev1.Secret); ok {
+							secret.Name = "item"
+							secret.Namespace = testNamespace
+						}
+						return nil
+					}),
+					MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+						if secret, ok := obj.(*corev1.Secret); ok {
+							injected = string(secret.Data["item"])
+						}
+						return nil
+					},
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 200,
+								Body:       responseBody,
+							},
+						}, nil
+					},
+				},
+			}
+
+			cr := httpDisposableRequest(func(r *v1alpha2.DisposableRequest) {
+				r.Spec.ForProvider.MaxStatusBodyBytes = tc.maxStatusBodyBytes
+				r.Spec.ForProvider.SecretInjectionConfigs = []common.SecretInjectionConfig{
+					{
+						SecretRef:   common.SecretRef{Name: "item", Namespace: testNamespace},
+						KeyMappings: []common.KeyInjection{{SecretKey: "item", ResponseJQ: ".body.items[2]"}},
+					},
+				}
+			})
+
+			if err := e.deployAction(context.Background(), cr); err != nil {
+				t.Fatalf("deployAction(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.statusBody, cr.Status.Response.Body); diff != "" {
+				t.Fatalf("deployAction(...): -want Status.Response.Body, +got Status.Response.Body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.injected, injected); diff != "" {
+				t.Fatalf("deployAction(...): -want injected value, +got injected value: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_isResponseAsExpected_ResponseBodyFormat(t *testing.T) {
+	type args struct {
+		format           string
+		expectedResponse string
+		body             string
+	}
+	type want struct {
+		expected bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"XMLResponseExpected": {
+			args: args{
+				format:           common.ResponseBodyFormatXML,
+				expectedResponse: `.body.job["@id"] == "42" and .body.job.status == "success"`,
+				body:             `<job id="42"><status>success</status></job>`,
+			},
+			want: want{expected: true},
+		},
+		"XMLResponseNotExpected": {
+			args: args{
+				format:           common.ResponseBodyFormatXML,
+				expectedResponse: `.body.job.status == "success"`,
+				body:             `<job id="42"><status>running</status></job>`,
+			},
+			want: want{expected: false},
+		},
+		"YAMLResponseExpected": {
+			args: args{
+				format:           common.ResponseBodyFormatYAML,
+				expectedResponse: `.body.job.status == "success" and (.body.job.steps | length) == 2`,
+				body:             "job:\n  status: success\n  steps: [build, deploy]\n",
+			},
+			want: want{expected: true},
+		},
+		"RawResponseExpected": {
+			args: args{
+				format:           common.ResponseBodyFormatRaw,
+				expectedResponse: `.body | contains("success")`,
+				body:             `{"job_status": "success"}`,
+			},
+			want: want{expected: true},
+		},
+		"ArrayRootResponseExpected": {
+			args: args{
+				format:           common.ResponseBodyFormatJSON,
+				expectedResponse: `.body | all(.status == "success")`,
+				body:             `[{"id": 1, "status": "success"}, {"id": 2, "status": "success"}]`,
+			},
+			want: want{expected: true},
+		},
+		"ArrayRootResponseNotExpected": {
+			args: args{
+				format:           common.ResponseBodyFormatJSON,
+				expectedResponse: `.body | all(.status == "success")`,
+				body:             `[{"id": 1, "status": "success"}, {"id": 2, "status": "failed"}]`,
+			},
+			want: want{expected: false},
+		},
+		"ScalarRootResponseExpected": {
+			args: args{
+				format:           common.ResponseBodyFormatJSON,
+				expectedResponse: `.body == 5`,
+				body:             `5`,
+			},
+			want: want{expected: true},
+		},
+		"ArrayRootResponseWithoutFormat": {
+			args: args{
+				expectedResponse: `.body | fromjson | length == 1`,
+				body:             `[{"id": 1}]`,
+			},
+			want: want{expected: true},
+		},
+		"InvalidYAMLResponse": {
+			args: args{
+				format:           common.ResponseBodyFormatYAML,
+				expectedResponse: `.body.job.status == "success"`,
+				body:             "job: [",
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errors.New("yaml: line 1: did not find expected node content"), "cannot parse the response body as YAML"), errConvertResToMap),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger()}
+			cr := &v1alpha2.DisposableRequest{
+				Spec: v1alpha2.DisposableRequestSpec{
+					ForProvider: v1alpha2.DisposableRequestParameters{
+						ExpectedResponse:   tc.args.expectedResponse,
+						ResponseBodyFormat: tc.args.format,
+					},
+				},
+				Status: v1alpha2.DisposableRequestStatus{
+					Response: v1alpha2.Response{StatusCode: 200},
+				},
+			}
+
+			got, gotErr := e.isResponseAsExpected(cr, httpClient.HttpResponse{StatusCode: 200, Body: tc.args.body})
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("isResponseAsExpected(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.expected, got); diff != "" {
+				t.Fatalf("isResponseAsExpected(...): -want expected, +got expected: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_scheduledPollInterval(t *testing.T) {
+	const defaultPollInterval = 30 * time.Second
+	lastReconcile := time.Date(2024, time.May, 15, 10, 0, 0, 0, time.UTC)
+
+	type args struct {
+		schedule string
+		now      time.Time
+	}
+
+	cases := map[string]struct {
+		args args
+		want time.Duration
+	}{
+		"UntilNextFireTime": {
+			args: args{
+				schedule: "0 */6 * * *",
+				now:      lastReconcile.Add(30 * time.Minute),
+			},
+			want: 90 * time.Minute,
+		},
+		"NextDay": {
+			args: args{
+				schedule: "15 9 * * *",
+				now:      lastReconcile.Add(time.Hour),
+			},
+			want: 22*time.Hour + 15*time.Minute,
+		},
+		"Due": {
+			args: args{
+				schedule: "*/5 * * * *",
+				now:      lastReconcile.Add(6 * time.Minute),
+			},
+			want: defaultPollInterval,
+		},
+		"InvalidSchedule": {
+			args: args{
+				schedule: "0 */6 * *",
+				now:      lastReconcile,
+			},
+			want: defaultPollInterval,
+		},
+		"NeverFires": {
+			args: args{
+				schedule: "0 0 30 2 *",
+				now:      lastReconcile,
+			},
+			want: defaultPollInterval,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := httpDisposableRequest(func(r *v1alpha2.DisposableRequest) {
+				r.Spec.ForProvider.Schedule = tc.args.schedule
+				r.Status.LastReconcileTime = v1.NewTime(lastReconcile)
+			})
+
+			got := scheduledPollInterval(cr, defaultPollInterval, tc.args.now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("scheduledPollInterval(...): -want interval, +got interval: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_ConnectionDetails(t *testing.T) {
+	connectionDetails := []common.ConnectionDetail{
+		{Key: "endpoint", ResponseJQ: ".body.endpoint"},
+		{Key: "token", ResponseJQ: ".body.token"},
+	}
+	want := managed.ConnectionDetails{
+		"endpoint": []byte("db.example.com"),
+		"token":    []byte("s3cr3t"),
+	}
+
+	e := &external{
+		localKube: &test.MockClient{
+			MockGet:          test.NewMockGetFn(nil),
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		},
+		logger: logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+				return httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"endpoint":"db.example.com","token":"s3cr3t"}`,
+					},
+				}, nil
+			},
+		},
+	}
+
+	cr := httpDisposableRequest(func(r *v1alpha2.DisposableRequest) {
+		r.Spec.ForProvider.ConnectionDetails = connectionDetails
+	})
+	created, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Create(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, created.ConnectionDetails); diff != "" {
+		t.Errorf("e.Create(...): -want connection details, +got connection details: %s", diff)
+	}
+
+	// The connection details are published again from the recorded response at every reconcile.
+	observed, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, observed.ConnectionDetails); diff != "" {
+		t.Errorf("e.Observe(...): -want connection details, +got connection details: %s", diff)
+	}
+}
+
+func Test_httpExternal_Observe_UpstreamCircuit(t *testing.T) {
+	type args struct {
+		annotations map[string]string
+		conditions  []xpv1.Condition
+	}
+	type want struct {
+		status      corev1.ConditionStatus
+		annotations map[string]string
+		updated     bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"OpenCircuitKeptAfterRefetch": {
+			args: args{},
+			want: want{
+				status: corev1.ConditionTrue,
+			},
+		},
+		"ResetAnnotationClosesCircuit": {
+			args: args{
+				annotations: map[string]string{utils.AnnotationKeyResetCircuitBreaker: "true"},
+				conditions:  []xpv1.Condition{utils.UpstreamCircuitOpen()},
+			},
+			want: want{
+				status:      corev1.ConditionFalse,
+				annotations: map[string]string{},
+				updated:     true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			httpClient.SetCircuitBreaker(1, time.Minute)
+			defer httpClient.SetCircuitBreaker(0, httpClient.DefaultCircuitOpenDuration)
+
+			// Open the circuit of the host of a server failing every request.
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+			c, err := httpClient.NewClient(logging.NewNopLogger(), time.Minute, "")
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+			body := httpClient.Data{Encrypted: "", Decrypted: ""}
+			headers := httpClient.Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+			if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, body, headers, false); err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+
+			updated := false
+			e := &external{
+				localKube: &test.MockClient{
+					// Fetching the latest version drops the conditions set during the reconcile.
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						if r, ok := obj.(*v1alpha2.DisposableRequest); ok {
+							r.Status.Conditions = tc.args.conditions
+						}
+						return nil
+					}),
+					MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+						updated = true
+						return nil
+					},
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+			}
+
+			cr := httpDisposableRequest(func(r *v1alpha2.DisposableRequest) {
+				r.Spec.ForProvider.URL = server.URL
+				r.Status.Synced = true
+				r.SetAnnotations(tc.args.annotations)
+			})
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.status, cr.GetCondition(utils.TypeUpstreamCircuitOpen).Status); diff != "" {
+				t.Errorf("e.Observe(...): -want circuit condition, +got circuit condition: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.annotations, cr.GetAnnotations()); diff != "" {
+				t.Errorf("e.Observe(...): -want annotations, +got annotations: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("e.Observe(...): -want updated, +got updated: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Create_BodyFrom(t *testing.T) {
+	bodyFrom := &common.BodyFrom{
+		ConfigMapKeyRef: &common.ConfigMapKeyRef{Name: "payloads", Namespace: "default", Key: "user.json"},
+	}
+
+	cases := map[string]struct {
+		body string
+		want string
+	}{
+		"BodyFromConfigMap": {
+			want: `{"username": "from_config_map"}`,
+		},
+		"InlineBodyTakesPrecedence": {
+			body: `{"username": "inline"}`,
+			want: `{"username": "inline"}`,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var sent string
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						if configMap, ok := obj.(*corev1.ConfigMap); ok {
+							configMap.Data = map[string]string{"user.json": `{"username": "from_config_map"}`}
+						}
+						return nil
+					}),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						sent = body.Decrypted.(string)
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: 200}}, nil
+					},
+				},
+			}
+
+			cr := httpDisposableRequest(func(r *v1alpha2.DisposableRequest) {
+				r.Spec.ForProvider.Body = tc.body
+				r.Spec.ForProvider.BodyFrom = bodyFrom
+			})
+			if _, err := e.Create(context.Background(), cr); err != nil {
+				t.Fatalf("e.Create(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, sent); diff != "" {
+				t.Errorf("e.Create(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
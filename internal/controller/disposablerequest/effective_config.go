@@ -0,0 +1,49 @@
+package disposablerequest
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const errGetDisposableRequest = "cannot get DisposableRequest"
+
+// connectionParameters returns the parameters of the DisposableRequest that configure its HTTP client.
+func connectionParameters(cr *v1alpha2.DisposableRequest, propagateTraceContext bool) utils.ConnectionParameters {
+	params := cr.Spec.ForProvider
+	return utils.ConnectionParameters{
+		WaitTimeout:           params.WaitTimeout,
+		InsecureSkipTLSVerify: params.InsecureSkipTLSVerify,
+		TLSRenegotiation:      params.TLSRenegotiation,
+		RoutingProfile:        params.RoutingProfile,
+		Proxy:                 params.Proxy,
+		TLS:                   params.TLS,
+		MaxResponseBodyBytes:  params.MaxResponseBodyBytes,
+		MaxStatusBodyBytes:    params.MaxStatusBodyBytes,
+		CorrelationHeaders:    params.CorrelationHeaders,
+		Failed:                cr.Status.Failed,
+		PropagateTraceContext: propagateTraceContext,
+	}
+}
+
+// EffectiveConfig returns the configuration the named DisposableRequest is reconciled with, once
+// merged with its ProviderConfig and the defaults.
+func EffectiveConfig(ctx context.Context, kube client.Client, name string, propagateTraceContext bool) (any, error) {
+	cr := &v1alpha2.DisposableRequest{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: name}, cr); err != nil {
+		return nil, errors.Wrap(err, errGetDisposableRequest)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: utils.ProviderConfigReference(cr).Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errProviderNotRetrieved)
+	}
+
+	return utils.NewEffectiveConfig(cr, v1alpha2.DisposableRequestKind, connectionParameters(cr, propagateTraceContext), pc)
+}
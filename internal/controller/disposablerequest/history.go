@@ -0,0 +1,47 @@
+package disposablerequest
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+// maxHistoryBodyBytes caps the response bodies kept in the history, so that it doesn't bloat etcd.
+const maxHistoryBodyBytes = 1024
+
+// recordHistory returns a status setter appending the response, or the error of a request without response,
+// to the history of the DisposableRequest.
+func recordHistory(cr *v1alpha2.DisposableRequest, response httpClient.HttpResponse, err error) utils.SetRequestStatusFunc {
+	return func() {
+		appendHistory(cr, response, err, time.Now())
+	}
+}
+
+// appendHistory appends an entry to the history of the DisposableRequest, dropping the oldest entries beyond
+// its history limit. The history is cleared when the limit is 0.
+func appendHistory(cr *v1alpha2.DisposableRequest, response httpClient.HttpResponse, err error, now time.Time) {
+	limit := int(cr.Spec.ForProvider.HistoryLimit)
+	if limit <= 0 {
+		cr.Status.History = nil
+		return
+	}
+
+	entry := v1alpha2.HistoryEntry{
+		StatusCode: response.StatusCode,
+		Timestamp:  metav1.NewTime(now),
+		Body:       utils.TruncateBody(response.Body, maxHistoryBodyBytes),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	history := append(cr.Status.History, entry)
+	if len(history) > limit {
+		history = append([]v1alpha2.HistoryEntry(nil), history[len(history)-limit:]...)
+	}
+	cr.Status.History = history
+}
@@ -0,0 +1,93 @@
+package disposablerequest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+func Test_appendHistory(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(statusCode int, body string) v1alpha2.HistoryEntry {
+		return v1alpha2.HistoryEntry{StatusCode: statusCode, Timestamp: metav1.NewTime(now), Body: body}
+	}
+	largeBody := strings.Repeat("a", maxHistoryBodyBytes+1)
+
+	type args struct {
+		limit    int32
+		history  []v1alpha2.HistoryEntry
+		response httpClient.HttpResponse
+		err      error
+	}
+
+	cases := map[string]struct {
+		args args
+		want []v1alpha2.HistoryEntry
+	}{
+		"Appended": {
+			args: args{
+				limit:    3,
+				history:  []v1alpha2.HistoryEntry{entry(500, "first")},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: "second"},
+			},
+			want: []v1alpha2.HistoryEntry{entry(500, "first"), entry(200, "second")},
+		},
+		"TrimmedAtLimit": {
+			args: args{
+				limit:    2,
+				history:  []v1alpha2.HistoryEntry{entry(500, "first"), entry(502, "second")},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: "third"},
+			},
+			want: []v1alpha2.HistoryEntry{entry(502, "second"), entry(200, "third")},
+		},
+		"TrimmedAfterLimitLowered": {
+			args: args{
+				limit:    1,
+				history:  []v1alpha2.HistoryEntry{entry(500, "first"), entry(502, "second")},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: "third"},
+			},
+			want: []v1alpha2.HistoryEntry{entry(200, "third")},
+		},
+		"RequestFailed": {
+			args: args{
+				limit: 2,
+				err:   errBoom,
+			},
+			want: []v1alpha2.HistoryEntry{{Timestamp: metav1.NewTime(now), Error: errBoom.Error()}},
+		},
+		"BodyTruncated": {
+			args: args{
+				limit:    1,
+				response: httpClient.HttpResponse{StatusCode: 200, Body: largeBody},
+			},
+			want: []v1alpha2.HistoryEntry{entry(200, utils.TruncateBody(largeBody, maxHistoryBodyBytes))},
+		},
+		"DisabledClearsHistory": {
+			args: args{
+				history:  []v1alpha2.HistoryEntry{entry(500, "first")},
+				response: httpClient.HttpResponse{StatusCode: 200, Body: "second"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.DisposableRequest{}
+			cr.Spec.ForProvider.HistoryLimit = tc.args.limit
+			cr.Status.History = tc.args.history
+
+			appendHistory(cr, tc.args.response, tc.args.err, now)
+			if diff := cmp.Diff(tc.want, cr.Status.History); diff != "" {
+				t.Errorf("appendHistory(...): -want history, +got history: %s", diff)
+			}
+		})
+	}
+}
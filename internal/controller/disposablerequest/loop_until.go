@@ -0,0 +1,36 @@
+package disposablerequest
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errLoopUntil = "failed to evaluate the loopUntil expression"
+)
+
+// isLoopCompleted returns true if the DisposableRequest loops infinitely and the response matches its
+// loopUntil expression, i.e. the request must not be sent again.
+func isLoopCompleted(cr *v1alpha2.DisposableRequest, res httpClient.HttpResponse) (bool, error) {
+	if !cr.Spec.ForProvider.ShouldLoopInfinitely || cr.Spec.ForProvider.LoopUntil == "" {
+		return false, nil
+	}
+
+	completed, err := evaluateResponse(cr, res, cr.Spec.ForProvider.LoopUntil)
+	if err != nil {
+		return false, errors.Wrap(err, errLoopUntil)
+	}
+
+	return completed, nil
+}
+
+// setLoopCompleted returns a status setter recording whether the infinite loop of the DisposableRequest
+// is completed.
+func setLoopCompleted(cr *v1alpha2.DisposableRequest, completed bool) utils.SetRequestStatusFunc {
+	return func() {
+		cr.Status.LoopCompleted = completed
+	}
+}
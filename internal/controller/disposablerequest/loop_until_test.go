@@ -0,0 +1,126 @@
+package disposablerequest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_isLoopCompleted(t *testing.T) {
+	type args struct {
+		shouldLoopInfinitely bool
+		loopUntil            string
+		body                 string
+	}
+	type want struct {
+		completed bool
+		wantErr   bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ConditionMet": {
+			args: args{shouldLoopInfinitely: true, loopUntil: `.body.status == "complete"`, body: `{"status":"complete"}`},
+			want: want{completed: true},
+		},
+		"ConditionNotMet": {
+			args: args{shouldLoopInfinitely: true, loopUntil: `.body.status == "complete"`, body: `{"status":"running"}`},
+			want: want{completed: false},
+		},
+		"NoLoopUntil": {
+			args: args{shouldLoopInfinitely: true, body: `{"status":"complete"}`},
+			want: want{completed: false},
+		},
+		"NotLooping": {
+			args: args{loopUntil: `.body.status == "complete"`, body: `{"status":"complete"}`},
+			want: want{completed: false},
+		},
+		"NotABoolean": {
+			args: args{shouldLoopInfinitely: true, loopUntil: `.body.status`, body: `{"status":"complete"}`},
+			want: want{wantErr: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := httpDisposableRequest(func(r *v1alpha2.DisposableRequest) {
+				r.Spec.ForProvider.ShouldLoopInfinitely = tc.args.shouldLoopInfinitely
+				r.Spec.ForProvider.LoopUntil = tc.args.loopUntil
+			})
+
+			got, err := isLoopCompleted(cr, httpClient.HttpResponse{StatusCode: 200, Body: tc.args.body})
+			if diff := cmp.Diff(tc.want.wantErr, err != nil); diff != "" {
+				t.Fatalf("isLoopCompleted(...): -want error, +got error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.completed, got); diff != "" {
+				t.Errorf("isLoopCompleted(...): -want completed, +got completed: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_LoopUntil(t *testing.T) {
+	statuses := []string{"pending", "running", "complete"}
+	sent := 0
+	e := &external{
+		localKube: &test.MockClient{
+			MockGet:          test.NewMockGetFn(nil),
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		},
+		logger: logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+				status := statuses[sent]
+				sent++
+				return httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: 200, Body: `{"status":"` + status + `"}`},
+				}, nil
+			},
+		},
+	}
+
+	cr := httpDisposableRequest(func(r *v1alpha2.DisposableRequest) {
+		r.Spec.ForProvider.ShouldLoopInfinitely = true
+		r.Spec.ForProvider.LoopUntil = `.body.status == "complete"`
+	})
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error: %s", err)
+	}
+
+	// The request is sent again until its response matches the loopUntil expression.
+	for i := 1; i < len(statuses); i++ {
+		observation, err := e.Observe(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("e.Observe(...): unexpected error: %s", err)
+		}
+		if observation.ResourceUpToDate {
+			t.Fatalf("e.Observe(...): the loop stopped after the %q response", statuses[i-1])
+		}
+		if _, err := e.Update(context.Background(), cr); err != nil {
+			t.Fatalf("e.Update(...): unexpected error: %s", err)
+		}
+	}
+
+	observation, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(true, observation.ResourceUpToDate); diff != "" {
+		t.Errorf("e.Observe(...): -want up to date, +got up to date: %s", diff)
+	}
+	if diff := cmp.Diff(true, cr.Status.LoopCompleted); diff != "" {
+		t.Errorf("e.Observe(...): -want loop completed, +got loop completed: %s", diff)
+	}
+	if diff := cmp.Diff(len(statuses), sent); diff != "" {
+		t.Errorf("e.Observe(...): -want requests, +got requests: %s", diff)
+	}
+}
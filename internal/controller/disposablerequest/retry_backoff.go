@@ -0,0 +1,41 @@
+package disposablerequest
+
+import (
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const defaultRetryBackoffFactor = 2
+
+// retryBackoffInterval returns the interval before the retry of a request that failed the given
+// number of times, min(base * factor^failed, max).
+func retryBackoffInterval(backoff *v1alpha2.RetryBackoff, failed int32) time.Duration {
+	factor := time.Duration(defaultRetryBackoffFactor)
+	if backoff.Factor != nil {
+		factor = time.Duration(*backoff.Factor)
+	}
+
+	interval, maxInterval := backoff.Base.Duration, backoff.Max.Duration
+	for i := int32(0); i < failed && interval < maxInterval; i++ {
+		if factor > 1 && interval > maxInterval/factor {
+			return maxInterval
+		}
+		interval *= factor
+	}
+
+	return min(interval, maxInterval)
+}
+
+// retryBackoffRemaining returns the time left before the failed DisposableRequest is retried, and
+// false when it isn't backing off.
+func retryBackoffRemaining(cr *v1alpha2.DisposableRequest, now time.Time) (time.Duration, bool) {
+	backoff, limit, failed := cr.Spec.ForProvider.RetryBackoff, cr.Spec.ForProvider.RollbackRetriesLimit, cr.Status.Failed
+	if backoff == nil || !utils.ShouldRetry(limit, failed) || utils.RetriesLimitReached(failed, limit) {
+		return 0, false
+	}
+
+	remaining := cr.Status.LastReconcileTime.Add(retryBackoffInterval(backoff, failed)).Sub(now)
+	return remaining, remaining > 0
+}
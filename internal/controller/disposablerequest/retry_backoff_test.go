@@ -0,0 +1,164 @@
+package disposablerequest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+)
+
+func Test_retryBackoffInterval(t *testing.T) {
+	type args struct {
+		factor *int32
+		failed int32
+	}
+
+	cases := map[string]struct {
+		args args
+		want time.Duration
+	}{
+		"NoFailure": {
+			args: args{failed: 0},
+			want: 10 * time.Second,
+		},
+		"FirstFailure": {
+			args: args{failed: 1},
+			want: 20 * time.Second,
+		},
+		"SecondFailure": {
+			args: args{failed: 2},
+			want: 40 * time.Second,
+		},
+		"FourthFailure": {
+			args: args{failed: 4},
+			want: 160 * time.Second,
+		},
+		"CappedAtMax": {
+			args: args{failed: 6},
+			want: 5 * time.Minute,
+		},
+		"ManyFailuresDontOverflow": {
+			args: args{failed: 100},
+			want: 5 * time.Minute,
+		},
+		"CustomFactor": {
+			args: args{factor: ptr.To[int32](3), failed: 2},
+			want: 90 * time.Second,
+		},
+		"ConstantFactor": {
+			args: args{factor: ptr.To[int32](1), failed: 5},
+			want: 10 * time.Second,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			backoff := &v1alpha2.RetryBackoff{
+				Base:   v1.Duration{Duration: 10 * time.Second},
+				Factor: tc.args.factor,
+				Max:    v1.Duration{Duration: 5 * time.Minute},
+			}
+
+			got := retryBackoffInterval(backoff, tc.args.failed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("retryBackoffInterval(...): -want interval, +got interval: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_retryBackoffRemaining(t *testing.T) {
+	lastReconcile := time.Date(2024, time.May, 15, 10, 0, 0, 0, time.UTC)
+	backoff := &v1alpha2.RetryBackoff{
+		Base: v1.Duration{Duration: 10 * time.Second},
+		Max:  v1.Duration{Duration: 5 * time.Minute},
+	}
+
+	type args struct {
+		backoff *v1alpha2.RetryBackoff
+		limit   *int32
+		failed  int32
+		now     time.Time
+	}
+	type want struct {
+		remaining  time.Duration
+		backingOff bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"BackingOff": {
+			args: args{
+				backoff: backoff,
+				limit:   ptr.To[int32](5),
+				failed:  2,
+				now:     lastReconcile.Add(15 * time.Second),
+			},
+			want: want{remaining: 25 * time.Second, backingOff: true},
+		},
+		"BackoffElapsed": {
+			args: args{
+				backoff: backoff,
+				limit:   ptr.To[int32](5),
+				failed:  2,
+				now:     lastReconcile.Add(time.Minute),
+			},
+			want: want{remaining: -20 * time.Second},
+		},
+		"NotFailed": {
+			args: args{
+				backoff: backoff,
+				limit:   ptr.To[int32](5),
+				now:     lastReconcile,
+			},
+		},
+		"RetriesLimitReached": {
+			args: args{
+				backoff: backoff,
+				limit:   ptr.To[int32](2),
+				failed:  2,
+				now:     lastReconcile,
+			},
+		},
+		"NoRetriesLimit": {
+			args: args{
+				backoff: backoff,
+				failed:  2,
+				now:     lastReconcile,
+			},
+		},
+		"NoBackoff": {
+			args: args{
+				limit:  ptr.To[int32](5),
+				failed: 2,
+				now:    lastReconcile,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := httpDisposableRequest(func(r *v1alpha2.DisposableRequest) {
+				r.Spec.ForProvider.RetryBackoff = tc.args.backoff
+				r.Spec.ForProvider.RollbackRetriesLimit = tc.args.limit
+				r.Status.Failed = tc.args.failed
+				r.Status.LastReconcileTime = v1.NewTime(lastReconcile)
+			})
+
+			remaining, backingOff := retryBackoffRemaining(cr, tc.args.now)
+			if diff := cmp.Diff(tc.want, want{remaining: remaining, backingOff: backingOff}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("retryBackoffRemaining(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -20,24 +20,58 @@ import (
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane-contrib/provider-http/internal/controller/config"
 	disposablerequest "github.com/crossplane-contrib/provider-http/internal/controller/disposablerequest"
 	request "github.com/crossplane-contrib/provider-http/internal/controller/request"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
+// Concurrency overrides the maximum number of concurrent reconciles of each
+// managed resource kind. Zero values keep the global MaxConcurrentReconciles.
+type Concurrency struct {
+	Request           int
+	DisposableRequest int
+}
+
+type setupFn func(ctrl.Manager, controller.Options, time.Duration, *utils.PauseSwitch) error
+
 // Setup creates all http controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error {
-	for _, setup := range []func(ctrl.Manager, controller.Options, time.Duration) error{
-		config.Setup,
-		disposablerequest.Setup,
-		request.Setup,
+// the supplied manager. The reconciles of the managed resources are paused while
+// the given pause ConfigMap sets its paused key to true.
+func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration, pauseConfigMap types.NamespacedName, concurrency Concurrency) error {
+	if err := config.Setup(mgr, o, timeout); err != nil {
+		return err
+	}
+
+	pause := utils.NewPauseSwitch(mgr.GetClient(), pauseConfigMap)
+	return setupManaged(mgr, o, timeout, pause, concurrency, disposablerequest.Setup, request.Setup)
+}
+
+// setupManaged creates the controllers of the managed resources, each with its
+// own maximum number of concurrent reconciles.
+func setupManaged(mgr ctrl.Manager, o controller.Options, timeout time.Duration, pause *utils.PauseSwitch, concurrency Concurrency, disposableRequestSetup, requestSetup setupFn) error {
+	for _, c := range []struct {
+		setup                   setupFn
+		maxConcurrentReconciles int
+	}{
+		{setup: disposableRequestSetup, maxConcurrentReconciles: concurrency.DisposableRequest},
+		{setup: requestSetup, maxConcurrentReconciles: concurrency.Request},
 	} {
-		if err := setup(mgr, o, timeout); err != nil {
+		if err := c.setup(mgr, withMaxConcurrentReconciles(o, c.maxConcurrentReconciles), timeout, pause); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// withMaxConcurrentReconciles returns a copy of the options with the given
+// maximum number of concurrent reconciles, when set.
+func withMaxConcurrentReconciles(o controller.Options, maxConcurrentReconciles int) controller.Options {
+	if maxConcurrentReconciles > 0 {
+		o.MaxConcurrentReconciles = maxConcurrentReconciles
+	}
+	return o
+}
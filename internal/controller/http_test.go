@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/google/go-cmp/cmp"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+func Test_setupManaged(t *testing.T) {
+	type args struct {
+		concurrency Concurrency
+	}
+	type want struct {
+		request           int
+		disposableRequest int
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"PerKindOverrides": {
+			args: args{
+				concurrency: Concurrency{Request: 20, DisposableRequest: 2},
+			},
+			want: want{
+				request:           20,
+				disposableRequest: 2,
+			},
+		},
+		"OneKindOverridden": {
+			args: args{
+				concurrency: Concurrency{DisposableRequest: 2},
+			},
+			want: want{
+				request:           10,
+				disposableRequest: 2,
+			},
+		},
+		"GlobalDefault": {
+			args: args{},
+			want: want{
+				request:           10,
+				disposableRequest: 10,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got := want{}
+			recordInto := func(max *int) setupFn {
+				return func(_ ctrl.Manager, o controller.Options, _ time.Duration, _ *utils.PauseSwitch) error {
+					*max = o.MaxConcurrentReconciles
+					return nil
+				}
+			}
+
+			o := controller.Options{MaxConcurrentReconciles: 10}
+			if err := setupManaged(nil, o, time.Minute, nil, tc.args.concurrency, recordInto(&got.disposableRequest), recordInto(&got.request)); err != nil {
+				t.Fatalf("setupManaged(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Fatalf("setupManaged(...): -want concurrency, +got concurrency: %s", diff)
+			}
+		})
+	}
+}
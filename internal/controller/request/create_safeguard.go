@@ -0,0 +1,63 @@
+package request
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/observe"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestmapping"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/statushandler"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errCreateSafeguard           = "failed to look up the object before creating it"
+	errCreateSafeguardStatusCode = "the lookup before CREATE returned the status code %d, which doesn't mean that the object is absent"
+)
+
+// adoptExisting looks up the object of the Request with the OBSERVE mapping sent to the URL of the create
+// safeguard, and records the response in the status when it is found, as if it had just been created, so
+// that CREATE is not sent again for an object whose status was lost. Returns true if the object was found.
+func (c *external) adoptExisting(ctx context.Context, cr *v1alpha2.Request) (bool, error) {
+	observeMapping, err := requestmapping.GetMapping(&cr.Spec.ForProvider, v1alpha2.ActionObserve, c.logger)
+	if err != nil {
+		return false, errors.Wrap(err, errCreateSafeguard)
+	}
+
+	lookup := *observeMapping
+	lookup.URL = cr.Spec.ForProvider.CreateSafeguard.URL
+
+	requestDetails, err := requestgen.GenerateValidRequestDetails(ctx, cr, &lookup, c.localKube, c.logger)
+	if err != nil {
+		return false, errors.Wrap(err, errCreateSafeguard)
+	}
+
+	details, err := c.sendRequest(ctx, cr, &lookup, requestDetails)
+	if err != nil {
+		return false, errors.Wrap(err, errCreateSafeguard)
+	}
+
+	// Only a response meaning that the object is absent allows CREATE, any other failure of the lookup, e.g. a
+	// 503, could hide an existing object.
+	removedErr := observe.GetIsRemovedResponseCheck(cr, c.localKube, c.logger, c.http).Check(ctx, cr, details, nil)
+	switch {
+	case removedErr != nil && removedErr.Error() == observe.ErrObjectNotFound:
+		c.logger.Debug("the object was not found before CREATE", "url", requestDetails.Url, "statusCode", details.HttpResponse.StatusCode)
+		return false, nil
+	case removedErr != nil:
+		return false, errors.Wrap(removedErr, errCreateSafeguard)
+	case utils.IsHTTPError(details.HttpResponse.StatusCode):
+		return false, errors.Errorf(errCreateSafeguardStatusCode, details.HttpResponse.StatusCode)
+	}
+
+	c.logger.Info("the object already exists, skipping CREATE", "url", requestDetails.Url)
+	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, details, nil, c.localKube, c.logger)
+	if err != nil {
+		return true, err
+	}
+
+	return true, statusHandler.SetRequestStatus()
+}
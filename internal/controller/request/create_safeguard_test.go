@@ -0,0 +1,177 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_httpExternal_Create_CreateSafeguard(t *testing.T) {
+	type args struct {
+		safeguard        *v1alpha2.CreateSafeguard
+		lookupStatusCode int
+		lookupErr        error
+	}
+	type want struct {
+		requests   []string
+		statusCode int
+		err        error
+	}
+
+	safeguard := &v1alpha2.CreateSafeguard{URL: `(.payload.baseUrl + "/" + .payload.body.username)`}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ExistingObjectSkipsCreate": {
+			args: args{
+				safeguard:        safeguard,
+				lookupStatusCode: 200,
+			},
+			want: want{
+				requests:   []string{"GET https://api.example.com/users/john_doe"},
+				statusCode: 200,
+			},
+		},
+		"MissingObjectIsCreated": {
+			args: args{
+				safeguard:        safeguard,
+				lookupStatusCode: 404,
+			},
+			want: want{
+				requests:   []string{"GET https://api.example.com/users/john_doe", "POST https://api.example.com/users"},
+				statusCode: 201,
+			},
+		},
+		"LookupUnavailableSkipsCreate": {
+			args: args{
+				safeguard:        safeguard,
+				lookupStatusCode: 503,
+			},
+			want: want{
+				requests: []string{"GET https://api.example.com/users/john_doe"},
+				err:      errors.Wrap(errors.Errorf(errCreateSafeguardStatusCode, 503), errFailedToSendHttpRequest),
+			},
+		},
+		"LookupFailedSkipsCreate": {
+			args: args{
+				safeguard: safeguard,
+				lookupErr: errBoom,
+			},
+			want: want{
+				requests: []string{"GET https://api.example.com/users/john_doe"},
+				err:      errors.Wrap(errors.Wrap(errBoom, errCreateSafeguard), errFailedToSendHttpRequest),
+			},
+		},
+		"SafeguardDisabled": {
+			args: args{},
+			want: want{
+				requests:   []string{"POST https://api.example.com/users"},
+				statusCode: 201,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						requests = append(requests, method+" "+url)
+						details := httpClient.HttpDetails{
+							HttpRequest: httpClient.HttpRequest{Method: method, URL: url},
+						}
+						if method == "GET" {
+							details.HttpResponse = httpClient.HttpResponse{StatusCode: tc.args.lookupStatusCode, Body: `{"id":"123"}`}
+							return details, tc.args.lookupErr
+						}
+						details.HttpResponse = httpClient.HttpResponse{StatusCode: 201, Body: `{"id":"123"}`}
+						return details, nil
+					},
+				},
+			}
+
+			// The status of the Request was lost, e.g. after a restore from a backup.
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.CreateSafeguard = tc.args.safeguard
+			})
+			_, gotErr := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Create(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, requests); diff != "" {
+				t.Errorf("e.Create(...): -want requests, +got requests: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.statusCode, cr.Status.Response.StatusCode); diff != "" {
+				t.Errorf("e.Create(...): -want status code, +got status code: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Create_CookieJar(t *testing.T) {
+	var cookies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookies = append(cookies, r.Method+" "+r.Header.Get("Cookie"))
+		if r.Method == http.MethodGet {
+			// The lookup of the create safeguard logs in and sets a session cookie.
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123"})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	// A client, with its own cookie jar, is created at every reconcile.
+	reconcile := func() {
+		h, err := httpClient.NewClient(logging.NewNopLogger(), time.Minute, "", httpClient.WithCookieJar())
+		if err != nil {
+			t.Fatalf("httpClient.NewClient(...): unexpected error: %s", err)
+		}
+		e := &external{
+			localKube: &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			},
+			logger: logging.NewNopLogger(),
+			http:   h,
+		}
+
+		cr := httpRequest(func(r *v1alpha2.Request) {
+			r.Spec.ForProvider.Payload.BaseUrl = server.URL + "/users"
+			r.Spec.ForProvider.UseCookieJar = true
+			r.Spec.ForProvider.CreateSafeguard = &v1alpha2.CreateSafeguard{URL: `(.payload.baseUrl + "/" + .payload.body.username)`}
+		})
+		if _, err := e.Create(context.Background(), cr); err != nil {
+			t.Fatalf("e.Create(...): unexpected error: %s", err)
+		}
+	}
+
+	reconcile()
+	reconcile()
+
+	// The cookie set by the first mapping is sent on the second one, but not on the next reconcile.
+	want := []string{"GET ", "POST session=abc123", "GET ", "POST session=abc123"}
+	if diff := cmp.Diff(want, cookies); diff != "" {
+		t.Errorf("e.Create(...): -want cookies, +got cookies: %s", diff)
+	}
+}
@@ -0,0 +1,82 @@
+package request
+
+import (
+	"context"
+	"slices"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/observe"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestmapping"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errDeletionCheck = "failed to verify the deletion of the object"
+)
+
+// awaitsDeletion returns true if the REMOVE mapping of the deleted Request was sent and its deletion check
+// is polled until the object is gone.
+func awaitsDeletion(cr *v1alpha2.Request) bool {
+	return meta.WasDeleted(cr) && cr.Status.RemoveRequested != nil && cr.Spec.ForProvider.DeletionCheck != nil && !hasItems(cr)
+}
+
+// recordRemoveRequested records that the REMOVE mapping was sent, when the deletion of the Request is
+// verified by a deletion check, so that it is not sent again while the object is being deleted.
+func recordRemoveRequested(cr *v1alpha2.Request) {
+	if cr.Spec.ForProvider.DeletionCheck == nil || hasItems(cr) {
+		return
+	}
+
+	cr.Status.RemoveRequested = &v1alpha2.DriftCheck{Time: metav1.Now(), Generation: cr.Generation}
+}
+
+// isDeleted sends the OBSERVE mapping after the removal of the object, and returns true once the response
+// matches the deletion check.
+func (c *external) isDeleted(ctx context.Context, cr *v1alpha2.Request) (bool, error) {
+	mapping, err := requestmapping.GetMapping(&cr.Spec.ForProvider, v1alpha2.ActionObserve, c.logger)
+	if err != nil {
+		return false, errors.Wrap(err, errDeletionCheck)
+	}
+
+	requestDetails, err := requestgen.GenerateValidRequestDetails(ctx, cr, mapping, c.localKube, c.logger)
+	if err != nil {
+		return false, errors.Wrap(err, errDeletionCheck)
+	}
+
+	details, err := c.sendRequest(ctx, cr, mapping, requestDetails)
+	if err != nil {
+		return false, errors.Wrap(err, errDeletionCheck)
+	}
+
+	check := cr.Spec.ForProvider.DeletionCheck
+	removed := slices.Contains(check.StatusCodes, details.HttpResponse.StatusCode)
+	if len(check.StatusCodes) == 0 && check.Logic == "" {
+		removed = observe.ObservedAbsent(cr, details.HttpResponse)
+	}
+	if !removed && check.Logic != "" {
+		response := responseconverter.HttpResponseToV1alpha1Response(details.HttpResponse)
+		responseMap, err := requestgen.GenerateRequestObject(cr.Spec.ForProvider, cr, response)
+		if err != nil {
+			return false, errors.Wrap(err, errDeletionCheck)
+		}
+
+		if removed, err = jq.ParseBool(utils.NormalizeWhitespace(check.Logic), responseMap); err != nil {
+			return false, errors.Wrap(err, errDeletionCheck)
+		}
+	}
+
+	if !removed {
+		c.logger.Debug("the object is still being deleted", "url", requestDetails.Url, "statusCode", details.HttpResponse.StatusCode)
+		return false, nil
+	}
+
+	c.logger.Debug("the object was removed", "url", requestDetails.Url, "statusCode", details.HttpResponse.StatusCode)
+	return true, nil
+}
@@ -0,0 +1,201 @@
+package request
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_httpExternal_Delete_DeletionCheck(t *testing.T) {
+	type args struct {
+		check *v1alpha2.DeletionCheck
+		// removedAfter is the number of OBSERVE requests after the DELETE request after which the object is gone.
+		removedAfter int
+		deleteErr    error
+		observeErr   error
+		polls        int
+	}
+	type want struct {
+		errs     []error
+		deleted  bool
+		requests []string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ImmediateDeletion": {
+			args: args{
+				check:        &v1alpha2.DeletionCheck{},
+				removedAfter: 1,
+				polls:        2,
+			},
+			want: want{
+				errs:    []error{nil, nil},
+				deleted: true,
+				requests: []string{
+					"GET https://api.example.com/users/123", "DELETE https://api.example.com/users/123",
+					"GET https://api.example.com/users/123",
+				},
+			},
+		},
+		"EventualDeletion": {
+			args: args{
+				check:        &v1alpha2.DeletionCheck{},
+				removedAfter: 3,
+				polls:        4,
+			},
+			want: want{
+				errs:    []error{nil, nil, nil, nil},
+				deleted: true,
+				requests: []string{
+					"GET https://api.example.com/users/123", "DELETE https://api.example.com/users/123",
+					"GET https://api.example.com/users/123",
+					"GET https://api.example.com/users/123",
+					"GET https://api.example.com/users/123",
+				},
+			},
+		},
+		"EventualDeletionWithLogic": {
+			args: args{
+				check:        &v1alpha2.DeletionCheck{Logic: `.response.body.state == "deleted"`},
+				removedAfter: 2,
+				polls:        3,
+			},
+			want: want{
+				errs:    []error{nil, nil, nil},
+				deleted: true,
+				requests: []string{
+					"GET https://api.example.com/users/123", "DELETE https://api.example.com/users/123",
+					"GET https://api.example.com/users/123",
+					"GET https://api.example.com/users/123",
+				},
+			},
+		},
+		"CustomStatusCodes": {
+			args: args{
+				check:        &v1alpha2.DeletionCheck{StatusCodes: []int{410}},
+				removedAfter: 1,
+				polls:        2,
+			},
+			want: want{
+				errs: []error{nil, nil},
+				requests: []string{
+					"GET https://api.example.com/users/123", "DELETE https://api.example.com/users/123",
+					"GET https://api.example.com/users/123",
+				},
+			},
+		},
+		"DeletionFailed": {
+			args: args{
+				check:     &v1alpha2.DeletionCheck{},
+				deleteErr: errBoom,
+				polls:     1,
+			},
+			want: want{
+				errs:     []error{errors.Wrap(errBoom, errFailedToSendHttpRequest)},
+				requests: []string{"GET https://api.example.com/users/123", "DELETE https://api.example.com/users/123"},
+			},
+		},
+		"DeletionCheckFailed": {
+			args: args{
+				check:      &v1alpha2.DeletionCheck{},
+				observeErr: errBoom,
+				polls:      2,
+			},
+			want: want{
+				errs: []error{nil, errors.Wrap(errBoom, errDeletionCheck)},
+				requests: []string{
+					"GET https://api.example.com/users/123", "DELETE https://api.example.com/users/123",
+					"GET https://api.example.com/users/123",
+				},
+			},
+		},
+		"DeletionCheckDisabled": {
+			args: args{
+				removedAfter: 3,
+				polls:        1,
+			},
+			want: want{
+				errs:     []error{nil},
+				requests: []string{"GET https://api.example.com/users/123", "DELETE https://api.example.com/users/123"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			deletes, checks := 0, 0
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						requests = append(requests, method+" "+url)
+						details := httpClient.HttpDetails{
+							HttpRequest:  httpClient.HttpRequest{Method: method, URL: url},
+							HttpResponse: httpClient.HttpResponse{StatusCode: 200, Body: `{"id":"123","state":"deleting"}`},
+						}
+						if method == "DELETE" {
+							deletes++
+							details.HttpResponse.StatusCode = 202
+							return details, tc.args.deleteErr
+						}
+						if deletes == 0 {
+							return details, nil
+						}
+						if checks++; checks >= tc.args.removedAfter {
+							details.HttpResponse = httpClient.HttpResponse{StatusCode: 404, Body: `{"id":"123","state":"deleted"}`}
+							if tc.args.check != nil && tc.args.check.Logic != "" {
+								details.HttpResponse.StatusCode = 200
+							}
+						}
+						return details, tc.args.observeErr
+					},
+				},
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.DeletionCheck = tc.args.check
+				r.Status.Response = v1alpha2.Response{StatusCode: 200, Body: `{"id":"123"}`}
+				r.SetDeletionTimestamp(&v1.Time{Time: time.Now()})
+			})
+
+			// Every poll observes the Request being deleted, and deletes it while it exists.
+			var errs []error
+			deleted := false
+			for i := 0; i < tc.args.polls && !deleted; i++ {
+				observation, err := e.Observe(context.Background(), cr)
+				if err == nil && observation.ResourceExists {
+					err = e.Delete(context.Background(), cr)
+				}
+				deleted = err == nil && !observation.ResourceExists
+				errs = append(errs, err)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Delete(...): -want errors, +got errors: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("e.Delete(...): -want deleted, +got deleted: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, requests); diff != "" {
+				t.Errorf("e.Delete(...): -want requests, +got requests: %s", diff)
+			}
+		})
+	}
+}
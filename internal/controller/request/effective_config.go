@@ -0,0 +1,66 @@
+package request
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const errGetRequest = "cannot get Request"
+
+// effectiveConfig is the configuration a Request is reconciled with.
+type effectiveConfig struct {
+	utils.EffectiveConfig `json:",inline"`
+
+	ResponseTransform     string                         `json:"responseTransform,omitempty"`
+	ExpectedResponseCheck v1alpha2.ExpectedResponseCheck `json:"expectedResponseCheck"`
+}
+
+// connectionParameters returns the parameters of the Request that configure its HTTP client.
+func connectionParameters(cr *v1alpha2.Request, propagateTraceContext bool) utils.ConnectionParameters {
+	params := cr.Spec.ForProvider
+	return utils.ConnectionParameters{
+		WaitTimeout:           params.WaitTimeout,
+		InsecureSkipTLSVerify: params.InsecureSkipTLSVerify,
+		TLSRenegotiation:      params.TLSRenegotiation,
+		RoutingProfile:        params.RoutingProfile,
+		Proxy:                 params.Proxy,
+		TLS:                   params.TLS,
+		MaxResponseBodyBytes:  params.MaxResponseBodyBytes,
+		UseCookieJar:          params.UseCookieJar,
+		CorrelationHeaders:    params.CorrelationHeaders,
+		Failed:                cr.Status.Failed,
+		PropagateTraceContext: propagateTraceContext,
+	}
+}
+
+// EffectiveConfig returns the configuration the named Request is reconciled with, once merged with
+// its ProviderConfig and the defaults.
+func EffectiveConfig(ctx context.Context, kube client.Client, name string, propagateTraceContext bool) (any, error) {
+	cr := &v1alpha2.Request{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: name}, cr); err != nil {
+		return nil, errors.Wrap(err, errGetRequest)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: utils.ProviderConfigReference(cr).Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errProviderNotRetrieved)
+	}
+
+	ec, err := utils.NewEffectiveConfig(cr, v1alpha2.RequestKind, connectionParameters(cr, propagateTraceContext), pc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &effectiveConfig{
+		EffectiveConfig:       *ec,
+		ResponseTransform:     responseTransform(cr, pc.Spec.ResponseDefaults),
+		ExpectedResponseCheck: applyResponseDefaults(cr, pc.Spec.ResponseDefaults).Spec.ForProvider.ExpectedResponseCheck,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package request
+
+import (
+	"slices"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errClassificationCondition = "cannot evaluate the condition of errorClassifications[%d]"
+	errClassifiedResponse      = "%s request failed with status code %d, classified as %s"
+	errTerminalErrorRecorded   = "a response to generation %d of the Request was classified as terminal, no request is sent until its spec changes: %s"
+)
+
+// classifyResponse returns the category of the first error classification of the Request matching the response,
+// or an empty string when none does. Requests that failed without a response are never classified.
+func classifyResponse(cr *v1alpha2.Request, details httpClient.HttpDetails, responseErr error) (string, error) {
+	classifications := cr.Spec.ForProvider.ErrorClassifications
+	if responseErr != nil || len(classifications) == 0 {
+		return "", nil
+	}
+
+	var responseMap map[string]interface{}
+	for i, classification := range classifications {
+		if len(classification.StatusCodes) != 0 && !slices.Contains(classification.StatusCodes, details.HttpResponse.StatusCode) {
+			continue
+		}
+
+		if classification.Condition == "" {
+			return classification.Category, nil
+		}
+
+		if responseMap == nil {
+			response := responseconverter.HttpResponseToV1alpha1Response(details.HttpResponse)
+			var err error
+			if responseMap, err = requestgen.GenerateRequestObject(cr.Spec.ForProvider, cr, response); err != nil {
+				return "", errors.Wrapf(err, errClassificationCondition, i)
+			}
+		}
+
+		matched, err := jq.ParseBool(utils.NormalizeWhitespace(classification.Condition), responseMap)
+		if err != nil {
+			return "", errors.Wrapf(err, errClassificationCondition, i)
+		}
+		if matched {
+			return classification.Category, nil
+		}
+	}
+
+	return "", nil
+}
+
+// classifiedError returns the error reported for a response of the mapping classified in the given category.
+func classifiedError(category string, mapping *v1alpha2.Mapping, details httpClient.HttpDetails) error {
+	return errors.Errorf(errClassifiedResponse, mapping.Method, details.HttpResponse.StatusCode, category)
+}
+
+// recordTerminalError records in the status whether the response of a request was classified as terminal, so that
+// no request is sent for the current generation of the Request anymore.
+func recordTerminalError(cr *v1alpha2.Request, category string) {
+	if category == v1alpha2.ErrorCategoryTerminal {
+		cr.Status.LastTerminalError = &v1alpha2.DriftCheck{Time: metav1.Now(), Generation: cr.Generation}
+		return
+	}
+
+	cr.Status.LastTerminalError = nil
+}
+
+// terminalErrorRecorded returns true if a response for the current generation of the Request was classified as
+// terminal. A deleted Request is always observed, so that it can be removed.
+func terminalErrorRecorded(cr *v1alpha2.Request) bool {
+	terminal := cr.Status.LastTerminalError
+	return terminal != nil && terminal.Generation == cr.Generation && !meta.WasDeleted(cr)
+}
+
+// terminalError returns the error reported while a terminal error is recorded for the current generation of the
+// Request, with the error recorded in its status.
+func terminalError(cr *v1alpha2.Request) error {
+	return errors.Errorf(errTerminalErrorRecorded, cr.Generation, cr.Status.Error)
+}
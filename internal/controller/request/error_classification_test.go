@@ -0,0 +1,383 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+var testErrorClassifications = []v1alpha2.ErrorClassification{
+	{StatusCodes: []int{503}, Category: v1alpha2.ErrorCategoryRetryable},
+	{StatusCodes: []int{400}, Condition: `.response.body.code == "INVALID"`, Category: v1alpha2.ErrorCategoryTerminal},
+	{Condition: `.response.body.deleted == true`, Category: v1alpha2.ErrorCategoryNotFound},
+	{StatusCodes: []int{409}, Category: v1alpha2.ErrorCategoryConflict},
+}
+
+func withErrorClassifications(classifications ...v1alpha2.ErrorClassification) httpRequestModifier {
+	return func(r *v1alpha2.Request) {
+		r.Spec.ForProvider.ErrorClassifications = classifications
+		r.Status.Response.StatusCode = 200
+		r.Status.Response.Body = `{"id":"123"}`
+	}
+}
+
+func Test_classifyResponse(t *testing.T) {
+	type args struct {
+		classifications []v1alpha2.ErrorClassification
+		statusCode      int
+		body            string
+		responseErr     error
+	}
+	type want struct {
+		category string
+		err      error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoClassifications": {
+			args: args{
+				statusCode: 503,
+			},
+			want: want{},
+		},
+		"RetryableStatusCode": {
+			args: args{
+				classifications: testErrorClassifications,
+				statusCode:      503,
+			},
+			want: want{
+				category: v1alpha2.ErrorCategoryRetryable,
+			},
+		},
+		"TerminalStatusCodeAndCondition": {
+			args: args{
+				classifications: testErrorClassifications,
+				statusCode:      400,
+				body:            `{"code":"INVALID"}`,
+			},
+			want: want{
+				category: v1alpha2.ErrorCategoryTerminal,
+			},
+		},
+		"ConditionNotMet": {
+			args: args{
+				classifications: testErrorClassifications,
+				statusCode:      400,
+				body:            `{"code":"THROTTLED"}`,
+			},
+			want: want{},
+		},
+		"NotFoundCondition": {
+			args: args{
+				classifications: testErrorClassifications,
+				statusCode:      200,
+				body:            `{"id":"123","deleted":true}`,
+			},
+			want: want{
+				category: v1alpha2.ErrorCategoryNotFound,
+			},
+		},
+		"ConflictStatusCode": {
+			args: args{
+				classifications: testErrorClassifications,
+				statusCode:      409,
+			},
+			want: want{
+				category: v1alpha2.ErrorCategoryConflict,
+			},
+		},
+		"FirstMatchWins": {
+			args: args{
+				classifications: []v1alpha2.ErrorClassification{
+					{StatusCodes: []int{409}, Category: v1alpha2.ErrorCategoryTerminal},
+					{StatusCodes: []int{409}, Category: v1alpha2.ErrorCategoryConflict},
+				},
+				statusCode: 409,
+			},
+			want: want{
+				category: v1alpha2.ErrorCategoryTerminal,
+			},
+		},
+		"FailedRequestNotClassified": {
+			args: args{
+				classifications: testErrorClassifications,
+				responseErr:     errBoom,
+			},
+			want: want{},
+		},
+		"InvalidCondition": {
+			args: args{
+				classifications: []v1alpha2.ErrorClassification{
+					{Condition: `.response.body.code`, Category: v1alpha2.ErrorCategoryTerminal},
+				},
+				statusCode: 400,
+				body:       `{"code":"INVALID"}`,
+			},
+			want: want{
+				err: errors.Wrapf(errors.Errorf("failed to parse string: %s", "INVALID"), errClassificationCondition, 0),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(withErrorClassifications(tc.args.classifications...))
+			details := httpClient.HttpDetails{
+				HttpResponse: httpClient.HttpResponse{StatusCode: tc.args.statusCode, Body: tc.args.body},
+			}
+
+			got, gotErr := classifyResponse(cr, details, tc.args.responseErr)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("classifyResponse(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.category, got); diff != "" {
+				t.Fatalf("classifyResponse(...): -want category, +got category: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Observe_ErrorClassifications(t *testing.T) {
+	type args struct {
+		statusCode int
+		body       string
+	}
+	type want struct {
+		observation managed.ExternalObservation
+		err         error
+		terminal    bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"RetryableIsRetried": {
+			args: args{statusCode: 503, body: `{"id":"123"}`},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errClassifiedResponse, "GET", 503, v1alpha2.ErrorCategoryRetryable), errFailedToCheckIfUpToDate),
+			},
+		},
+		"TerminalIsUpToDate": {
+			args: args{statusCode: 400, body: `{"code":"INVALID"}`},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				terminal:    true,
+			},
+		},
+		"NotFoundIsRemoved": {
+			args: args{statusCode: 200, body: `{"id":"123","deleted":true}`},
+			want: want{
+				observation: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ConflictIsRetried": {
+			args: args{statusCode: 409, body: `{"id":"123"}`},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errClassifiedResponse, "GET", 409, v1alpha2.ErrorCategoryConflict), errFailedToCheckIfUpToDate),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: tc.args.statusCode, Body: tc.args.body},
+						}, nil
+					},
+				},
+			}
+
+			cr := httpRequest(withErrorClassifications(testErrorClassifications...))
+			got, gotErr := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Observe(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.observation, got); diff != "" {
+				t.Fatalf("e.Observe(...): -want observation, +got observation: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.terminal, cr.Status.LastTerminalError != nil); diff != "" {
+				t.Fatalf("e.Observe(...): -want terminal error recorded, +got terminal error recorded: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Observe_TerminalErrorRecorded(t *testing.T) {
+	cases := map[string]struct {
+		generation int64
+		sent       bool
+		err        error
+	}{
+		"SameGenerationNotObserved": {
+			generation: 1,
+			sent:       false,
+			err:        errors.Errorf(errTerminalErrorRecorded, 1, "PUT request failed with status code 400, classified as terminal"),
+		},
+		"NewGenerationObserved": {
+			generation: 2,
+			sent:       true,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			sent := false
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						sent = true
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: 200, Body: `{"id":"123"}`},
+						}, nil
+					},
+				},
+			}
+
+			cr := httpRequest(withErrorClassifications(testErrorClassifications...), func(r *v1alpha2.Request) {
+				r.Generation = tc.generation
+				r.Status.LastTerminalError = &v1alpha2.DriftCheck{Generation: 1}
+				r.Status.Error = "PUT request failed with status code 400, classified as terminal"
+			})
+			got, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Observe(...): -want error, +got error: %s", diff)
+			}
+			if tc.err != nil && got.ResourceExists {
+				t.Errorf("e.Observe(...): the Request must not be reported as existing without an OBSERVE request")
+			}
+			if diff := cmp.Diff(tc.sent, sent); diff != "" {
+				t.Fatalf("e.Observe(...): -want request sent, +got request sent: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Deploy_ErrorClassifications(t *testing.T) {
+	type args struct {
+		action     string
+		statusCode int
+		body       string
+	}
+	type want struct {
+		err           error
+		statusUpdated bool
+		terminal      bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"RetryableIsRecordedAndRetried": {
+			args: args{action: v1alpha2.ActionCreate, statusCode: 503},
+			want: want{
+				err:           errors.Wrap(errors.Errorf(errClassifiedResponse, "POST", 503, v1alpha2.ErrorCategoryRetryable), errFailedToSendHttpRequest),
+				statusUpdated: true,
+			},
+		},
+		"TerminalIsRecorded": {
+			args: args{action: v1alpha2.ActionUpdate, statusCode: 400, body: `{"code":"INVALID"}`},
+			want: want{
+				err:           errors.Wrap(errors.Errorf(errClassifiedResponse, "PUT", 400, v1alpha2.ErrorCategoryTerminal), errFailedToSendHttpRequest),
+				statusUpdated: true,
+				terminal:      true,
+			},
+		},
+		"NotFoundRemovalSucceeds": {
+			args: args{action: v1alpha2.ActionRemove, statusCode: 200, body: `{"deleted":true}`},
+			want: want{},
+		},
+		"NotFoundUpdateIsNotRecorded": {
+			args: args{action: v1alpha2.ActionUpdate, statusCode: 200, body: `{"deleted":true}`},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errClassifiedResponse, "PUT", 200, v1alpha2.ErrorCategoryNotFound), errFailedToSendHttpRequest),
+			},
+		},
+		"ConflictIsNotRecorded": {
+			args: args{action: v1alpha2.ActionUpdate, statusCode: 409},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errClassifiedResponse, "PUT", 409, v1alpha2.ErrorCategoryConflict), errFailedToSendHttpRequest),
+			},
+		},
+		"UnclassifiedIsRecorded": {
+			args: args{action: v1alpha2.ActionCreate, statusCode: 201, body: `{"id":"123"}`},
+			want: want{
+				statusUpdated: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			statusUpdated := false
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockStatusUpdate: func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						statusUpdated = true
+						return nil
+					},
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: tc.args.statusCode, Body: tc.args.body},
+						}, nil
+					},
+				},
+			}
+
+			cr := httpRequest(withErrorClassifications(testErrorClassifications...))
+			var err error
+			switch tc.args.action {
+			case v1alpha2.ActionCreate:
+				_, err = e.Create(context.Background(), cr)
+			case v1alpha2.ActionUpdate:
+				_, err = e.Update(context.Background(), cr)
+			case v1alpha2.ActionRemove:
+				err = e.Delete(context.Background(), cr)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("%s: -want error, +got error: %s", tc.args.action, diff)
+			}
+			if diff := cmp.Diff(tc.want.statusUpdated, statusUpdated); diff != "" {
+				t.Fatalf("%s: -want status updated, +got status updated: %s", tc.args.action, diff)
+			}
+			if diff := cmp.Diff(tc.want.terminal, cr.Status.LastTerminalError != nil); diff != "" {
+				t.Fatalf("%s: -want terminal error recorded, +got terminal error recorded: %s", tc.args.action, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/observe"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestmapping"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errObserveItem      = "failed to observe item %d"
+	errDeployItem       = "failed to send the %s request of item %d"
+	errRemoveItem       = "failed to remove the object of the item %s, no longer listed"
+	errItemKey          = "failed to evaluate the itemKey of item %d"
+	errItemStatusCode   = "HTTP request failed with status code %d"
+	errUpdateItemStatus = "failed to update the status of the items"
+)
+
+// hasItems returns true if the Request manages a list of payload items, or of forEach values, instead of
+// a single object.
+func hasItems(cr *v1alpha2.Request) bool {
+	return itemCount(cr) > 0
+}
+
+// itemValues returns the payload items, or the forEach values, of the Request.
+func itemValues(cr *v1alpha2.Request) []string {
+	if hasForEach(cr) {
+		return cr.Spec.ForProvider.ForEach
+	}
+
+	return cr.Spec.ForProvider.Payload.Items
+}
+
+// itemCount returns the number of objects managed by the Request, one per payload item or forEach value.
+func itemCount(cr *v1alpha2.Request) int {
+	return len(itemValues(cr))
+}
+
+// itemKey returns the identity of the payload item, or forEach value, at the given index: the result of the
+// itemKey filter of the Request, or its index by default, so that changing an item updates its object.
+func itemKey(cr *v1alpha2.Request, value string, index int) (string, error) {
+	filter := cr.Spec.ForProvider.ItemKey
+	if filter == "" {
+		return strconv.Itoa(index), nil
+	}
+
+	var parsed interface{} = value
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+		parsed = decoded
+	}
+
+	key, err := jq.ParseInterface(filter, parsed)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := key.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(key)
+	return string(encoded), err
+}
+
+// itemRequest returns a copy of the Request managing only the given payload item, or forEach value, at the
+// given index: its payload body is the item, or its mappings are the mapping template for the value, exposed to
+// their jq filters as .each, and its response and request details are the ones recorded in the given item status.
+func itemRequest(cr *v1alpha2.Request, value string, index int, status v1alpha2.ItemStatus) *v1alpha2.Request {
+	item := cr.DeepCopy()
+	if hasForEach(cr) {
+		item.Spec.ForProvider.Mappings = renderMappingTemplate(cr.Spec.ForProvider.MappingTemplate, value, index)
+		item.Spec.ForProvider.ForEachItem = &v1alpha2.ForEachItem{Value: value, Index: index}
+		item.Spec.ForProvider.ForEach = nil
+		item.Spec.ForProvider.MappingTemplate = nil
+	} else {
+		item.Spec.ForProvider.Payload.Body = value
+	}
+	item.Spec.ForProvider.Payload.Items = nil
+	item.Status.Items = nil
+	item.Status.Cache = v1alpha2.Cache{}
+	item.Status.Response = status.Response
+	item.Status.RequestDetails = status.RequestDetails
+
+	return item
+}
+
+// removedItem is the status of an item no longer listed by the Request, at the given index of its statuses.
+type removedItem struct {
+	index  int
+	status v1alpha2.ItemStatus
+}
+
+// itemStatuses returns the recorded statuses of the payload items, one per item, matched by the key of the
+// item so that they follow the items when they are reordered, and the statuses of the items no longer listed,
+// whose objects must be removed. Without itemKey, and for the statuses recorded without key, the statuses
+// are matched by index, whatever the key they were recorded with.
+func itemStatuses(cr *v1alpha2.Request) ([]v1alpha2.ItemStatus, []removedItem, error) {
+	byKey := map[string][]int{}
+	for j, status := range cr.Status.Items {
+		if status.Key != "" {
+			byKey[status.Key] = append(byKey[status.Key], j)
+		}
+	}
+
+	values := itemValues(cr)
+	statuses := make([]v1alpha2.ItemStatus, len(values))
+	used := make([]bool, len(cr.Status.Items))
+	for i, value := range values {
+		key, err := itemKey(cr, value, i)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, errItemKey, i)
+		}
+
+		var candidates []int
+		if cr.Spec.ForProvider.ItemKey != "" {
+			candidates = byKey[key]
+		}
+		if i < len(cr.Status.Items) && (cr.Spec.ForProvider.ItemKey == "" || cr.Status.Items[i].Key == "") {
+			candidates = append(candidates, i)
+		}
+		for _, j := range candidates {
+			if !used[j] {
+				statuses[i], used[j] = cr.Status.Items[j], true
+				break
+			}
+		}
+		statuses[i].Key, statuses[i].Item = key, value
+	}
+
+	var removed []removedItem
+	for j, status := range cr.Status.Items {
+		// The items recorded without key can't be rendered anymore to remove their object.
+		if !used[j] && status.Key != "" {
+			removed = append(removed, removedItem{index: j, status: status})
+		}
+	}
+
+	return statuses, removed, nil
+}
+
+// withRemovedItems returns the item statuses followed by the statuses of the items no longer listed, so that
+// their objects are removed by a later reconcile.
+func withRemovedItems(statuses []v1alpha2.ItemStatus, removed []removedItem) []v1alpha2.ItemStatus {
+	for _, r := range removed {
+		statuses = append(statuses, r.status)
+	}
+	return statuses
+}
+
+// setItemDetails records the request and response of the given details in the item status.
+func setItemDetails(status *v1alpha2.ItemStatus, details httpClient.HttpDetails) {
+	if details.HttpRequest.Method != "" {
+		status.RequestDetails = v1alpha2.Mapping{
+			Method:  details.HttpRequest.Method,
+			URL:     details.HttpRequest.URL,
+			Body:    details.HttpRequest.Body,
+			Headers: details.HttpRequest.Headers,
+		}
+	}
+
+	if details.HttpResponse.StatusCode != 0 {
+		status.Response = responseconverter.HttpResponseToV1alpha1Response(details.HttpResponse)
+	}
+}
+
+// observeItems observes every payload item of the Request. The Request exists once all its items exist, or
+// as long as one of them exists when it is being deleted, and is up to date when all its items are.
+func (c *external) observeItems(ctx context.Context, cr *v1alpha2.Request) (managed.ExternalObservation, error) {
+	statuses, removed, err := itemStatuses(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// The objects of the items no longer listed are removed by the next UPDATE, or by the deletion.
+	allExist, anyExists, synced := true, len(removed) > 0, len(removed) == 0
+	for i := range statuses {
+		observed, err := c.isUpToDate(ctx, applyResponseDefaults(itemRequest(cr, statuses[i].Item, i, statuses[i]), c.responseDefaults))
+		if err != nil && err.Error() == observe.ErrObjectNotFound {
+			// Forget the removed object so that it is created again.
+			statuses[i] = v1alpha2.ItemStatus{Key: statuses[i].Key, Item: statuses[i].Item}
+			allExist, synced = false, false
+			continue
+		}
+
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrapf(err, errObserveItem, i)
+		}
+
+		setItemDetails(&statuses[i], observed.Details)
+		statuses[i].Synced = observed.Synced
+		anyExists = true
+		synced = synced && observed.Synced
+	}
+
+	exists := allExist
+	if meta.WasDeleted(cr) {
+		exists = anyExists
+	}
+
+	if err := c.setItemStatuses(ctx, cr, withRemovedItems(statuses, removed), exists); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   exists,
+		ResourceUpToDate: synced,
+	}, nil
+}
+
+// deployItems sends the given action for the payload items needing it: CREATE for the items that don't
+// exist, UPDATE for the ones that are not synced and REMOVE for the ones that exist. The objects of the items
+// no longer listed are removed first. Every item is handled even if some fail, and the first failure is returned.
+func (c *external) deployItems(ctx context.Context, cr *v1alpha2.Request, action string) error {
+	statuses, removed, err := itemStatuses(cr)
+	if err != nil {
+		return err
+	}
+
+	removed, deployErr := c.removeItems(ctx, cr, removed)
+	for i := range statuses {
+		item := itemRequest(cr, statuses[i].Item, i, statuses[i])
+		if !c.itemNeedsAction(item, statuses[i], action) {
+			continue
+		}
+
+		// The mappings of the items rendered from a mapping template may differ.
+		mapping, err := requestmapping.GetMapping(&item.Spec.ForProvider, action, c.logger)
+		if err != nil {
+			c.logger.Info(err.Error())
+			continue
+		}
+
+		if err := c.deployItem(ctx, cr, item, mapping, &statuses[i]); err != nil && deployErr == nil {
+			deployErr = errors.Wrapf(err, errDeployItem, action, i)
+		}
+	}
+
+	if err := c.setItemStatuses(ctx, cr, withRemovedItems(statuses, removed), false); err != nil {
+		return err
+	}
+
+	return deployErr
+}
+
+// removeItems sends REMOVE for the objects of the items no longer listed by the Request, and returns the ones
+// that are still to be removed, along with the first failure.
+func (c *external) removeItems(ctx context.Context, cr *v1alpha2.Request, removed []removedItem) ([]removedItem, error) {
+	var pending []removedItem
+	var removeErr error
+	for _, r := range removed {
+		item := itemRequest(cr, r.status.Item, r.index, r.status)
+		if !c.isObjectValidForObservation(item) {
+			continue
+		}
+
+		mapping, err := requestmapping.GetMapping(&item.Spec.ForProvider, v1alpha2.ActionRemove, c.logger)
+		if err != nil {
+			c.logger.Info(err.Error())
+			continue
+		}
+
+		err = c.deployItem(ctx, cr, item, mapping, &r.status)
+		if err == nil || observe.ResourceAbsent(item, r.status.Response.StatusCode) {
+			c.logger.Debug("removed the object of an item no longer listed", "key", r.status.Key)
+			continue
+		}
+
+		pending = append(pending, r)
+		if removeErr == nil {
+			removeErr = errors.Wrapf(err, errRemoveItem, r.status.Key)
+		}
+	}
+
+	return pending, removeErr
+}
+
+// deployItem sends the request of the given mapping for a single item and records it in the item status.
+func (c *external) deployItem(ctx context.Context, cr *v1alpha2.Request, item *v1alpha2.Request, mapping *v1alpha2.Mapping, status *v1alpha2.ItemStatus) error {
+	met, err := requestgen.MappingConditionMet(item, mapping)
+	if err != nil || !met {
+		return err
+	}
+
+	requestDetails, err := requestgen.GenerateValidRequestDetails(ctx, item, mapping, c.localKube, c.logger)
+	if err != nil {
+		return err
+	}
+
+	details, err := c.sendRequest(ctx, item, mapping, requestDetails)
+	utils.SetSecretInjectionCondition(cr, datapatcher.ApplyResponseDataToSecrets(ctx, c.localKube, c.logger, &details.HttpResponse, item.Spec.ForProvider.SecretInjectionConfigs, item.Spec.ForProvider.SecretInjectionDiscriminator, item.Spec.ForProvider.AtomicSecretInjection, item.Spec.ForProvider.PreserveRawBody, cr))
+	setItemDetails(status, details)
+	if err != nil {
+		return err
+	}
+
+	if err := checkStatusCode(mapping, details); err != nil {
+		return err
+	}
+
+	if utils.IsHTTPError(details.HttpResponse.StatusCode) {
+		return errors.Errorf(errItemStatusCode, details.HttpResponse.StatusCode)
+	}
+
+	return nil
+}
+
+// itemNeedsAction returns true if the given action must be sent for the item.
+func (c *external) itemNeedsAction(item *v1alpha2.Request, status v1alpha2.ItemStatus, action string) bool {
+	switch action {
+	case v1alpha2.ActionCreate:
+		return !c.isObjectValidForObservation(item)
+	case v1alpha2.ActionUpdate:
+		return c.isObjectValidForObservation(item) && !status.Synced
+	default:
+		return c.isObjectValidForObservation(item)
+	}
+}
+
+// setItemStatuses records the item statuses in the status of the latest version of the Request, which is
+// read again after conflicts.
+func (c *external) setItemStatuses(ctx context.Context, cr *v1alpha2.Request, statuses []v1alpha2.ItemStatus, available bool) error {
+	return utils.RetryOnConflict(ctx, utils.DefaultConflictRetry, func() error {
+		if err := c.localKube.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr); err != nil {
+			return errors.Wrap(err, errGetLatestVersion)
+		}
+
+		cr.Status.Items = statuses
+		if available {
+			cr.Status.SetConditions(xpv1.Available())
+		}
+
+		return errors.Wrap(c.localKube.Status().Update(ctx, cr), errUpdateItemStatus)
+	})
+}
@@ -0,0 +1,352 @@
+package request
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+var (
+	testItemsForProvider = v1alpha2.RequestParameters{
+		Payload: v1alpha2.Payload{
+			BaseUrl: "https://api.example.com/users",
+			Items: []string{
+				`{"username": "alice"}`,
+				`{"username": "bob"}`,
+			},
+		},
+		Mappings: []v1alpha2.Mapping{
+			{
+				Method: "POST",
+				Body:   "{ username: .payload.body.username }",
+				URL:    ".payload.baseUrl",
+			},
+			testGetMapping,
+			{
+				Method: "PUT",
+				Body:   "{ username: .payload.body.username }",
+				URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+			},
+			testDeleteMapping,
+		},
+	}
+)
+
+// createdItem returns the status of an item created with the given id.
+func createdItem(id string) v1alpha2.ItemStatus {
+	return v1alpha2.ItemStatus{
+		Response: v1alpha2.Response{
+			StatusCode: 200,
+			Body:       `{"id":"` + id + `"}`,
+		},
+		RequestDetails: v1alpha2.Mapping{
+			Method: "POST",
+		},
+	}
+}
+
+// keyedItem returns the status of the given item created with the given id, keyed by its username.
+func keyedItem(t *testing.T, item string, id string) v1alpha2.ItemStatus {
+	t.Helper()
+	key, err := itemKey(httpRequest(withUsernameItemKey), item, 0)
+	if err != nil {
+		t.Fatalf("itemKey(...): unexpected error: %s", err)
+	}
+
+	status := createdItem(id)
+	status.Key, status.Item = key, item
+	return status
+}
+
+// withUsernameItemKey identifies the payload items of the Request by their username.
+func withUsernameItemKey(r *v1alpha2.Request) {
+	r.Spec.ForProvider.ItemKey = ".username"
+}
+
+// usersServer returns a mock HTTP client serving the users with the given names by id, and recording the
+// requests it receives.
+func usersServer(users map[string]string, requests *[]string) *MockHttpClient {
+	return &MockHttpClient{
+		MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+			*requests = append(*requests, method+" "+url)
+			id := url[strings.LastIndex(url, "/")+1:]
+			if method == "POST" {
+				id = "3"
+			}
+
+			return httpClient.HttpDetails{
+				HttpResponse: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"id":"` + id + `","username":"` + users[id] + `"}`,
+				},
+				HttpRequest: httpClient.HttpRequest{
+					Method: method,
+					URL:    url,
+				},
+			}, nil
+		},
+	}
+}
+
+func Test_httpExternal_Observe_Items(t *testing.T) {
+	type args struct {
+		users map[string]string
+		items []v1alpha2.ItemStatus
+	}
+	type want struct {
+		exists   bool
+		upToDate bool
+		synced   []bool
+		requests []string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"OneItemNotSynced": {
+			args: args{
+				users: map[string]string{"1": "alice", "2": "robert"},
+				items: []v1alpha2.ItemStatus{createdItem("1"), createdItem("2")},
+			},
+			want: want{
+				exists:   true,
+				upToDate: false,
+				synced:   []bool{true, false},
+				requests: []string{"GET https://api.example.com/users/1", "GET https://api.example.com/users/2"},
+			},
+		},
+		"AllItemsSynced": {
+			args: args{
+				users: map[string]string{"1": "alice", "2": "bob"},
+				items: []v1alpha2.ItemStatus{createdItem("1"), createdItem("2")},
+			},
+			want: want{
+				exists:   true,
+				upToDate: true,
+				synced:   []bool{true, true},
+				requests: []string{"GET https://api.example.com/users/1", "GET https://api.example.com/users/2"},
+			},
+		},
+		"ItemNotCreated": {
+			args: args{
+				users: map[string]string{"1": "alice"},
+				items: []v1alpha2.ItemStatus{createdItem("1")},
+			},
+			want: want{
+				exists:   false,
+				upToDate: false,
+				synced:   []bool{true, false},
+				requests: []string{"GET https://api.example.com/users/1"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http:   usersServer(tc.args.users, &requests),
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider = testItemsForProvider
+				r.Status.Items = tc.args.items
+			})
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.exists, got.ResourceExists); diff != "" {
+				t.Fatalf("e.Observe(...): -want exists, +got exists: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, got.ResourceUpToDate); diff != "" {
+				t.Fatalf("e.Observe(...): -want up to date, +got up to date: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, requests); diff != "" {
+				t.Fatalf("e.Observe(...): -want requests, +got requests: %s", diff)
+			}
+
+			synced := make([]bool, len(cr.Status.Items))
+			for i, item := range cr.Status.Items {
+				synced[i] = item.Synced
+			}
+			if diff := cmp.Diff(tc.want.synced, synced); diff != "" {
+				t.Fatalf("e.Observe(...): -want synced items, +got synced items: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Observe_ReorderedItems(t *testing.T) {
+	var requests []string
+	e := &external{
+		localKube: &test.MockClient{
+			MockGet:          test.NewMockGetFn(nil),
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		},
+		logger: logging.NewNopLogger(),
+		http:   usersServer(map[string]string{"1": "alice", "2": "bob"}, &requests),
+	}
+
+	cr := httpRequest(func(r *v1alpha2.Request) {
+		r.Spec.ForProvider = *testItemsForProvider.DeepCopy()
+		r.Spec.ForProvider.ItemKey = ".username"
+		r.Spec.ForProvider.Payload.Items = []string{`{"username": "bob"}`, `{"username": "alice"}`}
+		r.Status.Items = []v1alpha2.ItemStatus{
+			keyedItem(t, `{"username": "alice"}`, "1"),
+			keyedItem(t, `{"username":"bob"}`, "2"),
+		}
+	})
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %s", err)
+	}
+	if !got.ResourceExists || !got.ResourceUpToDate {
+		t.Fatalf("e.Observe(...): reordered items must keep their objects, got %+v", got)
+	}
+
+	want := []string{"GET https://api.example.com/users/2", "GET https://api.example.com/users/1"}
+	if diff := cmp.Diff(want, requests); diff != "" {
+		t.Fatalf("e.Observe(...): -want requests, +got requests: %s", diff)
+	}
+}
+
+func Test_httpExternal_Deploy_Items(t *testing.T) {
+	type args struct {
+		action       string
+		itemKey      string
+		payloadItems []string
+		items        []v1alpha2.ItemStatus
+	}
+	type want struct {
+		requests []string
+	}
+
+	notSynced := createdItem("2")
+	synced := createdItem("1")
+	synced.Synced = true
+	syncedKeyed := func(status v1alpha2.ItemStatus) v1alpha2.ItemStatus {
+		status.Synced = true
+		return status
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"CreateMissingItemsOnly": {
+			args: args{
+				action: v1alpha2.ActionCreate,
+				items:  []v1alpha2.ItemStatus{synced},
+			},
+			want: want{
+				requests: []string{"POST https://api.example.com/users"},
+			},
+		},
+		"UpdateNotSyncedItemsOnly": {
+			args: args{
+				action: v1alpha2.ActionUpdate,
+				items:  []v1alpha2.ItemStatus{synced, notSynced},
+			},
+			want: want{
+				requests: []string{"PUT https://api.example.com/users/2"},
+			},
+		},
+		"RemoveAllItems": {
+			args: args{
+				action: v1alpha2.ActionRemove,
+				items:  []v1alpha2.ItemStatus{synced, notSynced},
+			},
+			want: want{
+				requests: []string{"DELETE https://api.example.com/users/1", "DELETE https://api.example.com/users/2"},
+			},
+		},
+		"ChangedItemUpdatedWithoutItemKey": {
+			args: args{
+				action:       v1alpha2.ActionUpdate,
+				payloadItems: []string{`{"username": "alice"}`, `{"username": "bobby"}`},
+				items: []v1alpha2.ItemStatus{
+					syncedKeyed(keyedItem(t, `{"username": "alice"}`, "1")),
+					keyedItem(t, `{"username": "bob"}`, "2"),
+				},
+			},
+			want: want{
+				requests: []string{"PUT https://api.example.com/users/2"},
+			},
+		},
+		"LastItemsRemovedWithoutItemKey": {
+			args: args{
+				action: v1alpha2.ActionUpdate,
+				items: []v1alpha2.ItemStatus{
+					syncedKeyed(keyedItem(t, `{"username": "alice"}`, "1")),
+					syncedKeyed(keyedItem(t, `{"username": "bob"}`, "2")),
+					syncedKeyed(keyedItem(t, `{"username": "carol"}`, "4")),
+				},
+			},
+			want: want{
+				requests: []string{"DELETE https://api.example.com/users/4"},
+			},
+		},
+		"RemoveItemsNoLongerListed": {
+			args: args{
+				action:  v1alpha2.ActionUpdate,
+				itemKey: ".username",
+				items: []v1alpha2.ItemStatus{
+					syncedKeyed(keyedItem(t, `{"username": "alice"}`, "1")),
+					syncedKeyed(keyedItem(t, `{"username": "carol"}`, "4")),
+					syncedKeyed(keyedItem(t, `{"username": "bob"}`, "2")),
+				},
+			},
+			want: want{
+				requests: []string{"DELETE https://api.example.com/users/4"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http:   usersServer(map[string]string{"1": "alice", "2": "robert", "3": "bob"}, &requests),
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider = *testItemsForProvider.DeepCopy()
+				r.Spec.ForProvider.ItemKey = tc.args.itemKey
+				if tc.args.payloadItems != nil {
+					r.Spec.ForProvider.Payload.Items = tc.args.payloadItems
+				}
+				r.Status.Items = tc.args.items
+			})
+			if err := e.deployAction(context.Background(), cr, tc.args.action); err != nil {
+				t.Fatalf("e.deployAction(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.requests, requests); diff != "" {
+				t.Fatalf("e.deployAction(...): -want requests, +got requests: %s", diff)
+			}
+			if diff := cmp.Diff(len(testItemsForProvider.Payload.Items), len(cr.Status.Items)); diff != "" {
+				t.Fatalf("e.deployAction(...): -want items, +got items: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package request
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+// mappingTemplatePlaceholder matches the $(each), $(each.<field>) and $(index) placeholders of the headers of
+// the mapping template, the fields of $(each.<field>) being separated by dots.
+var mappingTemplatePlaceholder = regexp.MustCompile(`\$\((each((?:\.[A-Za-z0-9_-]+)*)|index)\)`)
+
+// hasForEach returns true if the Request manages an object per forEach value, with the mappings rendered
+// from its mapping template.
+func hasForEach(cr *v1alpha2.Request) bool {
+	return len(cr.Spec.ForProvider.ForEach) > 0
+}
+
+// mappingCount returns the number of mappings of the Request, counting the mapping template once for each
+// forEach value it is rendered for.
+func mappingCount(cr *v1alpha2.Request) int {
+	params := cr.Spec.ForProvider
+	return len(params.Mappings) + len(params.MappingTemplate)*max(1, len(params.ForEach))
+}
+
+// renderMappingTemplate returns the mappings of the template for the forEach value at the given index. Their
+// jq filters are kept as is, since they reference the value through .each, and only the placeholders of their
+// headers, which are not jq filters, are replaced.
+func renderMappingTemplate(template []v1alpha2.Mapping, each string, index int) []v1alpha2.Mapping {
+	var value interface{} = each
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(each), &parsed); err == nil {
+		value = parsed
+	}
+
+	render := func(s string) string {
+		return mappingTemplatePlaceholder.ReplaceAllStringFunc(s, func(placeholder string) string {
+			match := mappingTemplatePlaceholder.FindStringSubmatch(placeholder)
+			if match[1] == "index" {
+				return strconv.Itoa(index)
+			}
+			return placeholderValue(value, strings.Split(strings.TrimPrefix(match[2], "."), "."))
+		})
+	}
+
+	mappings := make([]v1alpha2.Mapping, len(template))
+	for i := range template {
+		mapping := template[i].DeepCopy()
+		for _, values := range mapping.Headers {
+			for j := range values {
+				values[j] = render(values[j])
+			}
+		}
+		mappings[i] = *mapping
+	}
+
+	return mappings
+}
+
+// placeholderValue returns the field of the value at the given path, as is for strings and as JSON otherwise.
+// Missing fields are replaced with an empty string.
+func placeholderValue(value interface{}, path []string) string {
+	for _, field := range path {
+		if field == "" {
+			continue
+		}
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		if value, ok = object[field]; !ok {
+			return ""
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		rendered, _ := json.Marshal(v)
+		return string(rendered)
+	}
+}
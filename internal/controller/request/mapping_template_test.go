@@ -0,0 +1,199 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+)
+
+var (
+	testForEachForProvider = v1alpha2.RequestParameters{
+		Payload: v1alpha2.Payload{
+			BaseUrl: "https://api.example.com",
+		},
+		ForEach: []string{
+			`{"team": "payments", "members": ["alice"]}`,
+			`{"team": "search", "members": ["bob", "carol"]}`,
+		},
+		MappingTemplate: []v1alpha2.Mapping{
+			{
+				Action: v1alpha2.ActionCreate,
+				Method: "POST",
+				Body:   `{ name: .each.team, members: .each.members, position: .index }`,
+				URL:    `(.payload.baseUrl + "/teams/" + .each.team)`,
+			},
+			{
+				Action:  v1alpha2.ActionObserve,
+				Method:  "GET",
+				URL:     `(.payload.baseUrl + "/teams/" + .each.team)`,
+				Headers: map[string][]string{"X-Team": {"$(each.team)"}},
+			},
+		},
+	}
+)
+
+func Test_renderMappingTemplate(t *testing.T) {
+	type args struct {
+		template string
+		each     string
+		index    int
+	}
+
+	cases := map[string]struct {
+		args args
+		want string
+	}{
+		"WholeJSONValue": {
+			args: args{template: "$(each)", each: `{"id": 1}`},
+			want: `{"id":1}`,
+		},
+		"StringValue": {
+			args: args{template: `"/users/$(each)"`, each: "alice"},
+			want: `"/users/alice"`,
+		},
+		"JSONStringValue": {
+			args: args{template: `"/users/$(each)"`, each: `"alice"`},
+			want: `"/users/alice"`,
+		},
+		"NestedField": {
+			args: args{template: "$(each.owner.name)", each: `{"owner": {"name": "alice"}}`},
+			want: "alice",
+		},
+		"NumberField": {
+			args: args{template: "$(each.size)", each: `{"size": 3}`},
+			want: "3",
+		},
+		"MissingField": {
+			args: args{template: "[$(each.owner)]", each: `{"id": 1}`},
+			want: "[]",
+		},
+		"FieldOfString": {
+			args: args{template: "[$(each.id)]", each: "alice"},
+			want: "[]",
+		},
+		"Index": {
+			args: args{template: "item-$(index)", each: "alice", index: 2},
+			want: "item-2",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			template := []v1alpha2.Mapping{{URL: tc.args.template, Headers: map[string][]string{"X-Item": {tc.args.template}}}}
+			got := renderMappingTemplate(template, tc.args.each, tc.args.index)
+			if diff := cmp.Diff(tc.want, got[0].Headers["X-Item"][0]); diff != "" {
+				t.Errorf("renderMappingTemplate(...): -want header, +got header: %s", diff)
+			}
+			if diff := cmp.Diff(tc.args.template, got[0].URL); diff != "" {
+				t.Errorf("renderMappingTemplate(...): the jq filters must be left as is: -want url, +got url: %s", diff)
+			}
+			if diff := cmp.Diff(tc.args.template, template[0].Headers["X-Item"][0]); diff != "" {
+				t.Errorf("renderMappingTemplate(...): the template must be left untouched: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_itemRequest_ForEach(t *testing.T) {
+	cr := httpRequest(func(r *v1alpha2.Request) {
+		r.Spec.ForProvider = testForEachForProvider
+	})
+
+	template := testForEachForProvider.MappingTemplate
+	want := []struct {
+		mappings []v1alpha2.Mapping
+		body     string
+		url      string
+	}{
+		{
+			mappings: []v1alpha2.Mapping{
+				template[0],
+				{
+					Action:  v1alpha2.ActionObserve,
+					Method:  "GET",
+					URL:     template[1].URL,
+					Headers: map[string][]string{"X-Team": {"payments"}},
+				},
+			},
+			body: `{"members":["alice"],"name":"payments","position":0}`,
+			url:  "https://api.example.com/teams/payments",
+		},
+		{
+			mappings: []v1alpha2.Mapping{
+				template[0],
+				{
+					Action:  v1alpha2.ActionObserve,
+					Method:  "GET",
+					URL:     template[1].URL,
+					Headers: map[string][]string{"X-Team": {"search"}},
+				},
+			},
+			body: `{"members":["bob","carol"],"name":"search","position":1}`,
+			url:  "https://api.example.com/teams/search",
+		},
+	}
+
+	if diff := cmp.Diff(len(want), itemCount(cr)); diff != "" {
+		t.Fatalf("itemCount(...): -want count, +got count: %s", diff)
+	}
+	for i := range want {
+		item := itemRequest(cr, cr.Spec.ForProvider.ForEach[i], i, v1alpha2.ItemStatus{})
+		if diff := cmp.Diff(want[i].mappings, item.Spec.ForProvider.Mappings); diff != "" {
+			t.Errorf("itemRequest(..., %d): -want mappings, +got mappings: %s", i, diff)
+		}
+
+		details, err := requestgen.GenerateValidRequestDetails(context.Background(), item, &item.Spec.ForProvider.Mappings[0], nil, logging.NewNopLogger())
+		if err != nil {
+			t.Fatalf("GenerateValidRequestDetails(..., %d): unexpected error: %s", i, err)
+		}
+		if diff := cmp.Diff(want[i].body, details.Body.Decrypted); diff != "" {
+			t.Errorf("GenerateValidRequestDetails(..., %d): -want body, +got body: %s", i, diff)
+		}
+		if diff := cmp.Diff(want[i].url, details.Url); diff != "" {
+			t.Errorf("GenerateValidRequestDetails(..., %d): -want url, +got url: %s", i, diff)
+		}
+		if item.Spec.ForProvider.ForEach != nil || item.Spec.ForProvider.MappingTemplate != nil {
+			t.Errorf("itemRequest(..., %d): the item must not render its mappings again", i)
+		}
+	}
+
+	// The template of the Request itself is left untouched.
+	if diff := cmp.Diff(testForEachForProvider.MappingTemplate, cr.Spec.ForProvider.MappingTemplate); diff != "" {
+		t.Errorf("itemRequest(...): -want template, +got template: %s", diff)
+	}
+}
+
+func Test_httpExternal_Deploy_ForEach(t *testing.T) {
+	var requests []string
+	e := &external{
+		localKube: &test.MockClient{
+			MockGet:          test.NewMockGetFn(nil),
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		},
+		logger: logging.NewNopLogger(),
+		http:   usersServer(map[string]string{}, &requests),
+	}
+
+	cr := httpRequest(func(r *v1alpha2.Request) {
+		r.Spec.ForProvider = testForEachForProvider
+	})
+	if err := e.deployAction(context.Background(), cr, v1alpha2.ActionCreate); err != nil {
+		t.Fatalf("e.deployAction(...): unexpected error: %s", err)
+	}
+
+	want := []string{"POST https://api.example.com/teams/payments", "POST https://api.example.com/teams/search"}
+	if diff := cmp.Diff(want, requests); diff != "" {
+		t.Errorf("e.deployAction(...): -want requests, +got requests: %s", diff)
+	}
+	if diff := cmp.Diff(len(testForEachForProvider.ForEach), len(cr.Status.Items)); diff != "" {
+		t.Errorf("e.deployAction(...): -want items, +got items: %s", diff)
+	}
+}
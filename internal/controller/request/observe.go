@@ -2,6 +2,7 @@ package request
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
@@ -9,8 +10,12 @@ import (
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/observe"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestmapping"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
 	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/pkg/errors"
 )
 
@@ -24,6 +29,7 @@ type ObserveRequestDetails struct {
 	Details       httpClient.HttpDetails
 	ResponseError error
 	Synced        bool
+	Terminal      bool
 }
 
 // NewObserveRequestDetails is a constructor function that initializes
@@ -60,12 +66,41 @@ func (c *external) isUpToDate(ctx context.Context, cr *v1alpha2.Request) (Observ
 		return FailedObserve(), err
 	}
 
-	details, responseErr := c.http.SendRequest(ctx, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, cr.Spec.ForProvider.InsecureSkipTLSVerify)
+	details, responseErr := c.sendRequest(ctx, cr, mapping, requestDetails)
+
+	// The classification comes first so that e.g. a 404 can be retried rather than seen as a removal.
+	category, err := classifyResponse(cr, details, responseErr)
+	if err != nil {
+		return FailedObserve(), err
+	}
+	switch category {
+	case v1alpha2.ErrorCategoryNotFound:
+		return FailedObserve(), errors.New(observe.ErrObjectNotFound)
+	case v1alpha2.ErrorCategoryRetryable, v1alpha2.ErrorCategoryConflict:
+		return FailedObserve(), classifiedError(category, mapping, details)
+	case v1alpha2.ErrorCategoryTerminal:
+		observed := NewObserve(details, nil, true)
+		observed.Terminal = true
+		return observed, nil
+	}
+
 	if err := c.determineIfRemoved(ctx, cr, details, responseErr); err != nil {
 		return FailedObserve(), err
 	}
 
-	datapatcher.ApplyResponseDataToSecrets(ctx, c.localKube, c.logger, &details.HttpResponse, cr.Spec.ForProvider.SecretInjectionConfigs, cr)
+	// The removal is checked first so that e.g. a 404 is still detected when only a 200 is expected.
+	if responseErr == nil {
+		if err := checkStatusCode(mapping, details); err != nil {
+			return NewObserve(details, err, false), nil
+		}
+	}
+
+	c.keepResponse(details.HttpResponse)
+	utils.SetSecretInjectionCondition(cr, datapatcher.ApplyResponseDataToSecrets(ctx, c.localKube, c.logger, &details.HttpResponse, cr.Spec.ForProvider.SecretInjectionConfigs, cr.Spec.ForProvider.SecretInjectionDiscriminator, cr.Spec.ForProvider.AtomicSecretInjection, cr.Spec.ForProvider.PreserveRawBody, cr))
+	if syncedByUpdate(cr) {
+		return NewObserve(details, responseErr, true), nil
+	}
+
 	return c.determineIfUpToDate(ctx, cr, details, responseErr)
 }
 
@@ -94,10 +129,19 @@ func (c *external) determineIfRemoved(ctx context.Context, cr *v1alpha2.Request,
 	return responseChecker.Check(ctx, cr, details, responseErr)
 }
 
-// isObjectValidForObservation checks if the object is valid for observation
+// isObjectValidForObservation checks if the object is valid for observation, i.e. that the last response
+// recorded by the Request doesn't show that its object doesn't exist: no response yet, a failed POST or a
+// status code of the resourceAbsentStatusCodes.
 func (c *external) isObjectValidForObservation(cr *v1alpha2.Request) bool {
-	return cr.Status.Response.StatusCode != 0 &&
-		!(cr.Status.RequestDetails.Method == http.MethodPost && utils.IsHTTPError(cr.Status.Response.StatusCode))
+	code := cr.Status.Response.StatusCode
+	switch {
+	case code == 0:
+		return false
+	case cr.Status.RequestDetails.Method == http.MethodPost:
+		return !utils.IsHTTPError(code)
+	}
+
+	return !observe.ResourceAbsent(cr, code)
 }
 
 // requestDetails generates the request details for a given method or action.
@@ -109,3 +153,84 @@ func (c *external) requestDetails(ctx context.Context, cr *v1alpha2.Request, act
 
 	return requestgen.GenerateValidRequestDetails(ctx, cr, mapping, c.localKube, c.logger)
 }
+
+// shouldRecreate evaluates the recreate condition of the Request against the OBSERVE response.
+func shouldRecreate(cr *v1alpha2.Request, details httpClient.HttpDetails) (bool, error) {
+	if cr.Spec.ForProvider.RecreateCondition == "" {
+		return false, nil
+	}
+
+	response := responseconverter.HttpResponseToV1alpha1Response(details.HttpResponse)
+	responseMap, err := requestgen.GenerateRequestObject(cr.Spec.ForProvider, cr, response)
+	if err != nil {
+		return false, err
+	}
+
+	return jq.ParseBool(utils.NormalizeWhitespace(cr.Spec.ForProvider.RecreateCondition), responseMap)
+}
+
+// canRecreate returns true if the management policies of the Request allow it to be removed and created again.
+func canRecreate(cr *v1alpha2.Request) bool {
+	policies := utils.ManagementPolicies(cr)
+	return policies.ShouldDelete() && policies.ShouldCreate()
+}
+
+// lateInitialize sets the payload body keys configured in lateInitFields that are not set yet
+// from the given OBSERVE response, unless the management policies don't allow late initialization.
+// It returns true if the Request was modified.
+func lateInitialize(cr *v1alpha2.Request, response httpClient.HttpResponse, logger logging.Logger) (bool, error) {
+	fields := cr.Spec.ForProvider.LateInitFields
+	if len(fields) == 0 || !utils.IsHTTPSuccess(response.StatusCode) || !utils.ManagementPolicies(cr).ShouldLateInitialize() {
+		return false, nil
+	}
+
+	payloadBody := cr.Spec.ForProvider.Payload.Body
+	if payloadBody == "" {
+		payloadBody = "{}"
+	}
+	if !json_util.IsJSONString(payloadBody) {
+		return false, errors.Errorf(errNotValidJSON, "payload.body", payloadBody)
+	}
+	body := json_util.JsonStringToMap(payloadBody)
+
+	responseMap, err := json_util.StructToMap(response)
+	if err != nil {
+		return false, errors.Wrap(err, errConvertResToMap)
+	}
+	if err := json_util.ConvertJSONStringsToMaps(&responseMap); err != nil {
+		return false, errors.Wrap(err, errConvertResToMap)
+	}
+
+	var keys []string
+	values := map[string]interface{}{}
+	for _, field := range fields {
+		if _, ok := body[field.PayloadBodyKey]; ok {
+			continue
+		}
+		if _, ok := values[field.PayloadBodyKey]; ok {
+			continue
+		}
+
+		value, err := jq.ParseInterface(field.ResponseJQ, responseMap)
+		if err != nil || value == nil {
+			logger.Debug(fmt.Sprintf("Skipping late initialization of %s, %s didn't select a value", field.PayloadBodyKey, field.ResponseJQ))
+			continue
+		}
+
+		keys = append(keys, field.PayloadBodyKey)
+		values[field.PayloadBodyKey] = value
+	}
+
+	if len(keys) == 0 {
+		return false, nil
+	}
+
+	// Only the missing keys are added, the payload body is otherwise kept as written.
+	lateInitBody, err := json_util.AppendKeys(payloadBody, keys, values)
+	if err != nil {
+		return false, err
+	}
+
+	cr.Spec.ForProvider.Payload.Body = lateInitBody
+	return true, nil
+}
@@ -3,9 +3,12 @@ package observe
 import (
 	"context"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,13 +32,34 @@ type defaultIsRemovedResponseCheck struct {
 
 // Check performs a default comparison between the response and desired state.
 func (d *defaultIsRemovedResponseCheck) Check(ctx context.Context, cr *v1alpha2.Request, details httpClient.HttpDetails, responseErr error) error {
-	if details.HttpResponse.StatusCode == http.StatusNotFound {
+	if ObservedAbsent(cr, details.HttpResponse) {
 		return errors.New(ErrObjectNotFound)
 	}
 
 	return nil
 }
 
+// ResourceAbsent returns true if the status code of an OBSERVE response means that the object doesn't exist,
+// according to the resourceAbsentStatusCodes of the Request, 404 by default.
+func ResourceAbsent(cr *v1alpha2.Request, statusCode int) bool {
+	if codes := cr.Spec.ForProvider.ResourceAbsentStatusCodes; len(codes) != 0 {
+		return slices.Contains(codes, statusCode)
+	}
+
+	return statusCode == http.StatusNotFound
+}
+
+// ObservedAbsent returns true if an OBSERVE response means that the object doesn't exist: its status code is
+// one of the resourceAbsentStatusCodes, or it is successful with an empty body when resourceAbsentOnEmptyBody
+// is set.
+func ObservedAbsent(cr *v1alpha2.Request, response httpClient.HttpResponse) bool {
+	if cr.Spec.ForProvider.ResourceAbsentOnEmptyBody && utils.IsHTTPSuccess(response.StatusCode) && strings.TrimSpace(response.Body) == "" {
+		return true
+	}
+
+	return ResourceAbsent(cr, response.StatusCode)
+}
+
 // // customIsRemovedResponseCheck performs a custom response check using JQ logic.
 type customIsRemovedResponseCheck struct {
 	localKube client.Client
@@ -45,12 +69,16 @@ type customIsRemovedResponseCheck struct {
 
 // Check performs a custom response check using JQ logic.
 func (c *customIsRemovedResponseCheck) Check(ctx context.Context, cr *v1alpha2.Request, details httpClient.HttpDetails, responseErr error) error {
-	logic := cr.Spec.ForProvider.IsRemovedCheck.Logic
+	logic, err := checkLogic(ctx, c.localKube, cr.Spec.ForProvider.IsRemovedCheck)
+	if err != nil {
+		return errors.Wrapf(err, errLogicRef, "isRemovedCheck")
+	}
+
 	customCheck := &customCheck{localKube: c.localKube, logger: c.logger, http: c.http}
 
-	isRemoved, err := customCheck.check(ctx, cr, details, logic)
+	isRemoved, err := customCheck.checkWithPolicy(ctx, cr, details, cr.Spec.ForProvider.IsRemovedCheck, logic, "isRemovedCheck")
 	if err != nil {
-		return errors.Errorf(errExpectedFormat, "isRemovedCheck", err.Error())
+		return err
 	} else if isRemoved {
 		return errors.New(ErrObjectNotFound)
 	}
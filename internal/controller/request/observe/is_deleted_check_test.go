@@ -13,6 +13,16 @@ import (
 	"github.com/pkg/errors"
 )
 
+func absentStatusCodesRequest(codes ...int) *v1alpha2.Request {
+	return &v1alpha2.Request{
+		Spec: v1alpha2.RequestSpec{
+			ForProvider: v1alpha2.RequestParameters{
+				ResourceAbsentStatusCodes: codes,
+			},
+		},
+	}
+}
+
 func Test_DefaultIsRemovedCheck(t *testing.T) {
 	type args struct {
 		ctx         context.Context
@@ -80,6 +90,68 @@ func Test_DefaultIsRemovedCheck(t *testing.T) {
 				err: errors.New(ErrObjectNotFound),
 			},
 		},
+		"GoneWithAbsentStatusCodes": {
+			args: args{
+				ctx: context.Background(),
+				cr:  absentStatusCodesRequest(http.StatusNotFound, http.StatusGone),
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusGone},
+				},
+			},
+			want: want{
+				err: errors.New(ErrObjectNotFound),
+			},
+		},
+		"CustomAbsentStatusCode": {
+			args: args{
+				ctx: context.Background(),
+				cr:  absentStatusCodesRequest(http.StatusNoContent),
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNoContent},
+				},
+			},
+			want: want{
+				err: errors.New(ErrObjectNotFound),
+			},
+		},
+		"EmptyBodyAbsent": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{ForProvider: v1alpha2.RequestParameters{ResourceAbsentOnEmptyBody: true}},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: " \n"},
+				},
+			},
+			want: want{
+				err: errors.New(ErrObjectNotFound),
+			},
+		},
+		"EmptyBodyWithoutOption": {
+			args: args{
+				ctx: context.Background(),
+				cr:  &v1alpha2.Request{},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"NotFoundNotInAbsentStatusCodes": {
+			args: args{
+				ctx: context.Background(),
+				cr:  absentStatusCodesRequest(http.StatusGone),
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotFound},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"ValidNotRemovedState": {
 			args: args{
 				ctx: context.Background(),
@@ -165,6 +237,31 @@ func Test_CustomIsRemovedCheck(t *testing.T) {
 				err: errors.New(ErrObjectNotFound),
 			},
 		},
+		"CustomCheckErrorsNotRemoved": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							IsRemovedCheck: v1alpha2.ExpectedResponseCheck{
+								Type:         v1alpha2.ExpectedResponseCheckTypeCustom,
+								Logic:        `.response.body.items[0].status == "deleted"`,
+								OnCheckError: v1alpha2.OnCheckErrorNotSynced,
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"items": "none"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"CustomCheckFails": {
 			args: args{
 				ctx: context.Background(),
@@ -220,7 +317,7 @@ func Test_CustomIsRemovedCheck(t *testing.T) {
 				responseErr: nil,
 			},
 			want: want{
-				err: errors.Errorf(errExpectedFormat, "isRemovedCheck", "failed to parse string: map[expectedResponseCheck:map[] isRemovedCheck:map[] mappings:<nil> payload:map[body:map[password:password]] response:map[body:map[password:wrong_password]]]"),
+				err: errors.Errorf(errExpectedFormat, "isRemovedCheck", "failed to parse string: map[expectedResponseCheck:map[] isRemovedCheck:map[] mappings:<nil> meta:map[annotations:map[] labels:map[] name:] payload:map[body:map[password:password]] request:map[method: url:] response:map[body:map[password:wrong_password]]]"),
 			},
 		},
 	}
@@ -10,10 +10,13 @@ import (
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestmapping"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
 	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
 	"github.com/crossplane-contrib/provider-http/internal/json"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -21,6 +24,11 @@ import (
 var (
 	errExpectedFormat = "%s.Logic JQ filter should return a boolean, but returned error: %s"
 	errNotValidJSON   = "%s is not a valid JSON string: %s"
+
+	errOwnedFieldFormat = "cannot evaluate the owned field %s"
+	errInvalidPatch     = "UPDATE mapping result is not a valid %s body"
+
+	patchOperationRemove = "remove"
 )
 
 // defaultIsUpToDateResponseCheck performs a default comparison between the response and desired state.
@@ -32,7 +40,7 @@ type defaultIsUpToDateResponseCheck struct {
 
 // Check performs a default comparison between the response and desired state.
 func (d *defaultIsUpToDateResponseCheck) Check(ctx context.Context, cr *v1alpha2.Request, details httpClient.HttpDetails, responseErr error) (bool, error) {
-	desiredState, err := d.desiredState(ctx, cr)
+	mapping, err := requestmapping.GetMapping(&cr.Spec.ForProvider, v1alpha2.ActionUpdate, d.logger)
 	if err != nil {
 		if isErrorMappingNotFound(err) {
 			return true, nil
@@ -40,22 +48,47 @@ func (d *defaultIsUpToDateResponseCheck) Check(ctx context.Context, cr *v1alpha2
 		return false, err
 	}
 
-	return d.compareResponseAndDesiredState(ctx, details, desiredState)
+	met, err := requestgen.MappingConditionMetFor(cr, mapping, responseconverter.HttpResponseToV1alpha1Response(details.HttpResponse))
+	if err != nil {
+		return false, err
+	}
+	if !met {
+		// A skipped UPDATE mapping can't resolve a drift, so none is reported.
+		return true, nil
+	}
+
+	desiredState, err := d.desiredState(ctx, cr, mapping)
+	if err != nil {
+		return false, err
+	}
+
+	scope := syncScope{ownedFields: cr.Spec.ForProvider.OwnedFields, patchStrategy: mapping.PatchStrategy}
+	return d.compareResponseAndDesiredState(ctx, details, desiredState, scope, cr.Spec.ForProvider.UnresolvedSecretPolicy)
 }
 
-// compareResponseAndDesiredState compares the response and desired state to determine if they are in sync.
-func (d *defaultIsUpToDateResponseCheck) compareResponseAndDesiredState(ctx context.Context, details httpClient.HttpDetails, desiredState string) (bool, error) {
-	sensitiveBody, err := d.patchAndValidate(ctx, details.HttpResponse.Body)
+// syncScope specifies which parts of the response are compared with the desired state.
+type syncScope struct {
+	// ownedFields are the jq paths of the only fields compared, all of them when empty.
+	ownedFields []string
+
+	// patchStrategy is the PatchStrategy of the UPDATE mapping, empty for a plain body.
+	patchStrategy string
+}
+
+// compareResponseAndDesiredState compares the response and desired state to determine if they are in sync, once
+// their secret placeholders are resolved according to the unresolved secret policy.
+func (d *defaultIsUpToDateResponseCheck) compareResponseAndDesiredState(ctx context.Context, details httpClient.HttpDetails, desiredState string, scope syncScope, policy string) (bool, error) {
+	sensitiveBody, err := d.patchAndValidate(ctx, details.HttpResponse.Body, policy)
 	if err != nil {
 		return false, err
 	}
 
-	sensitiveDesiredState, err := d.patchAndValidate(ctx, desiredState)
+	sensitiveDesiredState, err := d.patchAndValidate(ctx, desiredState, policy)
 	if err != nil {
 		return false, err
 	}
 
-	synced, err := d.comparePatchedResults(sensitiveBody, sensitiveDesiredState, details.HttpResponse.StatusCode)
+	synced, err := d.comparePatchedResults(sensitiveBody, sensitiveDesiredState, details.HttpResponse.StatusCode, scope)
 	if err != nil {
 		return false, err
 	}
@@ -64,8 +97,8 @@ func (d *defaultIsUpToDateResponseCheck) compareResponseAndDesiredState(ctx cont
 }
 
 // patchAndValidate patches secrets into a string and validates the result.
-func (d *defaultIsUpToDateResponseCheck) patchAndValidate(ctx context.Context, content string) (string, error) {
-	patched, err := datapatcher.PatchSecretsIntoString(ctx, d.localKube, content, d.logger)
+func (d *defaultIsUpToDateResponseCheck) patchAndValidate(ctx context.Context, content string, policy string) (string, error) {
+	patched, err := datapatcher.PatchSecretsIntoString(ctx, d.localKube, content, policy, d.logger)
 	if err != nil {
 		return "", err
 	}
@@ -74,10 +107,15 @@ func (d *defaultIsUpToDateResponseCheck) patchAndValidate(ctx context.Context, c
 }
 
 // comparePatchedResults compares the patched response and desired state to determine if they are in sync.
-func (d *defaultIsUpToDateResponseCheck) comparePatchedResults(body, desiredState string, statusCode int) (bool, error) {
+func (d *defaultIsUpToDateResponseCheck) comparePatchedResults(body, desiredState string, statusCode int, scope syncScope) (bool, error) {
+	// Desired state is a patch of the response
+	if scope.patchStrategy != "" {
+		return d.comparePatch(body, desiredState, statusCode, scope.patchStrategy)
+	}
+
 	// Both are JSON strings
 	if json.IsJSONString(body) && json.IsJSONString(desiredState) {
-		return d.compareJSON(body, desiredState, statusCode), nil
+		return d.compareJSON(body, desiredState, statusCode, scope.ownedFields)
 	}
 
 	// Body is not JSON but desired state is JSON
@@ -95,15 +133,82 @@ func (d *defaultIsUpToDateResponseCheck) comparePatchedResults(body, desiredStat
 }
 
 // compareJSON compares two JSON strings to determine if they are in sync.
-func (d *defaultIsUpToDateResponseCheck) compareJSON(body, desiredState string, statusCode int) bool {
+// When owned fields are given, only the values at those jq paths are compared.
+func (d *defaultIsUpToDateResponseCheck) compareJSON(body, desiredState string, statusCode int, ownedFields []string) (bool, error) {
 	responseBodyMap := json.JsonStringToMap(body)
 	desiredStateMap := json.JsonStringToMap(desiredState)
-	return json.Contains(responseBodyMap, desiredStateMap) && utils.IsHTTPSuccess(statusCode)
+
+	if len(ownedFields) != 0 {
+		var err error
+		if responseBodyMap, err = ownedValues(responseBodyMap, ownedFields); err != nil {
+			return false, err
+		}
+		if desiredStateMap, err = ownedValues(desiredStateMap, ownedFields); err != nil {
+			return false, err
+		}
+	}
+
+	return json.Contains(responseBodyMap, desiredStateMap) && utils.IsHTTPSuccess(statusCode), nil
+}
+
+// comparePatch determines whether the response body is in sync with a patch, i.e. whether applying the
+// patch to it changes nothing. A JSON merge patch is in sync when the response has the values it sets and
+// lacks the fields it sets to null. A JSON patch is in sync when its operations, applied in order, leave the
+// response as is; an operation that can't be applied, e.g. a failing test, is drift, except for a remove
+// of a field the response already lacks.
+func (d *defaultIsUpToDateResponseCheck) comparePatch(body, patch string, statusCode int, strategy string) (bool, error) {
+	if !json.IsJSONString(body) {
+		return false, errors.Errorf(errNotValidJSON, "response body", body)
+	}
+
+	patched := []byte(body)
+	switch strategy {
+	case v1alpha2.PatchStrategyJSONMerge:
+		var err error
+		if patched, err = jsonpatch.MergePatch(patched, []byte(patch)); err != nil {
+			return false, errors.Wrapf(err, errInvalidPatch, strategy)
+		}
+	case v1alpha2.PatchStrategyJSONPatch:
+		operations, err := jsonpatch.DecodePatch([]byte(patch))
+		if err != nil {
+			return false, errors.Wrapf(err, errInvalidPatch, strategy)
+		}
+		for _, operation := range operations {
+			next, err := jsonpatch.Patch{operation}.Apply(patched)
+			if err != nil {
+				if operation.Kind() == patchOperationRemove {
+					continue
+				}
+				d.logger.Debug("JSON patch operation can't be applied to the response", "operation", operation.Kind(), "error", err.Error())
+				return false, nil
+			}
+			patched = next
+		}
+	}
+
+	return jsonpatch.Equal([]byte(body), patched) && utils.IsHTTPSuccess(statusCode), nil
 }
 
-// desiredState returns the desired state for a given request
-func (d *defaultIsUpToDateResponseCheck) desiredState(ctx context.Context, cr *v1alpha2.Request) (string, error) {
-	requestDetails, err := d.requestDetails(ctx, cr, v1alpha2.ActionUpdate)
+// ownedValues returns the values found at the given jq paths of a JSON document, keyed by path.
+// Paths that resolve to null are left out, so fields the desired state does not set are not compared.
+func ownedValues(document map[string]interface{}, ownedFields []string) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(ownedFields))
+	for _, path := range ownedFields {
+		value, err := jq.ParseInterface(path, document)
+		if err != nil {
+			return nil, errors.Wrapf(err, errOwnedFieldFormat, path)
+		}
+		if value != nil {
+			values[path] = value
+		}
+	}
+
+	return values, nil
+}
+
+// desiredState returns the desired state produced by the UPDATE mapping of a given request.
+func (d *defaultIsUpToDateResponseCheck) desiredState(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping) (string, error) {
+	requestDetails, err := requestgen.GenerateValidRequestDetails(ctx, cr, mapping, d.localKube, d.logger)
 	if err != nil {
 		return "", err
 	}
@@ -120,15 +225,14 @@ type customIsUpToDateResponseCheck struct {
 
 // Check performs a custom response check using JQ logic.
 func (c *customIsUpToDateResponseCheck) Check(ctx context.Context, cr *v1alpha2.Request, details httpClient.HttpDetails, responseErr error) (bool, error) {
-	logic := cr.Spec.ForProvider.ExpectedResponseCheck.Logic
-	customCheck := &customCheck{localKube: c.localKube, logger: c.logger, http: c.http}
-
-	isUpToDate, err := customCheck.check(ctx, cr, details, logic)
+	logic, err := checkLogic(ctx, c.localKube, cr.Spec.ForProvider.ExpectedResponseCheck)
 	if err != nil {
-		return false, errors.Errorf(errExpectedFormat, "expectedResponseCheck", err.Error())
+		return false, errors.Wrapf(err, errLogicRef, "expectedResponseCheck")
 	}
 
-	return isUpToDate, nil
+	customCheck := &customCheck{localKube: c.localKube, logger: c.logger, http: c.http}
+
+	return customCheck.checkWithPolicy(ctx, cr, details, cr.Spec.ForProvider.ExpectedResponseCheck, logic, "expectedResponseCheck")
 }
 
 // isErrorMappingNotFound checks if the provided error indicates that the
@@ -137,16 +241,6 @@ func isErrorMappingNotFound(err error) bool {
 	return errors.Cause(err).Error() == fmt.Sprintf(requestmapping.ErrMappingNotFound, v1alpha2.ActionUpdate, http.MethodPut)
 }
 
-// requestDetails generates the request details for a given method or action.
-func (d *defaultIsUpToDateResponseCheck) requestDetails(ctx context.Context, cr *v1alpha2.Request, action string) (requestgen.RequestDetails, error) {
-	mapping, err := requestmapping.GetMapping(&cr.Spec.ForProvider, action, d.logger)
-	if err != nil {
-		return requestgen.RequestDetails{}, err
-	}
-
-	return requestgen.GenerateValidRequestDetails(ctx, cr, mapping, d.localKube, d.logger)
-}
-
 // isUpToDateChecksFactoryMap is a map that associates each check type with its corresponding factory function.
 var isUpToDateChecksFactoryMap = map[string]func(localKube client.Client, logger logging.Logger, http httpClient.Client) responseCheck{
 	v1alpha2.ExpectedResponseCheckTypeDefault: func(localKube client.Client, logger logging.Logger, http httpClient.Client) responseCheck {
@@ -4,12 +4,15 @@ import (
 	"context"
 	"testing"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
@@ -30,6 +33,35 @@ var (
 		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
 	}
 
+	testPutMappingWithEmail = v1alpha2.Mapping{
+		Method: "PUT",
+		Body:   "{ username: \"john_doe_new_username\", email: \"john.doe@example.com\" }",
+		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+	}
+
+	testMergePatchMapping = v1alpha2.Mapping{
+		Method:        "PATCH",
+		Action:        v1alpha2.ActionUpdate,
+		Body:          "{ username: \"john_doe_new_username\", nickname: null }",
+		URL:           "(.payload.baseUrl + \"/\" + .response.body.id)",
+		PatchStrategy: v1alpha2.PatchStrategyJSONMerge,
+	}
+
+	testJSONPatchMapping = v1alpha2.Mapping{
+		Method:        "PATCH",
+		Action:        v1alpha2.ActionUpdate,
+		Body:          "[{ op: \"replace\", path: \"/username\", value: \"john_doe_new_username\" }, { op: \"remove\", path: \"/nickname\" }]",
+		URL:           "(.payload.baseUrl + \"/\" + .response.body.id)",
+		PatchStrategy: v1alpha2.PatchStrategyJSONPatch,
+	}
+
+	testSkippedPutMapping = v1alpha2.Mapping{
+		Method:    "PUT",
+		Condition: `.response.body.state != "terminated"`,
+		Body:      "{ username: \"john_doe_new_username\" }",
+		URL:       "(.payload.baseUrl + \"/\" + .response.body.id)",
+	}
+
 	testDeleteMapping = v1alpha2.Mapping{
 		Method: "DELETE",
 		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
@@ -71,6 +103,74 @@ func Test_DefaultIsUpToDateCheck(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"SkippedUpdateMappingIsSynced": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body:    "{\"username\": \"john_doe\", \"email\": \"john.doe@example.com\"}",
+								BaseUrl: "https://api.example.com/users",
+							},
+							Mappings: []v1alpha2.Mapping{
+								testPostMapping,
+								testGetMapping,
+								testSkippedPutMapping,
+								testDeleteMapping,
+							},
+						},
+					},
+					Status: v1alpha2.RequestStatus{
+						Response: v1alpha2.Response{StatusCode: 200, Body: `{"id":"123","username":"john_doe","state":"terminated"}`},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id":"123","username":"john_doe","state":"terminated"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"SkippedByTheObservedResponse": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body:    "{\"username\": \"john_doe\", \"email\": \"john.doe@example.com\"}",
+								BaseUrl: "https://api.example.com/users",
+							},
+							Mappings: []v1alpha2.Mapping{
+								testPostMapping,
+								testGetMapping,
+								testSkippedPutMapping,
+								testDeleteMapping,
+							},
+						},
+					},
+					Status: v1alpha2.RequestStatus{
+						Response: v1alpha2.Response{StatusCode: 200, Body: `{"id":"123","username":"john_doe","state":"active"}`},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id":"123","username":"john_doe","state":"terminated"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
 		"UnsyncedStateWithValidJSON": {
 			args: args{
 				ctx: context.Background(),
@@ -142,122 +242,744 @@ func Test_DefaultIsUpToDateCheck(t *testing.T) {
 				err:    errors.New("response body is not a valid JSON string: {"),
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		tc := tc
-
-		t.Run(name, func(t *testing.T) {
-			e := &defaultIsUpToDateResponseCheck{
-				localKube: nil,
-				http:      nil,
-				logger:    logging.NewNopLogger(),
-			}
-			got, gotErr := e.Check(tc.args.ctx, tc.args.cr, tc.args.details, tc.args.responseErr)
-			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
-				t.Fatalf("Check(...): -want error, +got error: %s", diff)
-			}
-
-			if diff := cmp.Diff(tc.want.result, got); diff != "" {
-				t.Fatalf("Check(...): -want result, +got result: %s", diff)
-			}
-		})
-	}
-}
-
-func Test_CustomIsUpToDateCheck(t *testing.T) {
-	type args struct {
-		ctx         context.Context
-		cr          *v1alpha2.Request
-		details     httpClient.HttpDetails
-		responseErr error
-	}
-
-	type want struct {
-		result bool
-		err    error
-	}
-
-	cases := map[string]struct {
-		args args
-		want want
-	}{
-		"CustomCheckPasses": {
+		"NonOwnedFieldDriftIsSynced": {
 			args: args{
 				ctx: context.Background(),
 				cr: &v1alpha2.Request{
 					Spec: v1alpha2.RequestSpec{
 						ForProvider: v1alpha2.RequestParameters{
 							Payload: v1alpha2.Payload{
-								Body: `{"password": "password"}`,
+								Body:    "{\"username\": \"john_doe\", \"email\": \"john.doe@example.com\"}",
+								BaseUrl: "https://api.example.com/users",
 							},
-							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
-								Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
-								Logic: `.response.body.password == .payload.body.password`,
+							Mappings: []v1alpha2.Mapping{
+								testPostMapping,
+								testGetMapping,
+								testPutMappingWithEmail,
+								testDeleteMapping,
 							},
+							OwnedFields: []string{".username"},
 						},
 					},
 				},
 				details: httpClient.HttpDetails{
 					HttpResponse: httpClient.HttpResponse{
-						Body:       `{"password":"password"}`,
-						Headers:    nil,
-						StatusCode: 0,
+						Body:       `{"username": "john_doe_new_username", "email": "JOHN.DOE@EXAMPLE.COM"}`,
+						StatusCode: 200,
 					},
 				},
-				responseErr: nil,
 			},
 			want: want{
 				result: true,
 				err:    nil,
 			},
 		},
-		"CustomCheckFails": {
+		"OwnedFieldDriftIsNotSynced": {
 			args: args{
 				ctx: context.Background(),
 				cr: &v1alpha2.Request{
 					Spec: v1alpha2.RequestSpec{
 						ForProvider: v1alpha2.RequestParameters{
 							Payload: v1alpha2.Payload{
-								Body: `{"password": "password"}`,
+								Body:    "{\"username\": \"john_doe\", \"email\": \"john.doe@example.com\"}",
+								BaseUrl: "https://api.example.com/users",
 							},
-							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
-								Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
-								Logic: `.response.body.password == .payload.body.password`,
+							Mappings: []v1alpha2.Mapping{
+								testPostMapping,
+								testGetMapping,
+								testPutMappingWithEmail,
+								testDeleteMapping,
 							},
+							OwnedFields: []string{".username"},
 						},
 					},
 				},
 				details: httpClient.HttpDetails{
 					HttpResponse: httpClient.HttpResponse{
-						Body:       `{"password":"wrong_password"}`,
-						Headers:    nil,
-						StatusCode: 0,
+						Body:       `{"username": "john_doe", "email": "john.doe@example.com"}`,
+						StatusCode: 200,
 					},
 				},
-				responseErr: nil,
 			},
 			want: want{
 				result: false,
 				err:    nil,
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		tc := tc // Create local copies of loop variables
-
-		t.Run(name, func(t *testing.T) {
-			e := &customIsUpToDateResponseCheck{
-				localKube: nil,
-				http:      nil,
-				logger:    logging.NewNopLogger(),
-			}
-			got, gotErr := e.Check(tc.args.ctx, tc.args.cr, tc.args.details, tc.args.responseErr)
-			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
-				t.Fatalf("Check(...): -want error, +got error: %s", diff)
-			}
-
+		"InvalidOwnedField": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body:    "{\"username\": \"john_doe\", \"email\": \"john.doe@example.com\"}",
+								BaseUrl: "https://api.example.com/users",
+							},
+							Mappings: []v1alpha2.Mapping{
+								testPostMapping,
+								testGetMapping,
+								testPutMappingWithEmail,
+								testDeleteMapping,
+							},
+							OwnedFields: []string{".username["},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"username": "john_doe", "email": "john.doe@example.com"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: false,
+				err:    errors.Wrapf(errors.New("unexpected EOF"), errOwnedFieldFormat, ".username["),
+			},
+		},
+		"PartialMergePatchResponseIsSynced": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body:    "{\"username\": \"john_doe\", \"email\": \"john.doe@example.com\"}",
+								BaseUrl: "https://api.example.com/users",
+							},
+							Mappings: []v1alpha2.Mapping{
+								testPostMapping,
+								testGetMapping,
+								testMergePatchMapping,
+								testDeleteMapping,
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id": "123", "username": "john_doe_new_username", "email": "john.doe@example.com"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"MergePatchNullFieldPresentIsNotSynced": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body:    "{\"username\": \"john_doe\", \"email\": \"john.doe@example.com\"}",
+								BaseUrl: "https://api.example.com/users",
+							},
+							Mappings: []v1alpha2.Mapping{
+								testPostMapping,
+								testGetMapping,
+								testMergePatchMapping,
+								testDeleteMapping,
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id": "123", "username": "john_doe_new_username", "nickname": "johnny"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		"MergePatchChangedFieldIsNotSynced": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body:    "{\"username\": \"john_doe\", \"email\": \"john.doe@example.com\"}",
+								BaseUrl: "https://api.example.com/users",
+							},
+							Mappings: []v1alpha2.Mapping{
+								testPostMapping,
+								testGetMapping,
+								testMergePatchMapping,
+								testDeleteMapping,
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id": "123", "username": "john_doe", "email": "john.doe@example.com"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		"PartialJSONPatchResponseIsSynced": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body:    "{\"username\": \"john_doe\", \"email\": \"john.doe@example.com\"}",
+								BaseUrl: "https://api.example.com/users",
+							},
+							Mappings: []v1alpha2.Mapping{
+								testPostMapping,
+								testGetMapping,
+								testJSONPatchMapping,
+								testDeleteMapping,
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id": "123", "username": "john_doe_new_username", "email": "john.doe@example.com"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"JSONPatchChangedFieldIsNotSynced": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body:    "{\"username\": \"john_doe\", \"email\": \"john.doe@example.com\"}",
+								BaseUrl: "https://api.example.com/users",
+							},
+							Mappings: []v1alpha2.Mapping{
+								testPostMapping,
+								testGetMapping,
+								testJSONPatchMapping,
+								testDeleteMapping,
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id": "123", "username": "john_doe", "email": "john.doe@example.com"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		"JSONPatchUnappliedOperationIsNotSynced": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body:    "{\"username\": \"john_doe\", \"email\": \"john.doe@example.com\"}",
+								BaseUrl: "https://api.example.com/users",
+							},
+							Mappings: []v1alpha2.Mapping{
+								testPostMapping,
+								testGetMapping,
+								testJSONPatchMapping,
+								testDeleteMapping,
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id": "123", "email": "john.doe@example.com"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			e := &defaultIsUpToDateResponseCheck{
+				localKube: nil,
+				http:      nil,
+				logger:    logging.NewNopLogger(),
+			}
+			got, gotErr := e.Check(tc.args.ctx, tc.args.cr, tc.args.details, tc.args.responseErr)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("Check(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("Check(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_CustomIsUpToDateCheck(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		localKube   client.Client
+		cr          *v1alpha2.Request
+		details     httpClient.HttpDetails
+		responseErr error
+	}
+
+	type want struct {
+		result bool
+		err    error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"CustomCheckPasses": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body: `{"password": "password"}`,
+							},
+							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+								Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+								Logic: `.response.body.password == .payload.body.password`,
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"password":"password"}`,
+						Headers:    nil,
+						StatusCode: 0,
+					},
+				},
+				responseErr: nil,
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"CustomCheckFails": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body: `{"password": "password"}`,
+							},
+							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+								Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+								Logic: `.response.body.password == .payload.body.password`,
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"password":"wrong_password"}`,
+						Headers:    nil,
+						StatusCode: 0,
+					},
+				},
+				responseErr: nil,
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		"CustomCheckLogicFromConfigMap": {
+			args: args{
+				ctx: context.Background(),
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.(*corev1.ConfigMap).Data = map[string]string{
+							"logic": `.response.body.password == .payload.body.password`,
+						}
+						return nil
+					}),
+				},
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body: `{"password": "password"}`,
+							},
+							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+								Type:     v1alpha2.ExpectedResponseCheckTypeCustom,
+								LogicRef: &common.ConfigMapKeyRef{Name: "checks", Namespace: "default", Key: "logic"},
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"password":"password"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"CustomCheckLogicConfigMapKeyMissing": {
+			args: args{
+				ctx: context.Background(),
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.(*corev1.ConfigMap).Data = map[string]string{"other": "true"}
+						return nil
+					}),
+				},
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+								Type:     v1alpha2.ExpectedResponseCheckTypeCustom,
+								LogicRef: &common.ConfigMapKeyRef{Name: "checks", Namespace: "default", Key: "logic"},
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"password":"password"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: false,
+				err:    errors.Wrapf(errors.Errorf(errLogicConfigMapKey, "logic", "default", "checks"), errLogicRef, "expectedResponseCheck"),
+			},
+		},
+		"CustomCheckXMLResponsePasses": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body: `{"username": "john_doe"}`,
+							},
+							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+								Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+								Logic: `.response.body.Envelope.Body.GetUserResponse.username == .payload.body.username and .response.body.Envelope.Body.GetUserResponse["@status"] == "active"`,
+							},
+							ResponseBodyFormat: common.ResponseBodyFormatXML,
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `<?xml version="1.0"?><soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><GetUserResponse status="active"><username>john_doe</username></GetUserResponse></soap:Body></soap:Envelope>`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"CustomCheckXMLResponseFails": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body: `{"username": "john_doe"}`,
+							},
+							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+								Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+								Logic: `.response.body.Envelope.Body.GetUserResponse.username == "jane_doe"`,
+							},
+							ResponseBodyFormat: common.ResponseBodyFormatXML,
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `<?xml version="1.0"?><soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><GetUserResponse status="active"><username>john_doe</username></GetUserResponse></soap:Body></soap:Envelope>`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		"CustomCheckYAMLResponsePasses": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body: `{"username": "john_doe"}`,
+							},
+							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+								Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+								Logic: `.response.body.user.username == .payload.body.username and .response.body.user.roles[0] == "admin"`,
+							},
+							ResponseBodyFormat: common.ResponseBodyFormatYAML,
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       "user:\n  username: john_doe\n  roles:\n    - admin\n",
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"CustomCheckYAMLResponseFails": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body: `{"username": "john_doe"}`,
+							},
+							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+								Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+								Logic: `.response.body.user.roles | index("viewer") != null`,
+							},
+							ResponseBodyFormat: common.ResponseBodyFormatYAML,
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       "user:\n  username: john_doe\n  roles:\n    - admin\n",
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		"CustomCheckRawResponse": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body: `{"username": "john_doe"}`,
+							},
+							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+								Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+								Logic: `.response.body | startswith("{\"username\"")`,
+							},
+							ResponseBodyFormat: common.ResponseBodyFormatRaw,
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"username": "john_doe"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"CustomCheckInvalidXMLResponse": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								Body: `{"username": "john_doe"}`,
+							},
+							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+								Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+								Logic: `.response.body.username == "john_doe"`,
+							},
+							ResponseBodyFormat: common.ResponseBodyFormatXML,
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"username": "john_doe"}`,
+						StatusCode: 200,
+					},
+				},
+			},
+			want: want{
+				result: false,
+				err:    errors.Errorf(errExpectedFormat, "expectedResponseCheck", "cannot parse the response body as XML: the response body has no XML element"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &customIsUpToDateResponseCheck{
+				localKube: tc.args.localKube,
+				http:      nil,
+				logger:    logging.NewNopLogger(),
+			}
+			got, gotErr := e.Check(tc.args.ctx, tc.args.cr, tc.args.details, tc.args.responseErr)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("Check(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("Check(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_CustomIsUpToDateCheck_OnCheckError(t *testing.T) {
+	// The primary logic errors on a response of an unexpected shape, items being a string.
+	primaryLogic := `.response.body.items[0].status == "ready"`
+	details := httpClient.HttpDetails{
+		HttpResponse: httpClient.HttpResponse{
+			Body:       `{"items": "none", "status": "ready"}`,
+			StatusCode: 200,
+		},
+	}
+
+	type args struct {
+		onCheckError  string
+		fallbackLogic string
+	}
+	type want struct {
+		result bool
+		err    error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"FailByDefault": {
+			args: args{},
+			want: want{
+				err: errors.Errorf(errExpectedFormat, "expectedResponseCheck", `failed to parse given mapping - .response.body.items[0].status == "ready" jq error: expected an object but got: string ("n")`),
+			},
+		},
+		"Fail": {
+			args: args{onCheckError: v1alpha2.OnCheckErrorFail},
+			want: want{
+				err: errors.Errorf(errExpectedFormat, "expectedResponseCheck", `failed to parse given mapping - .response.body.items[0].status == "ready" jq error: expected an object but got: string ("n")`),
+			},
+		},
+		"NotSynced": {
+			args: args{onCheckError: v1alpha2.OnCheckErrorNotSynced},
+			want: want{
+				result: false,
+			},
+		},
+		"FallbackLogicPasses": {
+			args: args{
+				onCheckError:  v1alpha2.OnCheckErrorFallbackLogic,
+				fallbackLogic: `.response.body.status == "ready"`,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"FallbackLogicFails": {
+			args: args{
+				onCheckError:  v1alpha2.OnCheckErrorFallbackLogic,
+				fallbackLogic: `.response.body.status == "deleted"`,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"FallbackLogicErrors": {
+			args: args{
+				onCheckError:  v1alpha2.OnCheckErrorFallbackLogic,
+				fallbackLogic: `.response.body.items.count > 0`,
+			},
+			want: want{
+				err: errors.Errorf(errFallbackFormat, "expectedResponseCheck", `failed to parse given mapping - .response.body.items.count > 0 jq error: expected an object but got: string ("none")`),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Request{
+				Spec: v1alpha2.RequestSpec{
+					ForProvider: v1alpha2.RequestParameters{
+						ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+							Type:          v1alpha2.ExpectedResponseCheckTypeCustom,
+							Logic:         primaryLogic,
+							OnCheckError:  tc.args.onCheckError,
+							FallbackLogic: tc.args.fallbackLogic,
+						},
+					},
+				},
+			}
+
+			e := &customIsUpToDateResponseCheck{logger: logging.NewNopLogger()}
+			got, gotErr := e.Check(context.Background(), cr, details, nil)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("Check(...): -want error, +got error: %s", diff)
+			}
 			if diff := cmp.Diff(tc.want.result, got); diff != "" {
 				t.Fatalf("Check(...): -want result, +got result: %s", diff)
 			}
@@ -5,16 +5,26 @@ import (
 	"fmt"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/bodyformat"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
 	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
 	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	errLogicRef          = "cannot read %s.logicRef"
+	errLogicConfigMapKey = "key %s not found in ConfigMap %s:%s"
+	errFallbackFormat    = "%s.FallbackLogic JQ filter should return a boolean, but returned error: %s"
+)
+
 // responseCheck is an interface for performing response checks.
 type responseCheck interface {
 	Check(ctx context.Context, cr *v1alpha2.Request, details httpClient.HttpDetails, responseErr error) (bool, error)
@@ -31,15 +41,29 @@ type customCheck struct {
 func (c *customCheck) check(ctx context.Context, cr *v1alpha2.Request, details httpClient.HttpDetails, logic string) (bool, error) {
 	// Convert response to a map and apply JQ logic
 	response := responseconverter.HttpResponseToV1alpha1Response(details.HttpResponse)
-	responseMap := requestgen.GenerateRequestObject(cr.Spec.ForProvider, response)
+	responseMap, err := requestgen.GenerateRequestObject(cr.Spec.ForProvider, cr, response)
+	if err != nil {
+		return false, err
+	}
+	if responseObject, ok := responseMap["response"].(map[string]interface{}); ok {
+		// The checks also work on responses whose body is a JSON array or scalar, e.g. `.response.body | length`.
+		bodyformat.SetJSONBody(responseObject, response.Body, cr.Spec.ForProvider.ResponseBodyFormat)
+	}
+
+	// Expose the request that produced the response, for APIs echoing it back.
+	requestMap, err := requestObject(details.HttpRequest)
+	if err != nil {
+		return false, err
+	}
+	responseMap["request"] = requestMap
 
 	jqQuery := utils.NormalizeWhitespace(logic)
-	sensitiveJQQuery, err := datapatcher.PatchSecretsIntoString(ctx, c.localKube, jqQuery, c.logger)
+	sensitiveJQQuery, err := datapatcher.PatchSecretsIntoString(ctx, c.localKube, jqQuery, cr.Spec.ForProvider.UnresolvedSecretPolicy, c.logger)
 	if err != nil {
 		return false, err
 	}
 
-	sensitiveResponse, err := datapatcher.PatchSecretsIntoMap(ctx, c.localKube, responseMap, c.logger)
+	sensitiveResponse, err := datapatcher.PatchSecretsIntoMap(ctx, c.localKube, responseMap, cr.Spec.ForProvider.UnresolvedSecretPolicy, c.logger)
 	if err != nil {
 		return false, err
 	}
@@ -53,3 +77,62 @@ func (c *customCheck) check(ctx context.Context, cr *v1alpha2.Request, details h
 
 	return isExpected, nil
 }
+
+// checkWithPolicy performs the custom response check of the named check, and handles an error of its logic
+// according to its OnCheckError policy.
+func (c *customCheck) checkWithPolicy(ctx context.Context, cr *v1alpha2.Request, details httpClient.HttpDetails, check v1alpha2.ExpectedResponseCheck, logic string, name string) (bool, error) {
+	result, err := c.check(ctx, cr, details, logic)
+	if err == nil {
+		return result, nil
+	}
+
+	switch check.OnCheckError {
+	case v1alpha2.OnCheckErrorNotSynced:
+		c.logger.Info(fmt.Sprintf("%s.Logic failed, treating the check as false", name), "error", err.Error())
+		return false, nil
+	case v1alpha2.OnCheckErrorFallbackLogic:
+		c.logger.Info(fmt.Sprintf("%s.Logic failed, evaluating the fallback logic", name), "error", err.Error())
+		result, err = c.check(ctx, cr, details, check.FallbackLogic)
+		if err != nil {
+			return false, errors.Errorf(errFallbackFormat, name, err.Error())
+		}
+		return result, nil
+	default:
+		return false, errors.Errorf(errExpectedFormat, name, err.Error())
+	}
+}
+
+// checkLogic returns the jq logic of a check, read from the referenced ConfigMap key when set.
+func checkLogic(ctx context.Context, localKube client.Client, check v1alpha2.ExpectedResponseCheck) (string, error) {
+	ref := check.LogicRef
+	if ref == nil {
+		return check.Logic, nil
+	}
+
+	configMap, err := kubehandler.GetConfigMap(ctx, localKube, ref.Name, ref.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	logic, ok := configMap.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf(errLogicConfigMapKey, ref.Key, ref.Namespace, ref.Name)
+	}
+
+	return logic, nil
+}
+
+// requestObject converts the sent request to a map exposed to jq filters.
+// A JSON body is exposed as an object.
+func requestObject(request httpClient.HttpRequest) (map[string]interface{}, error) {
+	requestMap, err := json_util.StructToMap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json_util.ConvertJSONStringsToMaps(&requestMap); err != nil {
+		return nil, err
+	}
+
+	return requestMap, nil
+}
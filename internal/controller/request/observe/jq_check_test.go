@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -92,6 +93,128 @@ func Test_CustomCheck(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"EchoedRequestFieldMatches": {
+			args: args{
+				ctx: context.Background(),
+				cr:  &v1alpha2.Request{},
+				details: httpClient.HttpDetails{
+					HttpRequest: httpClient.HttpRequest{
+						Method: "POST",
+						URL:    "https://api.example.com/users",
+						Body:   `{"name":"john_doe"}`,
+					},
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id":"123","name":"john_doe"}`,
+						StatusCode: 201,
+					},
+				},
+				logic: `.response.body.name == .request.body.name and .request.method == "POST"`,
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"EchoedRequestFieldDiffers": {
+			args: args{
+				ctx: context.Background(),
+				cr:  &v1alpha2.Request{},
+				details: httpClient.HttpDetails{
+					HttpRequest: httpClient.HttpRequest{
+						Method: "POST",
+						URL:    "https://api.example.com/users",
+						Body:   `{"name":"john_doe"}`,
+					},
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id":"123","name":"john-doe"}`,
+						StatusCode: 201,
+					},
+				},
+				logic: `.response.body.name == .request.body.name`,
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		"ParsedAndRawBody": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							PreserveRawBody: true,
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"cert":"-----BEGIN CERTIFICATE-----"}`,
+						StatusCode: 200,
+					},
+				},
+				logic: `(.response.body.cert | startswith("-----BEGIN")) and .response.rawBody == "{\"cert\":\"-----BEGIN CERTIFICATE-----\"}"`,
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"ArrayRootBody": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{Spec: v1alpha2.RequestSpec{ForProvider: v1alpha2.RequestParameters{
+					ResponseBodyFormat: common.ResponseBodyFormatJSON,
+				}}},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `[{"name":"john_doe","active":true},{"name":"jane_doe","active":false}]`,
+						StatusCode: 200,
+					},
+				},
+				logic: `(.response.body | length) == 2 and ([.response.body[] | select(.active)] | length) == 1`,
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"ScalarRootBody": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{Spec: v1alpha2.RequestSpec{ForProvider: v1alpha2.RequestParameters{
+					ResponseBodyFormat: common.ResponseBodyFormatJSON,
+				}}},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `5`,
+						StatusCode: 200,
+					},
+				},
+				logic: `.response.body == 5`,
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"RawBodyNotPreserved": {
+			args: args{
+				ctx: context.Background(),
+				cr:  &v1alpha2.Request{},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"cert":"-----BEGIN CERTIFICATE-----"}`,
+						StatusCode: 200,
+					},
+				},
+				logic: `.response.body.cert != null and .response.rawBody == null`,
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
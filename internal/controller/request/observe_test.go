@@ -534,6 +534,45 @@ func Test_isObjectValidForObservation(t *testing.T) {
 				valid: false,
 			},
 		},
+		"DELETEMethodWithNotFoundResponse": {
+			args: args{
+				cr: &v1alpha2.Request{
+					Status: v1alpha2.RequestStatus{
+						Response: v1alpha2.Response{
+							StatusCode: http.StatusNotFound,
+						},
+						RequestDetails: v1alpha2.Mapping{
+							Method: http.MethodDelete,
+						},
+					},
+				},
+			},
+			want: want{
+				valid: false,
+			},
+		},
+		"PUTMethodWithAbsentStatusCode": {
+			args: args{
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							ResourceAbsentStatusCodes: []int{http.StatusGone},
+						},
+					},
+					Status: v1alpha2.RequestStatus{
+						Response: v1alpha2.Response{
+							StatusCode: http.StatusGone,
+						},
+						RequestDetails: v1alpha2.Mapping{
+							Method: http.MethodPut,
+						},
+					},
+				},
+			},
+			want: want{
+				valid: false,
+			},
+		},
 		"POSTMethodWithoutErrorResponse": {
 			args: args{
 				cr: &v1alpha2.Request{
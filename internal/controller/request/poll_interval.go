@@ -0,0 +1,85 @@
+package request
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+const (
+	// minHeaderPollInterval is the shortest interval a pollIntervalHeader can set, so that a server can't
+	// make the provider reconcile a Request in a tight loop.
+	minHeaderPollInterval = time.Second
+
+	// maxHeaderPollIntervalFactor bounds the interval a pollIntervalHeader can set to this many poll
+	// intervals, so that a Request is still checked for drift within a bounded time.
+	maxHeaderPollIntervalFactor = 10
+)
+
+// WithPollIntervalHook returns a managed.ReconcilerOption reconciling the Requests when the pollIntervalHeader
+// of their last response asks to, or else at their refresh interval when it is shorter than the poll interval,
+// so that their injected secrets are refreshed.
+func WithPollIntervalHook() managed.ReconcilerOption {
+	return managed.WithPollIntervalHook(func(mg resource.Managed, pollInterval time.Duration) time.Duration {
+		return requestPollInterval(mg, pollInterval, time.Now())
+	})
+}
+
+// requestPollInterval returns the interval after which the Request is reconciled next.
+func requestPollInterval(mg resource.Managed, pollInterval time.Duration, now time.Time) time.Duration {
+	if cr, ok := mg.(*v1alpha2.Request); ok {
+		if interval, ok := headerPollInterval(cr, pollInterval, now); ok {
+			return interval
+		}
+	}
+
+	return refreshPollInterval(mg, pollInterval)
+}
+
+// headerPollInterval returns the interval set by the pollIntervalHeader of the last response, either a number of
+// seconds or an HTTP date, bounded by minHeaderPollInterval and maxHeaderPollIntervalFactor poll intervals. It
+// returns false when the header is not configured, missing, invalid or in the past.
+func headerPollInterval(cr *v1alpha2.Request, pollInterval time.Duration, now time.Time) (time.Duration, bool) {
+	name := cr.Spec.ForProvider.PollIntervalHeader
+	if name == "" {
+		return 0, false
+	}
+
+	for key, values := range cr.Status.Response.Headers {
+		if !strings.EqualFold(key, name) || len(values) == 0 {
+			continue
+		}
+
+		interval, ok := parsePollInterval(strings.TrimSpace(values[0]), now)
+		if !ok || interval <= 0 {
+			return 0, false
+		}
+		return min(max(interval, minHeaderPollInterval), maxHeaderPollIntervalFactor*pollInterval), true
+	}
+
+	return 0, false
+}
+
+// parsePollInterval parses a header value in the format of Retry-After, a number of seconds or an HTTP date.
+// A number of seconds too large for a time.Duration is capped to the longest one.
+func parsePollInterval(value string, now time.Time) (time.Duration, bool) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds > int64(math.MaxInt64/time.Second) {
+			return time.Duration(math.MaxInt64), true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return date.Sub(now), true
+	}
+
+	return 0, false
+}
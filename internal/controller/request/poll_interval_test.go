@@ -0,0 +1,128 @@
+package request
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func Test_requestPollInterval(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	type args struct {
+		header          string
+		headers         map[string][]string
+		refreshInterval *v1.Duration
+		now             time.Time
+	}
+	cases := map[string]struct {
+		args args
+		want time.Duration
+	}{
+		"NoHeaderConfigured": {
+			args: args{
+				headers: map[string][]string{"X-Poll-After": {"10"}},
+			},
+			want: time.Minute,
+		},
+		"SecondsHeader": {
+			args: args{
+				header:  "X-Poll-After",
+				headers: map[string][]string{"X-Poll-After": {"10"}},
+			},
+			want: 10 * time.Second,
+		},
+		"HeaderNameIsCaseInsensitive": {
+			args: args{
+				header:  "X-Poll-After",
+				headers: map[string][]string{"x-poll-after": {"300"}},
+			},
+			want: 5 * time.Minute,
+		},
+		"HTTPDateHeader": {
+			args: args{
+				header:  "X-Poll-After",
+				headers: map[string][]string{"X-Poll-After": {now.Add(90 * time.Second).Format(http.TimeFormat)}},
+			},
+			want: 90 * time.Second,
+		},
+		"ShortHTTPDateClampedToMinimum": {
+			args: args{
+				header:  "X-Poll-After",
+				headers: map[string][]string{"X-Poll-After": {now.Truncate(time.Second).Add(time.Second).Format(http.TimeFormat)}},
+				now:     now.Add(800 * time.Millisecond),
+			},
+			want: time.Second,
+		},
+		"LongHeaderClampedToMaximum": {
+			args: args{
+				header:  "X-Poll-After",
+				headers: map[string][]string{"X-Poll-After": {"86400"}},
+			},
+			want: 10 * time.Minute,
+		},
+		"OverflowingHeaderClampedToMaximum": {
+			args: args{
+				header:  "X-Poll-After",
+				headers: map[string][]string{"X-Poll-After": {"9223372036854775807"}},
+			},
+			want: 10 * time.Minute,
+		},
+		"PastHTTPDateFallsBack": {
+			args: args{
+				header:  "X-Poll-After",
+				headers: map[string][]string{"X-Poll-After": {now.Add(-time.Minute).Format(http.TimeFormat)}},
+			},
+			want: time.Minute,
+		},
+		"InvalidHeaderFallsBack": {
+			args: args{
+				header:  "X-Poll-After",
+				headers: map[string][]string{"X-Poll-After": {"soon"}},
+			},
+			want: time.Minute,
+		},
+		"MissingHeaderFallsBackToRefreshInterval": {
+			args: args{
+				header:          "X-Poll-After",
+				headers:         map[string][]string{"Content-Type": {"application/json"}},
+				refreshInterval: &v1.Duration{Duration: 15 * time.Second},
+			},
+			want: 15 * time.Second,
+		},
+		"HeaderTakesPrecedenceOverRefreshInterval": {
+			args: args{
+				header:          "X-Poll-After",
+				headers:         map[string][]string{"X-Poll-After": {"120"}},
+				refreshInterval: &v1.Duration{Duration: 15 * time.Second},
+			},
+			want: 2 * time.Minute,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.PollIntervalHeader = tc.args.header
+				r.Spec.ForProvider.RefreshInterval = tc.args.refreshInterval
+				r.Status.Response.Headers = tc.args.headers
+			})
+
+			at := now
+			if !tc.args.now.IsZero() {
+				at = tc.args.now
+			}
+
+			got := requestPollInterval(cr, time.Minute, at)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("requestPollInterval(...): -want interval, +got interval: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package request
+
+import (
+	"context"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestmapping"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+// refreshPollInterval returns the refresh interval of the Request when it is shorter than the poll interval.
+func refreshPollInterval(mg resource.Managed, pollInterval time.Duration) time.Duration {
+	cr, ok := mg.(*v1alpha2.Request)
+	if !ok || cr.Spec.ForProvider.RefreshInterval == nil {
+		return pollInterval
+	}
+
+	if refresh := cr.Spec.ForProvider.RefreshInterval.Duration; refresh > 0 && refresh < pollInterval {
+		return refresh
+	}
+
+	return pollInterval
+}
+
+// onlyRefreshSecrets returns true if the reconcile should only refresh the injected secrets, which is the case
+// when the last drift check found the current generation of the Request up to date less than a poll interval ago.
+func (c *external) onlyRefreshSecrets(cr *v1alpha2.Request) bool {
+	check := cr.Status.LastDriftCheck
+	if cr.Spec.ForProvider.RefreshInterval == nil || check == nil || hasItems(cr) {
+		return false
+	}
+
+	return check.Generation == cr.Generation && time.Since(check.Time.Time) < c.pollInterval
+}
+
+// refreshSecrets sends the OBSERVE request only to inject its response into the secrets. The status of the
+// Request is left untouched and a failed refresh is only logged, so that its synced state isn't affected.
+func (c *external) refreshSecrets(ctx context.Context, cr *v1alpha2.Request) {
+	mapping, err := requestmapping.GetMapping(&cr.Spec.ForProvider, v1alpha2.ActionObserve, c.logger)
+	if err != nil {
+		c.logger.Info(err.Error())
+		return
+	}
+
+	requestDetails, err := requestgen.GenerateValidRequestDetails(ctx, cr, mapping, c.localKube, c.logger)
+	if err != nil {
+		c.logger.Info("Failed to generate the request refreshing the injected secrets", "error", err.Error())
+		return
+	}
+
+	details, err := c.sendRequest(ctx, cr, mapping, requestDetails)
+	if err != nil || !utils.IsHTTPSuccess(details.HttpResponse.StatusCode) {
+		c.logger.Info("Failed to refresh the injected secrets", "statusCode", details.HttpResponse.StatusCode, "error", err)
+		return
+	}
+
+	c.keepResponse(details.HttpResponse)
+	utils.SetSecretInjectionCondition(cr, datapatcher.ApplyResponseDataToSecrets(ctx, c.localKube, c.logger, &details.HttpResponse, cr.Spec.ForProvider.SecretInjectionConfigs, cr.Spec.ForProvider.SecretInjectionDiscriminator, cr.Spec.ForProvider.AtomicSecretInjection, cr.Spec.ForProvider.PreserveRawBody, cr))
+}
+
+// recordDriftCheck records a drift check that found the Request up to date when refreshInterval is set.
+func recordDriftCheck(cr *v1alpha2.Request, synced bool) {
+	if cr.Spec.ForProvider.RefreshInterval == nil || !synced {
+		cr.Status.LastDriftCheck = nil
+		return
+	}
+
+	cr.Status.LastDriftCheck = &v1alpha2.DriftCheck{
+		Time:       metav1.Now(),
+		Generation: cr.Generation,
+	}
+}
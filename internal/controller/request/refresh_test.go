@@ -0,0 +1,176 @@
+package request
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_httpExternal_Observe_RefreshInterval(t *testing.T) {
+	type args struct {
+		checkAge        time.Duration
+		checkGeneration int64
+	}
+	type want struct {
+		requests      int
+		injections    int
+		statusUpdates int
+		checkUpdated  bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"RecentDriftCheckOnlyRefreshesSecrets": {
+			args: args{
+				checkAge:        10 * time.Second,
+				checkGeneration: 1,
+			},
+			want: want{
+				requests:      1,
+				injections:    1,
+				statusUpdates: 0,
+				checkUpdated:  false,
+			},
+		},
+		"ExpiredDriftCheckChecksDrift": {
+			args: args{
+				checkAge:        2 * time.Minute,
+				checkGeneration: 1,
+			},
+			want: want{
+				requests:      1,
+				injections:    1,
+				statusUpdates: 1,
+				checkUpdated:  true,
+			},
+		},
+		"ChangedGenerationChecksDrift": {
+			args: args{
+				checkAge:        10 * time.Second,
+				checkGeneration: 0,
+			},
+			want: want{
+				requests:      1,
+				injections:    1,
+				statusUpdates: 1,
+				checkUpdated:  true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			requests, injections, statusUpdates := 0, 0, 0
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+						if secret, ok := obj.(*corev1.Secret); ok && string(secret.Data["token"]) == "rotated" {
+							injections++
+						}
+						return nil
+					},
+					MockStatusUpdate: func(_ context.Context, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+						statusUpdates++
+						return nil
+					},
+				},
+				logger:       logging.NewNopLogger(),
+				pollInterval: time.Minute,
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						requests++
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 200,
+								Body:       `{"id":"123","username":"john_doe_new_username","token":"rotated"}`,
+							},
+						}, nil
+					},
+				},
+			}
+
+			checkTime := v1.NewTime(time.Now().Add(-tc.args.checkAge).Truncate(time.Second))
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Generation = 1
+				r.Spec.ForProvider.RefreshInterval = &v1.Duration{Duration: 15 * time.Second}
+				r.Spec.ForProvider.SecretInjectionConfigs = []common.SecretInjectionConfig{
+					{
+						SecretRef:   common.SecretRef{Name: "token", Namespace: testNamespace},
+						KeyMappings: []common.KeyInjection{{SecretKey: "token", ResponseJQ: ".body.token"}},
+					},
+				}
+				r.Status.Response.StatusCode = 200
+				r.Status.Response.Body = `{"id":"123"}`
+				r.Status.LastDriftCheck = &v1alpha2.DriftCheck{Time: checkTime, Generation: tc.args.checkGeneration}
+			})
+
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(true, got.ResourceUpToDate); diff != "" {
+				t.Fatalf("e.Observe(...): -want up to date, +got up to date: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, requests); diff != "" {
+				t.Fatalf("e.Observe(...): -want requests, +got requests: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.injections, injections); diff != "" {
+				t.Fatalf("e.Observe(...): -want injections, +got injections: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.statusUpdates, statusUpdates); diff != "" {
+				t.Fatalf("e.Observe(...): -want status updates, +got status updates: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.checkUpdated, !cr.Status.LastDriftCheck.Time.Equal(&checkTime)); diff != "" {
+				t.Fatalf("e.Observe(...): -want drift check updated, +got drift check updated: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_refreshPollInterval(t *testing.T) {
+	cases := map[string]struct {
+		refreshInterval *v1.Duration
+		want            time.Duration
+	}{
+		"NoRefreshInterval": {
+			want: time.Minute,
+		},
+		"ShorterRefreshInterval": {
+			refreshInterval: &v1.Duration{Duration: 15 * time.Second},
+			want:            15 * time.Second,
+		},
+		"LongerRefreshInterval": {
+			refreshInterval: &v1.Duration{Duration: time.Hour},
+			want:            time.Minute,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.RefreshInterval = tc.refreshInterval
+			})
+
+			got := refreshPollInterval(cr, time.Minute)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("refreshPollInterval(...): -want interval, +got interval: %s", diff)
+			}
+		})
+	}
+}
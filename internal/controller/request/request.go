@@ -18,10 +18,14 @@ package request
 
 import (
 	"context"
+	"net/http"
+	"net/url"
+	"slices"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,8 +43,10 @@ import (
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/observe"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestmapping"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestprocessing"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/statushandler"
 	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/features"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
@@ -56,25 +62,51 @@ const (
 	errPatchDataToSecret            = "Warning, couldn't patch data from request to secret %s:%s:%s, error: %s"
 	errGetLatestVersion             = "failed to get the latest version of the resource"
 	errExtractCredentials           = "cannot extract credentials"
+	errOAuth2Config                 = "cannot read the OAuth2 client credentials"
+	errRequestInterceptor           = "cannot read the request interceptor"
+	errCABundle                     = "cannot read the CA bundle"
+	errResponseTransform            = "failed to apply response transform"
+	errServerDryRunURL              = "failed to append the server dry-run parameter to the URL"
+	errServerDryRunStatus           = "failed to record the server dry-run validation"
+	errLateInitialize               = "failed to late-initialize the Request"
+	errRecreateCondition            = "failed to evaluate the recreate condition"
+	errRecreateRemove               = "the resource is not created again, its removal failed with status code %d"
+	errProviderPaused               = "provider is paused, the resource will be removed once it is resumed"
+	errProviderPausedCreate         = "provider is paused, the resource will be created once it is resumed"
+	errUnexpectedStatusCode         = "HTTP %s request returned status code %d, expected one of %v"
+	errMappingTimeout               = "the timeout %s of the %s mapping exceeds the reconcile timeout %s of the provider"
+	errPatchStrategyOwnedFields     = "the patchStrategy of the %s mapping can't be combined with ownedFields, remove one of them"
 )
 
 // Setup adds a controller that reconciles Request managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error {
+func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration, pause *utils.PauseSwitch) error {
 	name := managed.ControllerName(v1alpha2.RequestGroupKind)
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 
-	r := managed.NewReconciler(mgr,
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	// The status updates of the managed reconciler itself are tracked too.
+	statusUpdates := utils.NewStatusUpdateTracker(recorder, utils.DefaultStatusUpdateFailureThreshold, utils.DefaultStatusUpdateBackoff)
+
+	r := managed.NewReconciler(statusUpdates.Manager(mgr),
 		resource.ManagedKind(v1alpha2.RequestGroupVersionKind),
 		managed.WithExternalConnecter(&connector{
-			logger:          o.Logger,
-			kube:            mgr.GetClient(),
-			usage:           resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newHttpClientFn: httpClient.NewClient,
+			logger:                o.Logger,
+			kube:                  mgr.GetClient(),
+			usage:                 resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newHttpClientFn:       httpClient.NewClient,
+			statusUpdates:         statusUpdates,
+			pause:                 pause,
+			pollInterval:          o.PollInterval,
+			reconcileTimeout:      timeout,
+			outcomes:              utils.NewOutcomeTracker(v1alpha2.RequestKind, utils.DefaultOutcomeWindow),
+			tokens:                httpClient.NewOAuth2TokenCache(),
+			propagateTraceContext: o.Features.Enabled(features.EnableTraceContextPropagation),
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
+		WithPollIntervalHook(),
 		managed.WithTimeout(timeout),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -88,10 +120,17 @@ func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	logger          logging.Logger
-	kube            client.Client
-	usage           resource.Tracker
-	newHttpClientFn func(log logging.Logger, timeout time.Duration, creds string) (httpClient.Client, error)
+	logger                logging.Logger
+	kube                  client.Client
+	usage                 resource.Tracker
+	newHttpClientFn       func(log logging.Logger, timeout time.Duration, creds string, opts ...httpClient.ClientOption) (httpClient.Client, error)
+	statusUpdates         *utils.StatusUpdateTracker
+	pause                 *utils.PauseSwitch
+	pollInterval          time.Duration
+	reconcileTimeout      time.Duration
+	outcomes              *utils.OutcomeTracker
+	tokens                *httpClient.OAuth2TokenCache
+	propagateTraceContext bool
 }
 
 // Connect creates a new external client using the provider config.
@@ -103,12 +142,13 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	l := c.logger.WithValues("request", cr.Name)
 
-	if err := c.usage.Track(ctx, mg); err != nil {
+	pcRef := utils.ProviderConfigReference(cr)
+	if err := c.usage.Track(ctx, utils.WithProviderConfigReference(cr, pcRef)); err != nil {
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
 	pc := &apisv1alpha1.ProviderConfig{}
-	n := types.NamespacedName{Name: cr.GetProviderConfigReference().Name}
+	n := types.NamespacedName{Name: pcRef.Name}
 	if err := c.kube.Get(ctx, n, pc); err != nil {
 		return nil, errors.Wrap(err, errProviderNotRetrieved)
 	}
@@ -123,33 +163,181 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		creds = string(data)
 	}
 
-	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout), creds)
+	// Invalid configurations can't be fixed by retrying, they are reported with a ConfigError
+	// condition and the reconciles back off until the configuration is fixed.
+	ec, err := utils.NewEffectiveConfig(cr, v1alpha2.RequestKind, connectionParameters(cr, c.propagateTraceContext), pc)
+	if err != nil {
+		utils.SetConfigErrorCondition(cr, err)
+		return nil, err
+	}
+	if err := utils.ValidateMappingCount(mappingCount(cr)); err != nil {
+		utils.SetConfigErrorCondition(cr, err)
+		return nil, err
+	}
+	if err := requestgen.ValidateBodyFromPrevious(cr.Spec.ForProvider, l); err != nil {
+		utils.SetConfigErrorCondition(cr, err)
+		return nil, err
+	}
+	if err := validateMappingTimeouts(cr, c.reconcileTimeout); err != nil {
+		utils.SetConfigErrorCondition(cr, err)
+		return nil, err
+	}
+	if err := validatePatchStrategies(cr); err != nil {
+		utils.SetConfigErrorCondition(cr, err)
+		return nil, err
+	}
+
+	opts := ec.ClientOptions(c.kube)
+	if tlsConfig := ec.TLS; tlsConfig != nil && tlsConfig.CABundleSecretRef != nil {
+		bundle, err := utils.CABundle(ctx, c.kube, tlsConfig.CABundleSecretRef)
+		if err != nil {
+			err = errors.Wrap(err, errCABundle)
+			utils.SetConfigErrorCondition(cr, err)
+			return nil, err
+		}
+		opts = append(opts, httpClient.WithRootCAs(bundle))
+	}
+	if ec.OAuth2 != nil {
+		config, err := utils.OAuth2Config(ctx, c.kube, (*apisv1alpha1.OAuth2)(ec.OAuth2), pc)
+		if err != nil {
+			return nil, errors.Wrap(err, errOAuth2Config)
+		}
+		source, err := c.tokens.TokenSource(pc.GetName(), config)
+		if err != nil {
+			err = errors.Wrap(err, errOAuth2Config)
+			utils.SetConfigErrorCondition(cr, err)
+			return nil, err
+		}
+		opts = append(opts, httpClient.WithOAuth2(source))
+	}
+	if ref := ec.RequestInterceptorRef; ref != nil {
+		program, err := utils.RequestInterceptor(ctx, c.kube, ref)
+		if err != nil {
+			err = errors.Wrap(err, errRequestInterceptor)
+			utils.SetConfigErrorCondition(cr, err)
+			return nil, err
+		}
+		opts = append(opts, httpClient.WithRequestInterceptor(program))
+	}
+	l.Debug("Resolved wait timeout", "waitTimeout", ec.WaitTimeout)
+
+	h, err := c.newHttpClientFn(l, ec.WaitTimeoutDuration(), creds, opts...)
 	if err != nil {
-		return nil, errors.Wrap(err, errNewHttpClient)
+		err = errors.Wrap(err, errNewHttpClient)
+		utils.SetConfigErrorCondition(cr, err)
+		return nil, err
 	}
+	utils.SetConfigErrorCondition(cr, nil)
 
 	return &external{
-		localKube: c.kube,
-		logger:    l,
-		http:      h,
+		localKube:        c.statusUpdates.Client(c.kube),
+		logger:           l,
+		http:             h,
+		responseDefaults: pc.Spec.ResponseDefaults,
+		statusUpdates:    c.statusUpdates,
+		pause:            c.pause,
+		pollInterval:     c.pollInterval,
+		outcomes:         c.outcomes,
 	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	localKube client.Client
-	logger    logging.Logger
-	http      httpClient.Client
+	localKube        client.Client
+	logger           logging.Logger
+	http             httpClient.Client
+	responseDefaults *apisv1alpha1.ResponseDefaults
+	statusUpdates    *utils.StatusUpdateTracker
+	pause            *utils.PauseSwitch
+	pollInterval     time.Duration
+	outcomes         *utils.OutcomeTracker
+
+	// response is the last response received during the reconcile, before its injected values are masked.
+	response *httpClient.HttpResponse
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	observation, err := c.observe(ctx, mg)
+	// The condition is set once the resource is observed, since fetching its latest version drops it.
+	if cr, ok := mg.(*v1alpha2.Request); ok {
+		utils.SetUpstreamCircuitCondition(cr, cr.Spec.ForProvider.Payload.BaseUrl)
+	}
+	if err != nil || !observation.ResourceExists {
+		return observation, err
+	}
+
+	// The connection details are published at every reconcile, they replace the whole connection secret.
+	observation.ConnectionDetails = c.connectionDetails(ctx, mg.(*v1alpha2.Request))
+	return observation, nil
+}
+
+func (c *external) observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
 	cr, ok := mg.(*v1alpha2.Request)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotRequest)
 	}
 
-	observeRequestDetails, err := c.isUpToDate(ctx, cr)
+	paused, err := c.pause.Paused(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	utils.SetPausedCondition(cr, paused)
+	if paused {
+		// Report the resource as up to date so that no request is sent until the provider is resumed. A resource
+		// that was never created is reported as such, and its creation waits for the provider to be resumed.
+		return managed.ExternalObservation{
+			ResourceExists:   c.isObjectValidForObservation(cr),
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	if err := c.statusUpdates.BackOff(cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if err := utils.ResetUpstreamCircuit(ctx, c.localKube, cr, cr.Spec.ForProvider.Payload.BaseUrl); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// Once REMOVE was sent, the object exists until its deletion check shows it is gone.
+	if awaitsDeletion(cr) {
+		deleted, err := c.isDeleted(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		return managed.ExternalObservation{ResourceExists: !deleted}, nil
+	}
+
+	if hasItems(cr) {
+		return c.observeItems(ctx, cr)
+	}
+
+	// The terminal error is returned, rather than an observation, so that the Request isn't reported as synced
+	// nor as existing without an OBSERVE request proving it.
+	if terminalErrorRecorded(cr) {
+		c.logger.Debug("a terminal error was recorded for the current generation, skipping the OBSERVE request")
+		return managed.ExternalObservation{}, terminalError(cr)
+	}
+
+	if c.onlyRefreshSecrets(cr) {
+		c.refreshSecrets(ctx, cr)
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	observeRequestDetails, err := c.isUpToDate(ctx, applyResponseDefaults(cr, c.responseDefaults))
+	if stillCreating(cr, observeRequestDetails, err) {
+		c.logger.Debug("the object wasn't observed yet, it is still being created", "statusCode", observeRequestDetails.Details.HttpResponse.StatusCode)
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
 	if err != nil && err.Error() == observe.ErrObjectNotFound {
 		return managed.ExternalObservation{
 			ResourceExists: false,
@@ -171,9 +359,13 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	synced := observeRequestDetails.Synced
-	if synced {
+	if synced && !observeRequestDetails.Terminal {
 		statusHandler.ResetFailures()
 	}
+	recordDriftCheck(cr, synced)
+	if observeRequestDetails.Terminal {
+		recordTerminalError(cr, v1alpha2.ErrorCategoryTerminal)
+	}
 
 	cr.Status.SetConditions(xpv1.Available())
 	err = statusHandler.SetRequestStatus()
@@ -181,28 +373,145 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, " failed updating status")
 	}
 
+	recreate, err := shouldRecreate(cr, observeRequestDetails.Details)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errRecreateCondition)
+	}
+
+	if recreate && !canRecreate(cr) {
+		c.logger.Info("recreate condition is met, but the management policies don't allow removing and creating the resource")
+		recreate = false
+	}
+
+	if recreate {
+		c.logger.Info("recreate condition is met, removing the resource so that it is created again")
+		if err := c.deployAction(ctx, cr, v1alpha2.ActionRemove); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errFailedToSendHttpRequest)
+		}
+		// The failed responses don't always return an error, the object is only created again once
+		// its removal succeeded or found it absent.
+		if code := cr.Status.Response.StatusCode; !utils.IsHTTPSuccess(code) && !observe.ResourceAbsent(cr, code) {
+			return managed.ExternalObservation{}, errors.Errorf(errRecreateRemove, code)
+		}
+
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	lateInitialized, err := lateInitialize(cr, observeRequestDetails.Details.HttpResponse, c.logger)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errLateInitialize)
+	}
+
 	return managed.ExternalObservation{
-		ResourceExists:    true,
-		ResourceUpToDate:  synced,
-		ConnectionDetails: nil,
+		ResourceExists:          true,
+		ResourceUpToDate:        synced,
+		ResourceLateInitialized: lateInitialized,
 	}, nil
 }
 
 // deployAction executes the action based on the given Request resource and Mapping configuration.
 func (c *external) deployAction(ctx context.Context, cr *v1alpha2.Request, action string) error {
+	if hasItems(cr) {
+		return c.deployItems(ctx, cr, action)
+	}
+
 	mapping, err := requestmapping.GetMapping(&cr.Spec.ForProvider, action, c.logger)
 	if err != nil {
 		c.logger.Info(err.Error())
 		return nil
 	}
 
+	met, err := requestgen.MappingConditionMet(cr, mapping)
+	if err != nil {
+		return err
+	}
+	if !met {
+		c.logger.Debug("skipping the mapping, its condition is not met", "action", action, "method", mapping.Method)
+		return nil
+	}
+
+	if action == v1alpha2.ActionCreate && cr.Spec.ForProvider.CreateSafeguard != nil {
+		if found, err := c.adoptExisting(ctx, cr); found || err != nil {
+			return err
+		}
+	}
+
 	requestDetails, err := requestgen.GenerateValidRequestDetails(ctx, cr, mapping, c.localKube, c.logger)
 	if err != nil {
 		return err
 	}
 
-	details, err := c.http.SendRequest(ctx, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, cr.Spec.ForProvider.InsecureSkipTLSVerify)
-	datapatcher.ApplyResponseDataToSecrets(ctx, c.localKube, c.logger, &details.HttpResponse, cr.Spec.ForProvider.SecretInjectionConfigs, cr)
+	if action == v1alpha2.ActionCreate && cr.Spec.ForProvider.ServerDryRun != "" {
+		return c.validateCreate(ctx, cr, mapping, requestDetails)
+	}
+
+	details, responseErr := c.sendRequest(ctx, cr, mapping, requestDetails)
+	if responseErr == nil {
+		responseErr = checkStatusCode(mapping, details)
+	}
+
+	category, err := classifyResponse(cr, details, responseErr)
+	if err != nil {
+		return err
+	}
+	switch category {
+	case v1alpha2.ErrorCategoryNotFound:
+		if action == v1alpha2.ActionRemove {
+			return nil
+		}
+		return classifiedError(category, mapping, details)
+	case v1alpha2.ErrorCategoryConflict:
+		return classifiedError(category, mapping, details)
+	case v1alpha2.ErrorCategoryRetryable:
+		responseErr = classifiedError(category, mapping, details)
+	}
+
+	c.keepResponse(details.HttpResponse)
+	utils.SetSecretInjectionCondition(cr, datapatcher.ApplyResponseDataToSecrets(ctx, c.localKube, c.logger, &details.HttpResponse, cr.Spec.ForProvider.SecretInjectionConfigs, cr.Spec.ForProvider.SecretInjectionDiscriminator, cr.Spec.ForProvider.AtomicSecretInjection, cr.Spec.ForProvider.PreserveRawBody, cr))
+
+	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, details, responseErr, c.localKube, c.logger)
+	if err != nil {
+		return err
+	}
+
+	if action == v1alpha2.ActionUpdate {
+		recordSyncedUpdate(cr, details, responseErr)
+	}
+	recordTerminalError(cr, category)
+
+	err = statusHandler.SetRequestStatus()
+	if category == v1alpha2.ErrorCategoryTerminal {
+		return classifiedError(category, mapping, details)
+	}
+
+	return err
+}
+
+// validateCreate sends the CREATE request as a server-side dry run. A successful validation
+// is only recorded in status.serverDryRunValidated so the resource isn't considered created,
+// while a failed one is recorded in the status like any other failed request.
+func (c *external) validateCreate(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping, requestDetails requestgen.RequestDetails) error {
+	if validated := cr.Status.ServerDryRunValidated; validated != nil && validated.Generation == cr.Generation {
+		c.logger.Debug("server dry-run validation already succeeded for this generation", "generation", cr.Generation)
+		return nil
+	}
+
+	dryRunURL, err := appendQueryParam(requestDetails.Url, cr.Spec.ForProvider.ServerDryRun)
+	if err != nil {
+		return errors.Wrap(err, errServerDryRunURL)
+	}
+	requestDetails.Url = dryRunURL
+
+	details, err := c.sendRequest(ctx, cr, mapping, requestDetails)
+	if err == nil && utils.IsHTTPSuccess(details.HttpResponse.StatusCode) {
+		c.logger.Info("server dry-run validation succeeded, the resource was not created", "url", dryRunURL)
+		// Create can't rely on the managed reconciler to persist the status, it is reverted when
+		// the annotations are updated.
+		cr.Status.ServerDryRunValidated = &v1alpha2.DriftCheck{Time: metav1.Now(), Generation: cr.Generation}
+		return errors.Wrap(utils.UpdateStatus(ctx, c.localKube, cr), errServerDryRunStatus)
+	}
 
 	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, details, err, c.localKube, c.logger)
 	if err != nil {
@@ -212,13 +521,156 @@ func (c *external) deployAction(ctx context.Context, cr *v1alpha2.Request, actio
 	return statusHandler.SetRequestStatus()
 }
 
+// appendQueryParam appends the given query parameter, formatted as key=value, to the URL. The
+// existing query is kept as is, since re-encoding it could reorder or alter its parameters.
+func appendQueryParam(rawURL string, param string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := url.ParseQuery(param); err != nil {
+		return "", err
+	}
+
+	if parsed.RawQuery != "" {
+		param = parsed.RawQuery + "&" + param
+	}
+	parsed.RawQuery = param
+
+	return parsed.String(), nil
+}
+
+// validateMappingTimeouts returns an error if the timeout of a mapping exceeds the reconcile timeout, which
+// bounds every request of the reconcile and would otherwise cut the requests short of the mapping timeout.
+func validateMappingTimeouts(cr *v1alpha2.Request, reconcileTimeout time.Duration) error {
+	if reconcileTimeout <= 0 {
+		return nil
+	}
+
+	for _, mappings := range [][]v1alpha2.Mapping{cr.Spec.ForProvider.Mappings, cr.Spec.ForProvider.MappingTemplate} {
+		for _, mapping := range mappings {
+			if mapping.Timeout != nil && mapping.Timeout.Duration > reconcileTimeout {
+				return errors.Errorf(errMappingTimeout, mapping.Timeout.Duration, mappingName(mapping), reconcileTimeout)
+			}
+		}
+	}
+
+	return nil
+}
+
+// mappingName returns the action of the mapping, or its method when it can't be found by action.
+func mappingName(mapping v1alpha2.Mapping) string {
+	if action := requestmapping.ResolvedAction(mapping); action != "" {
+		return action
+	}
+	return mapping.Method
+}
+
+// validatePatchStrategies returns an error if a mapping sets a patchStrategy while the Request sets ownedFields,
+// since the drift would then only be compared with one of them.
+func validatePatchStrategies(cr *v1alpha2.Request) error {
+	if len(cr.Spec.ForProvider.OwnedFields) == 0 {
+		return nil
+	}
+
+	for _, mappings := range [][]v1alpha2.Mapping{cr.Spec.ForProvider.Mappings, cr.Spec.ForProvider.MappingTemplate} {
+		for _, mapping := range mappings {
+			if mapping.PatchStrategy != "" {
+				return errors.Errorf(errPatchStrategyOwnedFields, mappingName(mapping))
+			}
+		}
+	}
+
+	return nil
+}
+
+// sendRequest sends the HTTP request for the given mapping, with its own timeout if any, and applies the effective
+// response transform to the response.
+func (c *external) sendRequest(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping, requestDetails requestgen.RequestDetails) (httpClient.HttpDetails, error) {
+	var opts []httpClient.RequestOption
+	if mapping.Timeout != nil {
+		opts = append(opts, httpClient.WithRequestTimeout(mapping.Timeout.Duration))
+	}
+
+	details, err := c.http.SendRequest(ctx, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, cr.Spec.ForProvider.InsecureSkipTLSVerify, opts...)
+	c.outcomes.Record(cr, details.HttpResponse.StatusCode, err)
+	if err != nil {
+		return details, err
+	}
+
+	transform := responseTransform(cr, c.responseDefaults)
+	if transform == "" {
+		return details, nil
+	}
+
+	body, err := requestprocessing.ApplyJQOnBody(transform, details.HttpResponse.Body)
+	if err != nil {
+		return details, errors.Wrap(err, errResponseTransform)
+	}
+
+	details.HttpResponse.Body = body
+	return details, nil
+}
+
+// checkStatusCode returns an error when the mapping sets expected status codes and the response has another one.
+func checkStatusCode(mapping *v1alpha2.Mapping, details httpClient.HttpDetails) error {
+	if len(mapping.ExpectedStatusCodes) == 0 || slices.Contains(mapping.ExpectedStatusCodes, details.HttpResponse.StatusCode) {
+		return nil
+	}
+
+	return errors.Errorf(errUnexpectedStatusCode, mapping.Method, details.HttpResponse.StatusCode, mapping.ExpectedStatusCodes)
+}
+
+// responseTransform returns the response transform of the Request, falling back to the ProviderConfig default.
+func responseTransform(cr *v1alpha2.Request, defaults *apisv1alpha1.ResponseDefaults) string {
+	if cr.Spec.ForProvider.ResponseTransform != "" || defaults == nil {
+		return cr.Spec.ForProvider.ResponseTransform
+	}
+
+	return defaults.ResponseTransform
+}
+
+// applyResponseDefaults returns the Request with the ProviderConfig response defaults applied to the fields
+// it doesn't set. The given Request is never modified, a copy is returned when defaults apply.
+func applyResponseDefaults(cr *v1alpha2.Request, defaults *apisv1alpha1.ResponseDefaults) *v1alpha2.Request {
+	if defaults == nil || defaults.ExpectedResponseCheck == nil {
+		return cr
+	}
+
+	if cr.Spec.ForProvider.ExpectedResponseCheck != (v1alpha2.ExpectedResponseCheck{}) {
+		return cr
+	}
+
+	effective := cr.DeepCopy()
+	effective.Spec.ForProvider.ExpectedResponseCheck = v1alpha2.ExpectedResponseCheck{
+		Type:  defaults.ExpectedResponseCheck.Type,
+		Logic: defaults.ExpectedResponseCheck.Logic,
+	}
+	return effective
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1alpha2.Request)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotRequest)
 	}
 
-	return managed.ExternalCreation{}, errors.Wrap(c.deployAction(ctx, cr, v1alpha2.ActionCreate), errFailedToSendHttpRequest)
+	// Observe reports the paused resources that were never created as absent, their creation waits for the
+	// provider to be resumed.
+	paused, err := c.pause.Paused(ctx)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if paused {
+		return managed.ExternalCreation{}, errors.New(errProviderPausedCreate)
+	}
+
+	if err := c.deployAction(ctx, cr, v1alpha2.ActionCreate); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedToSendHttpRequest)
+	}
+
+	return managed.ExternalCreation{ConnectionDetails: c.connectionDetails(ctx, cr)}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -227,7 +679,11 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotRequest)
 	}
 
-	return managed.ExternalUpdate{}, errors.Wrap(c.deployAction(ctx, cr, v1alpha2.ActionUpdate), errFailedToSendHttpRequest)
+	if err := c.deployAction(ctx, cr, v1alpha2.ActionUpdate); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedToSendHttpRequest)
+	}
+
+	return managed.ExternalUpdate{ConnectionDetails: c.connectionDetails(ctx, cr)}, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -236,5 +692,50 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotRequest)
 	}
 
-	return errors.Wrap(c.deployAction(ctx, cr, v1alpha2.ActionRemove), errFailedToSendHttpRequest)
+	// Observe reports the paused resources that were created as existing, make sure their removal waits for the
+	// provider to be resumed.
+	paused, err := c.pause.Paused(ctx)
+	if err != nil {
+		return err
+	}
+	if paused {
+		return errors.New(errProviderPaused)
+	}
+
+	defer c.outcomes.Forget(cr)
+	defer c.statusUpdates.Forget(cr)
+
+	// The object is still being deleted, its deletion check is polled by Observe.
+	if awaitsDeletion(cr) {
+		return nil
+	}
+
+	if err := c.deployAction(ctx, cr, v1alpha2.ActionRemove); err != nil {
+		return errors.Wrap(err, errFailedToSendHttpRequest)
+	}
+
+	recordRemoveRequested(cr)
+	return nil
+}
+
+// connectionDetails returns the connection details extracted from the last response received during the
+// reconcile, or from the last response recorded in the status when no request was sent.
+func (c *external) connectionDetails(ctx context.Context, cr *v1alpha2.Request) managed.ConnectionDetails {
+	if c.response != nil {
+		return datapatcher.ConnectionDetails(c.logger, c.response, cr.Spec.ForProvider.ConnectionDetails, cr.Spec.ForProvider.PreserveRawBody)
+	}
+
+	response := cr.Status.Response
+	return datapatcher.StoredConnectionDetails(ctx, c.localKube, c.logger, httpClient.HttpResponse{
+		StatusCode: response.StatusCode,
+		Body:       response.Body,
+		Headers:    response.Headers,
+	}, cr.Spec.ForProvider.ConnectionDetails, cr.Spec.ForProvider.PreserveRawBody, cr.Spec.ForProvider.UnresolvedSecretPolicy)
+}
+
+// keepResponse keeps a copy of the response, before the injected values are masked in it, to extract the
+// connection details from.
+func (c *external) keepResponse(response httpClient.HttpResponse) {
+	response.Headers = http.Header(response.Headers).Clone()
+	c.response = &response
 }
@@ -2,15 +2,28 @@ package request
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/observe"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
@@ -78,7 +91,7 @@ type MockHttpClient struct {
 	MockSendRequest MockSendRequestFn
 }
 
-func (c *MockHttpClient) SendRequest(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+func (c *MockHttpClient) SendRequest(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool, opts ...httpClient.RequestOption) (resp httpClient.HttpDetails, err error) {
 	return c.MockSendRequest(ctx, method, url, body, headers, skipTLSVerify)
 }
 
@@ -176,6 +189,1081 @@ func Test_httpExternal_Create(t *testing.T) {
 	}
 }
 
+func Test_httpExternal_Create_ServerDryRun(t *testing.T) {
+	type args struct {
+		serverDryRun string
+		validated    *v1alpha2.DriftCheck
+		statusCode   int
+		err          error
+	}
+	type want struct {
+		url           string
+		statusUpdated bool
+		validated     bool
+		err           error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ValidationSucceeded": {
+			args: args{
+				serverDryRun: "dryRun=All",
+				statusCode:   200,
+			},
+			want: want{
+				url:           "https://api.example.com/users?dryRun=All",
+				statusUpdated: true,
+				validated:     true,
+			},
+		},
+		"ValidationNotResent": {
+			args: args{
+				serverDryRun: "dryRun=All",
+				validated:    &v1alpha2.DriftCheck{},
+				statusCode:   200,
+			},
+			want: want{
+				validated: true,
+			},
+		},
+		"ValidationResentForNewGeneration": {
+			args: args{
+				serverDryRun: "dryRun=All",
+				validated:    &v1alpha2.DriftCheck{Generation: -1},
+				statusCode:   200,
+			},
+			want: want{
+				url:           "https://api.example.com/users?dryRun=All",
+				statusUpdated: true,
+				validated:     true,
+			},
+		},
+		"ValidationFailed": {
+			args: args{
+				serverDryRun: "dryRun=All",
+				statusCode:   400,
+			},
+			want: want{
+				url:           "https://api.example.com/users?dryRun=All",
+				statusUpdated: true,
+			},
+		},
+		"RequestFailed": {
+			args: args{
+				serverDryRun: "dryRun=All",
+				err:          errBoom,
+			},
+			want: want{
+				url:           "https://api.example.com/users?dryRun=All",
+				statusUpdated: true,
+				err:           errors.Wrap(errBoom, errFailedToSendHttpRequest),
+			},
+		},
+		"DryRunDisabled": {
+			args: args{
+				statusCode: 200,
+			},
+			want: want{
+				url:           "https://api.example.com/users",
+				statusUpdated: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var gotURL string
+			statusUpdated := false
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockStatusUpdate: func(_ context.Context, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+						statusUpdated = true
+						return nil
+					},
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						gotURL = url
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: tc.args.statusCode},
+						}, tc.args.err
+					},
+				},
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.ServerDryRun = tc.args.serverDryRun
+				r.Status.ServerDryRunValidated = tc.args.validated
+			})
+			_, gotErr := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Create(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.url, gotURL); diff != "" {
+				t.Fatalf("e.Create(...): -want url, +got url: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.statusUpdated, statusUpdated); diff != "" {
+				t.Fatalf("e.Create(...): -want status updated, +got status updated: %s", diff)
+			}
+			validated := cr.Status.ServerDryRunValidated != nil && cr.Status.ServerDryRunValidated.Generation == cr.Generation
+			if diff := cmp.Diff(tc.want.validated, validated); diff != "" {
+				t.Fatalf("e.Create(...): -want validated, +got validated: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_appendQueryParam(t *testing.T) {
+	type args struct {
+		url   string
+		param string
+	}
+	type want struct {
+		url string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoQuery": {
+			args: args{
+				url:   "https://api.example.com/users",
+				param: "dryRun=All",
+			},
+			want: want{
+				url: "https://api.example.com/users?dryRun=All",
+			},
+		},
+		"ExistingQueryKeptAsIs": {
+			args: args{
+				url:   "https://api.example.com/users?sort=name&filter=a%2Cb&filter=c",
+				param: "dryRun=All",
+			},
+			want: want{
+				url: "https://api.example.com/users?sort=name&filter=a%2Cb&filter=c&dryRun=All",
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, err := appendQueryParam(tc.args.url, tc.args.param)
+			if err != nil {
+				t.Fatalf("appendQueryParam(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.url, got); diff != "" {
+				t.Fatalf("appendQueryParam(...): -want url, +got url: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Observe_LateInit(t *testing.T) {
+	type args struct {
+		payloadBody        string
+		lateInitFields     []v1alpha2.LateInitField
+		managementPolicies xpv1.ManagementPolicies
+	}
+	type want struct {
+		lateInitialized bool
+		payloadBody     string
+		err             error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"LateInitializesMissingField": {
+			args: args{
+				payloadBody:    `{"username":"john_doe"}`,
+				lateInitFields: []v1alpha2.LateInitField{{ResponseJQ: ".body.region", PayloadBodyKey: "region"}},
+			},
+			want: want{
+				lateInitialized: true,
+				payloadBody:     `{"username":"john_doe","region":"eu-west-1"}`,
+			},
+		},
+		"FormattingOfPayloadBodyKept": {
+			args: args{
+				payloadBody: "{\n  \"username\": \"john_doe\",\n  \"email\": \"john@example.com\"\n}",
+				lateInitFields: []v1alpha2.LateInitField{
+					{ResponseJQ: ".body.region", PayloadBodyKey: "region"},
+					{ResponseJQ: ".body.id", PayloadBodyKey: "id"},
+				},
+			},
+			want: want{
+				lateInitialized: true,
+				payloadBody:     "{\n  \"username\": \"john_doe\",\n  \"email\": \"john@example.com\",\n  \"region\": \"eu-west-1\",\n  \"id\": \"123\"\n}",
+			},
+		},
+		"EmptyPayloadBody": {
+			args: args{
+				lateInitFields: []v1alpha2.LateInitField{{ResponseJQ: ".body.region", PayloadBodyKey: "region"}},
+			},
+			want: want{
+				lateInitialized: true,
+				payloadBody:     `{"region":"eu-west-1"}`,
+			},
+		},
+		"LateInitializeNotAllowed": {
+			args: args{
+				payloadBody:        `{"username":"john_doe"}`,
+				lateInitFields:     []v1alpha2.LateInitField{{ResponseJQ: ".body.region", PayloadBodyKey: "region"}},
+				managementPolicies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionUpdate, xpv1.ManagementActionDelete},
+			},
+			want: want{
+				lateInitialized: false,
+				payloadBody:     `{"username":"john_doe"}`,
+			},
+		},
+		"ExistingFieldNotOverridden": {
+			args: args{
+				payloadBody:    `{"username":"john_doe","region":"us-east-1"}`,
+				lateInitFields: []v1alpha2.LateInitField{{ResponseJQ: ".body.region", PayloadBodyKey: "region"}},
+			},
+			want: want{
+				lateInitialized: false,
+				payloadBody:     `{"username":"john_doe","region":"us-east-1"}`,
+			},
+		},
+		"MissingResponseFieldSkipped": {
+			args: args{
+				payloadBody:    `{"username":"john_doe"}`,
+				lateInitFields: []v1alpha2.LateInitField{{ResponseJQ: ".body.zone", PayloadBodyKey: "zone"}},
+			},
+			want: want{
+				lateInitialized: false,
+				payloadBody:     `{"username":"john_doe"}`,
+			},
+		},
+		"NoLateInitFields": {
+			args: args{
+				payloadBody: `{"username":"john_doe"}`,
+			},
+			want: want{
+				lateInitialized: false,
+				payloadBody:     `{"username":"john_doe"}`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 200,
+								Body:       `{"id":"123","username":"john_doe","region":"eu-west-1"}`,
+							},
+						}, nil
+					},
+				},
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.Payload.Body = tc.args.payloadBody
+				r.Spec.ForProvider.LateInitFields = tc.args.lateInitFields
+				r.Spec.ManagementPolicies = tc.args.managementPolicies
+				r.Status.Response.StatusCode = 200
+				r.Status.Response.Body = `{"id":"123"}`
+			})
+			got, gotErr := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Observe(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.lateInitialized, got.ResourceLateInitialized); diff != "" {
+				t.Fatalf("e.Observe(...): -want late initialized, +got late initialized: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.payloadBody, cr.Spec.ForProvider.Payload.Body); diff != "" {
+				t.Fatalf("e.Observe(...): -want payload body, +got payload body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Observe_RecreateCondition(t *testing.T) {
+	type args struct {
+		recreateCondition  string
+		state              string
+		removeStatus       int
+		deletionPolicy     xpv1.DeletionPolicy
+		managementPolicies xpv1.ManagementPolicies
+	}
+	type want struct {
+		exists  bool
+		methods []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"FailedStateTriggersRecreate": {
+			args: args{
+				recreateCondition: `.response.body.state == "failed"`,
+				state:             "failed",
+			},
+			want: want{
+				exists:  false,
+				methods: []string{"GET", "DELETE"},
+			},
+		},
+		"AbsentAfterRemovalRecreated": {
+			args: args{
+				recreateCondition: `.response.body.state == "failed"`,
+				state:             "failed",
+				removeStatus:      404,
+			},
+			want: want{
+				exists:  false,
+				methods: []string{"GET", "DELETE"},
+			},
+		},
+		"FailedRemovalNotRecreated": {
+			args: args{
+				recreateCondition: `.response.body.state == "failed"`,
+				state:             "failed",
+				removeStatus:      503,
+			},
+			want: want{
+				methods: []string{"GET", "DELETE"},
+				err:     errors.Errorf(errRecreateRemove, 503),
+			},
+		},
+		"OrphanedNotRecreated": {
+			args: args{
+				recreateCondition: `.response.body.state == "failed"`,
+				state:             "failed",
+				deletionPolicy:    xpv1.DeletionOrphan,
+			},
+			want: want{
+				exists:  true,
+				methods: []string{"GET"},
+			},
+		},
+		"CreateNotAllowedNotRecreated": {
+			args: args{
+				recreateCondition:  `.response.body.state == "failed"`,
+				state:              "failed",
+				managementPolicies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionUpdate, xpv1.ManagementActionDelete},
+			},
+			want: want{
+				exists:  true,
+				methods: []string{"GET"},
+			},
+		},
+		"HealthyStateNotRecreated": {
+			args: args{
+				recreateCondition: `.response.body.state == "failed"`,
+				state:             "ready",
+			},
+			want: want{
+				exists:  true,
+				methods: []string{"GET"},
+			},
+		},
+		"NoRecreateCondition": {
+			args: args{
+				state: "failed",
+			},
+			want: want{
+				exists:  true,
+				methods: []string{"GET"},
+			},
+		},
+		"InvalidRecreateCondition": {
+			args: args{
+				recreateCondition: ".response.body.state",
+				state:             "failed",
+			},
+			want: want{
+				methods: []string{"GET"},
+				err:     errors.Wrap(errors.Errorf("failed to parse string: %s", "failed"), errRecreateCondition),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var methods []string
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						methods = append(methods, method)
+						statusCode := 200
+						if method == "DELETE" && tc.args.removeStatus != 0 {
+							statusCode = tc.args.removeStatus
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: statusCode,
+								Body:       `{"id":"123","state":"` + tc.args.state + `"}`,
+							},
+						}, nil
+					},
+				},
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.RecreateCondition = tc.args.recreateCondition
+				if tc.args.deletionPolicy != "" {
+					r.Spec.DeletionPolicy = tc.args.deletionPolicy
+				}
+				if tc.args.managementPolicies != nil {
+					r.Spec.ManagementPolicies = tc.args.managementPolicies
+				}
+				r.Status.Response.StatusCode = 200
+				r.Status.Response.Body = `{"id":"123"}`
+			})
+			got, gotErr := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Observe(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.exists, got.ResourceExists); diff != "" {
+				t.Fatalf("e.Observe(...): -want exists, +got exists: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.methods, methods); diff != "" {
+				t.Fatalf("e.Observe(...): -want methods, +got methods: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Observe_Paused(t *testing.T) {
+	type args struct {
+		paused     bool
+		notCreated bool
+	}
+	type want struct {
+		requestSent bool
+		exists      bool
+		condition   corev1.ConditionStatus
+		createErr   error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"PausedSkipsRequests": {
+			args: args{
+				paused: true,
+			},
+			want: want{
+				requestSent: false,
+				exists:      true,
+				condition:   corev1.ConditionTrue,
+				createErr:   errors.New(errProviderPausedCreate),
+			},
+		},
+		"PausedNeverCreatedNotExisting": {
+			args: args{
+				paused:     true,
+				notCreated: true,
+			},
+			want: want{
+				requestSent: false,
+				exists:      false,
+				condition:   corev1.ConditionTrue,
+				createErr:   errors.New(errProviderPausedCreate),
+			},
+		},
+		"NotPausedSendsRequests": {
+			args: args{
+				paused: false,
+			},
+			want: want{
+				requestSent: true,
+				exists:      true,
+				condition:   corev1.ConditionUnknown,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			requestSent := false
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					if cm, ok := obj.(*corev1.ConfigMap); ok {
+						cm.Data = map[string]string{utils.PausedKey: strconv.FormatBool(tc.args.paused)}
+					}
+					return nil
+				}),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			}
+			e := &external{
+				localKube: kube,
+				logger:    logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						requestSent = true
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: 200, Body: `{"id":"123"}`},
+						}, nil
+					},
+				},
+				pause: utils.NewPauseSwitch(kube, types.NamespacedName{Namespace: testNamespace, Name: "pause"}),
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				if !tc.args.notCreated {
+					r.Status.Response.StatusCode = 200
+					r.Status.Response.Body = `{"id":"123"}`
+				}
+			})
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.requestSent, requestSent); diff != "" {
+				t.Fatalf("e.Observe(...): -want request sent, +got request sent: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.exists, got.ResourceExists); diff != "" {
+				t.Fatalf("e.Observe(...): -want exists, +got exists: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, cr.GetCondition(utils.TypePaused).Status); diff != "" {
+				t.Fatalf("e.Observe(...): -want paused condition, +got paused condition: %s", diff)
+			}
+			if !tc.args.paused {
+				return
+			}
+
+			// The creation waits for the provider to be resumed.
+			requestSent = false
+			_, err = e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.createErr, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Create(...): -want error, +got error: %s", diff)
+			}
+			if requestSent {
+				t.Fatalf("e.Create(...): no request must be sent while paused")
+			}
+		})
+	}
+}
+
+// openCircuit opens the circuit breaker of the host of a server failing every request, and returns its URL.
+func openCircuit(t *testing.T) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := httpClient.NewClient(logging.NewNopLogger(), time.Minute, "")
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %s", err)
+	}
+	body := httpClient.Data{Encrypted: "", Decrypted: ""}
+	headers := httpClient.Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+	if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, body, headers, false); err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %s", err)
+	}
+
+	return server.URL
+}
+
+func Test_httpExternal_Observe_UpstreamCircuit(t *testing.T) {
+	type args struct {
+		annotations map[string]string
+		conditions  []xpv1.Condition
+	}
+	type want struct {
+		status      corev1.ConditionStatus
+		annotations map[string]string
+		updated     bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"OpenCircuitKeptAfterRefetch": {
+			args: args{},
+			want: want{
+				status: corev1.ConditionTrue,
+			},
+		},
+		"ResetAnnotationClosesCircuit": {
+			args: args{
+				annotations: map[string]string{utils.AnnotationKeyResetCircuitBreaker: "true"},
+				conditions:  []xpv1.Condition{utils.UpstreamCircuitOpen()},
+			},
+			want: want{
+				status:      corev1.ConditionFalse,
+				annotations: map[string]string{},
+				updated:     true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			httpClient.SetCircuitBreaker(1, time.Minute)
+			defer httpClient.SetCircuitBreaker(0, httpClient.DefaultCircuitOpenDuration)
+			url := openCircuit(t)
+
+			updated := false
+			e := &external{
+				localKube: &test.MockClient{
+					// Fetching the latest version drops the conditions set during the reconcile.
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						if r, ok := obj.(*v1alpha2.Request); ok {
+							r.Status.Conditions = tc.args.conditions
+						}
+						return nil
+					}),
+					MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+						updated = true
+						return nil
+					},
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{StatusCode: 200, Body: `{"id":"123"}`},
+						}, nil
+					},
+				},
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.Payload.BaseUrl = url
+				r.Status.Response.StatusCode = 200
+				r.Status.Response.Body = `{"id":"123"}`
+				r.SetAnnotations(tc.args.annotations)
+			})
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.status, cr.GetCondition(utils.TypeUpstreamCircuitOpen).Status); diff != "" {
+				t.Errorf("e.Observe(...): -want circuit condition, +got circuit condition: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.annotations, cr.GetAnnotations()); diff != "" {
+				t.Errorf("e.Observe(...): -want annotations, +got annotations: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("e.Observe(...): -want updated, +got updated: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_connector_Connect_ConfigError(t *testing.T) {
+	type args struct {
+		cr             *v1alpha2.Request
+		interceptorRef *common.ConfigMapKeyRef
+	}
+	type want struct {
+		err       error
+		condition corev1.ConditionStatus
+	}
+
+	negativeTimeout := &v1.Duration{Duration: -time.Second}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"InvalidWaitTimeout": {
+			args: args{
+				cr: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.WaitTimeout = negativeTimeout
+				}),
+			},
+			want: want{
+				err:       errors.Wrap(errors.Errorf("waitTimeout must not be negative, got %s", negativeTimeout.Duration), "invalid wait timeout"),
+				condition: corev1.ConditionTrue,
+			},
+		},
+		"InvalidClientOption": {
+			args: args{
+				cr: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.TLSRenegotiation = "always"
+				}),
+			},
+			want: want{
+				err:       errors.Wrap(errors.New("unknown TLS renegotiation setting always"), errNewHttpClient),
+				condition: corev1.ConditionTrue,
+			},
+		},
+		"UnknownRoutingProfile": {
+			args: args{
+				cr: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.RoutingProfile = "gateway"
+				}),
+			},
+			want: want{
+				err:       errors.New("routing profile gateway not found in ProviderConfig "),
+				condition: corev1.ConditionTrue,
+			},
+		},
+		"TooManyMappings": {
+			args: args{
+				cr: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings = make([]v1alpha2.Mapping, utils.DefaultMaxMappings+1)
+				}),
+			},
+			want: want{
+				err:       errors.Errorf("the Request has %d mappings, more than the %d allowed by the provider, reduce its mappings and mappingTemplate or raise --max-mappings", utils.DefaultMaxMappings+1, utils.DefaultMaxMappings),
+				condition: corev1.ConditionTrue,
+			},
+		},
+		"TooManyRenderedMappings": {
+			args: args{
+				cr: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings = nil
+					r.Spec.ForProvider.MappingTemplate = make([]v1alpha2.Mapping, 2)
+					r.Spec.ForProvider.ForEach = make([]string, utils.DefaultMaxMappings/2+1)
+				}),
+			},
+			want: want{
+				err:       errors.Errorf("the Request has %d mappings, more than the %d allowed by the provider, reduce its mappings and mappingTemplate or raise --max-mappings", utils.DefaultMaxMappings+2, utils.DefaultMaxMappings),
+				condition: corev1.ConditionTrue,
+			},
+		},
+		"BodyFromPreviousCycle": {
+			args: args{
+				cr: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+						{Method: "POST", URL: ".payload.baseUrl", BodyFromPrevious: v1alpha2.ActionUpdate},
+						{Method: "PUT", URL: ".payload.baseUrl", BodyFromPrevious: v1alpha2.ActionCreate},
+					}
+				}),
+			},
+			want: want{
+				err:       errors.Errorf("bodyFromPrevious forms a cycle through the %s mapping", v1alpha2.ActionCreate),
+				condition: corev1.ConditionTrue,
+			},
+		},
+		"MappingTimeoutExceedsReconcileTimeout": {
+			args: args{
+				cr: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+						{Method: "POST", URL: ".payload.baseUrl", Timeout: &v1.Duration{Duration: 15 * time.Minute}},
+					}
+				}),
+			},
+			want: want{
+				err:       errors.Errorf(errMappingTimeout, 15*time.Minute, v1alpha2.ActionCreate, 10*time.Minute),
+				condition: corev1.ConditionTrue,
+			},
+		},
+		"PatchStrategyWithOwnedFields": {
+			args: args{
+				cr: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.OwnedFields = []string{".name"}
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+						{Method: "PATCH", URL: ".payload.baseUrl", PatchStrategy: v1alpha2.PatchStrategyJSONMerge},
+					}
+				}),
+			},
+			want: want{
+				err:       errors.Errorf(errPatchStrategyOwnedFields, "PATCH"),
+				condition: corev1.ConditionTrue,
+			},
+		},
+		"RequestInterceptorKeyMissing": {
+			args: args{
+				cr:             httpRequest(),
+				interceptorRef: &common.ConfigMapKeyRef{Name: "interceptors", Namespace: "default", Key: "sign.jq"},
+			},
+			want: want{
+				err:       errors.Wrap(errors.New("key sign.jq not found in ConfigMap default:interceptors"), errRequestInterceptor),
+				condition: corev1.ConditionTrue,
+			},
+		},
+		"FixedConfigClearsCondition": {
+			args: args{
+				cr: httpRequest(func(r *v1alpha2.Request) {
+					r.SetConditions(utils.ConfigError(errBoom))
+				}),
+			},
+			want: want{
+				condition: corev1.ConditionFalse,
+			},
+		},
+		"ValidConfig": {
+			args: args{
+				cr: httpRequest(),
+			},
+			want: want{
+				condition: corev1.ConditionUnknown,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			c := &connector{
+				logger: logging.NewNopLogger(),
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					if pc, ok := obj.(*apisv1alpha1.ProviderConfig); ok {
+						pc.Spec.RequestInterceptorRef = tc.args.interceptorRef
+					}
+					return nil
+				})},
+				usage:            resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil }),
+				newHttpClientFn:  httpClient.NewClient,
+				reconcileTimeout: 10 * time.Minute,
+			}
+
+			_, err := c.Connect(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("c.Connect(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, tc.args.cr.GetCondition(utils.TypeConfigError).Status); diff != "" {
+				t.Fatalf("c.Connect(...): -want config error condition, +got config error condition: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_connector_Connect_ProviderConfigAnnotation(t *testing.T) {
+	type args struct {
+		annotations map[string]string
+	}
+	type want struct {
+		providerConfig string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"AnnotationSelectsProviderConfig": {
+			args: args{
+				annotations: map[string]string{utils.AnnotationKeyProviderConfig: "tenant-a"},
+			},
+			want: want{
+				providerConfig: "tenant-a",
+			},
+		},
+		"DefaultProviderConfig": {
+			args: args{},
+			want: want{
+				providerConfig: utils.DefaultProviderConfigName,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			var got, tracked string
+			c := &connector{
+				logger: logging.NewNopLogger(),
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, _ client.Object) error {
+						got = key.Name
+						return nil
+					},
+				},
+				usage: resource.TrackerFn(func(_ context.Context, mg resource.Managed) error {
+					tracked = mg.GetProviderConfigReference().Name
+					return nil
+				}),
+				newHttpClientFn: httpClient.NewClient,
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.SetAnnotations(tc.args.annotations)
+				r.Spec.ProviderConfigReference = &xpv1.Reference{Name: utils.DefaultProviderConfigName}
+			})
+			if _, err := c.Connect(context.Background(), cr); err != nil {
+				t.Fatalf("c.Connect(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.providerConfig, got); diff != "" {
+				t.Fatalf("c.Connect(...): -want provider config, +got provider config: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.providerConfig, tracked); diff != "" {
+				t.Fatalf("c.Connect(...): -want tracked provider config, +got tracked provider config: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_ExpectedStatusCodes(t *testing.T) {
+	withExpectedStatusCodes := func(r *v1alpha2.Request) {
+		post, get, del := testPostMapping, testGetMapping, testDeleteMapping
+		post.ExpectedStatusCodes = []int{201}
+		get.ExpectedStatusCodes = []int{200}
+		del.ExpectedStatusCodes = []int{204}
+		r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{post, get, del}
+		r.Status.Response.StatusCode = 201
+		r.Status.Response.Body = `{"id":"123"}`
+	}
+
+	type args struct {
+		action     string
+		statusCode int
+	}
+	type want struct {
+		err         error
+		responseErr error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"CreateExpectedStatusCode": {
+			args: args{action: v1alpha2.ActionCreate, statusCode: 201},
+			want: want{},
+		},
+		"CreateUnexpectedStatusCode": {
+			args: args{action: v1alpha2.ActionCreate, statusCode: 200},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errUnexpectedStatusCode, "POST", 200, []int{201}), errFailedToSendHttpRequest),
+			},
+		},
+		"ObserveExpectedStatusCode": {
+			args: args{action: v1alpha2.ActionObserve, statusCode: 200},
+			want: want{},
+		},
+		"ObserveUnexpectedStatusCode": {
+			args: args{action: v1alpha2.ActionObserve, statusCode: 202},
+			want: want{
+				responseErr: errors.Errorf(errUnexpectedStatusCode, "GET", 202, []int{200}),
+			},
+		},
+		"ObserveNotFoundIsRemoved": {
+			args: args{action: v1alpha2.ActionObserve, statusCode: 404},
+			want: want{
+				err: errors.New(observe.ErrObjectNotFound),
+			},
+		},
+		"RemoveExpectedStatusCode": {
+			args: args{action: v1alpha2.ActionRemove, statusCode: 204},
+			want: want{},
+		},
+		"RemoveUnexpectedStatusCode": {
+			args: args{action: v1alpha2.ActionRemove, statusCode: 200},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errUnexpectedStatusCode, "DELETE", 200, []int{204}), errFailedToSendHttpRequest),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpRequest:  httpClient.HttpRequest{Method: method, URL: url},
+							HttpResponse: httpClient.HttpResponse{StatusCode: tc.args.statusCode, Body: `{"id":"123"}`},
+						}, nil
+					},
+				},
+			}
+
+			cr := httpRequest(withExpectedStatusCodes)
+			var err, responseErr error
+			switch tc.args.action {
+			case v1alpha2.ActionCreate:
+				_, err = e.Create(context.Background(), cr)
+			case v1alpha2.ActionObserve:
+				var observed ObserveRequestDetails
+				observed, err = e.isUpToDate(context.Background(), cr)
+				responseErr = observed.ResponseError
+				if responseErr != nil && observed.Synced {
+					t.Fatalf("e.isUpToDate(...): an unexpected status code must not be synced")
+				}
+			case v1alpha2.ActionRemove:
+				err = e.Delete(context.Background(), cr)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("%s: -want error, +got error: %s", tc.args.action, diff)
+			}
+			if diff := cmp.Diff(tc.want.responseErr, responseErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("%s: -want response error, +got response error: %s", tc.args.action, diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_UnexpectedCreateStatusCodeNotCreatedAgain(t *testing.T) {
+	posts := 0
+	e := &external{
+		localKube: &test.MockClient{
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			MockGet:          test.NewMockGetFn(nil),
+		},
+		logger: logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+				if method == http.MethodPost {
+					posts++
+				}
+				return httpClient.HttpDetails{
+					HttpRequest:  httpClient.HttpRequest{Method: method, URL: url},
+					HttpResponse: httpClient.HttpResponse{StatusCode: 200, Body: `{"id":"123"}`},
+				}, nil
+			},
+		},
+	}
+
+	cr := httpRequest(func(r *v1alpha2.Request) {
+		post := testPostMapping
+		post.ExpectedStatusCodes = []int{201}
+		r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{post, testGetMapping, testPutMapping, testDeleteMapping}
+	})
+
+	// The CREATE request answered with an unexpected 2xx fails, but the object it created is then observed.
+	for i := 0; i < 2; i++ {
+		observation, err := e.Observe(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("e.Observe(...): unexpected error: %s", err)
+		}
+		if observation.ResourceExists {
+			continue
+		}
+		if _, err := e.Create(context.Background(), cr); err == nil {
+			t.Fatalf("e.Create(...): want an unexpected status code error")
+		}
+	}
+
+	if diff := cmp.Diff(1, posts); diff != "" {
+		t.Errorf("e.Create(...): -want POST requests, +got POST requests: %s", diff)
+	}
+}
+
 func Test_httpExternal_Update(t *testing.T) {
 	type args struct {
 		http      httpClient.Client
@@ -243,69 +1331,595 @@ func Test_httpExternal_Update(t *testing.T) {
 				logger:    logging.NewNopLogger(),
 				http:      tc.args.http,
 			}
-			_, gotErr := e.Update(context.Background(), tc.args.mg)
+			_, gotErr := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Update(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
+func withUpdateCondition(condition string) httpRequestModifier {
+	return func(r *v1alpha2.Request) {
+		putMapping := testPutMapping
+		putMapping.Condition = condition
+		r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, testGetMapping, putMapping, testDeleteMapping}
+		r.Status.Response = v1alpha2.Response{StatusCode: 200, Body: `{"id":"123","state":"terminated"}`}
+	}
+}
+
+func Test_httpExternal_Update_MappingCondition(t *testing.T) {
+	type args struct {
+		condition string
+	}
+	type want struct {
+		requestSent bool
+		err         error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ConditionMetSendsRequest": {
+			args: args{
+				condition: `.response.body.state == "terminated"`,
+			},
+			want: want{
+				requestSent: true,
+			},
+		},
+		"ConditionNotMetSkipsMapping": {
+			args: args{
+				condition: `.response.body.state != "terminated"`,
+			},
+			want: want{
+				requestSent: false,
+			},
+		},
+		"MalformedCondition": {
+			args: args{
+				condition: `.response.body.state ==`,
+			},
+			want: want{
+				requestSent: false,
+				err:         errors.Wrap(errors.Wrapf(errors.New("unexpected EOF"), "cannot evaluate the condition of the %s mapping", "PUT"), errFailedToSendHttpRequest),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			requestSent := false
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockCreate:       test.NewMockCreateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						requestSent = true
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: 200}}, nil
+					},
+				},
+			}
+			_, gotErr := e.Update(context.Background(), httpRequest(withUpdateCondition(tc.args.condition)))
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Update(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.requestSent, requestSent); diff != "" {
+				t.Errorf("e.Update(...): -want request sent, +got request sent: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Delete(t *testing.T) {
+	type args struct {
+		http      httpClient.Client
+		localKube client.Client
+		mg        resource.Managed
+	}
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotRequestResource": {
+			args: args{
+				mg: notHttpRequest{},
+			},
+			want: want{
+				err: errors.New(errNotRequest),
+			},
+		},
+		"RequestFailed": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errFailedToSendHttpRequest),
+			},
+		},
+		"Success": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockCreate:       test.NewMockCreateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: tc.args.localKube,
+				logger:    logging.NewNopLogger(),
+				http:      tc.args.http,
+			}
+			gotErr := e.Delete(context.Background(), tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
-				t.Fatalf("e.Update(...): -want error, +got error: %s", diff)
+				t.Fatalf("e.Delete(...): -want error, +got error: %s", diff)
 			}
 		})
 	}
 }
 
-func Test_httpExternal_Delete(t *testing.T) {
+func Test_applyResponseDefaults(t *testing.T) {
 	type args struct {
-		http      httpClient.Client
-		localKube client.Client
-		mg        resource.Managed
+		cr       *v1alpha2.Request
+		defaults *apisv1alpha1.ResponseDefaults
 	}
 	type want struct {
-		err error
+		check v1alpha2.ExpectedResponseCheck
 	}
 
 	cases := map[string]struct {
 		args args
 		want want
 	}{
-		"NotRequestResource": {
+		"NoDefaults": {
 			args: args{
-				mg: notHttpRequest{},
+				cr: httpRequest(),
 			},
 			want: want{
-				err: errors.New(errNotRequest),
+				check: v1alpha2.ExpectedResponseCheck{},
 			},
 		},
-		"RequestFailed": {
+		"ProviderDefaultApplied": {
 			args: args{
-				http: &MockHttpClient{
-					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
-						return httpClient.HttpDetails{}, errBoom
+				cr: httpRequest(),
+				defaults: &apisv1alpha1.ResponseDefaults{
+					ExpectedResponseCheck: &apisv1alpha1.ExpectedResponseCheck{
+						Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+						Logic: ".response.statusCode == 200",
 					},
 				},
-				localKube: &test.MockClient{
-					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
-					MockGet:          test.NewMockGetFn(nil),
+			},
+			want: want{
+				check: v1alpha2.ExpectedResponseCheck{
+					Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+					Logic: ".response.statusCode == 200",
+				},
+			},
+		},
+		"ResourceOverrideWins": {
+			args: args{
+				cr: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ExpectedResponseCheck = v1alpha2.ExpectedResponseCheck{
+						Type: v1alpha2.ExpectedResponseCheckTypeDefault,
+					}
+				}),
+				defaults: &apisv1alpha1.ResponseDefaults{
+					ExpectedResponseCheck: &apisv1alpha1.ExpectedResponseCheck{
+						Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+						Logic: ".response.statusCode == 200",
+					},
 				},
-				mg: httpRequest(),
 			},
 			want: want{
-				err: errors.Wrap(errBoom, errFailedToSendHttpRequest),
+				check: v1alpha2.ExpectedResponseCheck{
+					Type: v1alpha2.ExpectedResponseCheckTypeDefault,
+				},
 			},
 		},
-		"Success": {
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			original := tc.args.cr.DeepCopy()
+			got := applyResponseDefaults(tc.args.cr, tc.args.defaults)
+			if diff := cmp.Diff(tc.want.check, got.Spec.ForProvider.ExpectedResponseCheck); diff != "" {
+				t.Fatalf("applyResponseDefaults(...): -want check, +got check: %s", diff)
+			}
+			if diff := cmp.Diff(original, tc.args.cr); diff != "" {
+				t.Fatalf("applyResponseDefaults(...): should not modify the given Request: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_sendRequest(t *testing.T) {
+	type args struct {
+		cr       *v1alpha2.Request
+		defaults *apisv1alpha1.ResponseDefaults
+	}
+	type want struct {
+		body string
+		err  error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoTransform": {
 			args: args{
+				cr: httpRequest(),
+			},
+			want: want{
+				body: `{"id":"123","name":"john_doe"}`,
+			},
+		},
+		"ProviderDefaultTransformApplied": {
+			args: args{
+				cr: httpRequest(),
+				defaults: &apisv1alpha1.ResponseDefaults{
+					ResponseTransform: "{ id: .id }",
+				},
+			},
+			want: want{
+				body: `{"id":"123"}`,
+			},
+		},
+		"ResourceTransformWins": {
+			args: args{
+				cr: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ResponseTransform = "{ name: .name }"
+				}),
+				defaults: &apisv1alpha1.ResponseDefaults{
+					ResponseTransform: "{ id: .id }",
+				},
+			},
+			want: want{
+				body: `{"name":"john_doe"}`,
+			},
+		},
+		"InvalidTransform": {
+			args: args{
+				cr: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ResponseTransform = "{ id: .id"
+				}),
+			},
+			want: want{
+				body: `{"id":"123","name":"john_doe"}`,
+				err:  errors.New(errResponseTransform),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger: logging.NewNopLogger(),
 				http: &MockHttpClient{
-					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
-						return httpClient.HttpDetails{}, nil
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 200,
+								Body:       `{"id":"123","name":"john_doe"}`,
+							},
+						}, nil
 					},
 				},
+				responseDefaults: tc.args.defaults,
+			}
+
+			got, gotErr := e.sendRequest(context.Background(), tc.args.cr, &testGetMapping, requestgen.RequestDetails{})
+			if tc.want.err != nil {
+				if gotErr == nil || !strings.Contains(gotErr.Error(), tc.want.err.Error()) {
+					t.Fatalf("sendRequest(...): want error containing %q, got %v", tc.want.err, gotErr)
+				}
+			} else if gotErr != nil {
+				t.Fatalf("sendRequest(...): unexpected error: %s", gotErr)
+			}
+			if diff := cmp.Diff(tc.want.body, got.HttpResponse.Body); diff != "" {
+				t.Fatalf("sendRequest(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_sendRequest_MappingTimeout(t *testing.T) {
+	type args struct {
+		waitTimeout time.Duration
+		timeout     *v1.Duration
+		path        string
+	}
+
+	cases := map[string]struct {
+		args    args
+		wantErr bool
+	}{
+		"SlowMappingTimesOut": {
+			args: args{
+				waitTimeout: 5 * time.Second,
+				timeout:     &v1.Duration{Duration: 50 * time.Millisecond},
+				path:        "/slow",
+			},
+			wantErr: true,
+		},
+		"FastMappingUnaffected": {
+			args: args{
+				waitTimeout: 5 * time.Second,
+				path:        "/fast",
+			},
+		},
+		"MappingTimeoutLongerThanWaitTimeout": {
+			args: args{
+				waitTimeout: 50 * time.Millisecond,
+				timeout:     &v1.Duration{Duration: 5 * time.Second},
+				path:        "/slow",
+			},
+		},
+		"ZeroTimeoutFallsBackToWaitTimeout": {
+			args: args{
+				waitTimeout: 50 * time.Millisecond,
+				timeout:     &v1.Duration{},
+				path:        "/slow",
+			},
+			wantErr: true,
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(300 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+	defer server.Close()
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			h, err := httpClient.NewClient(logging.NewNopLogger(), tc.args.waitTimeout, "")
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+			e := &external{logger: logging.NewNopLogger(), http: h}
+
+			mapping := testGetMapping
+			mapping.Timeout = tc.args.timeout
+			_, err = e.sendRequest(context.Background(), httpRequest(), &mapping, requestgen.RequestDetails{
+				Url:     server.URL + tc.args.path,
+				Body:    httpClient.Data{Encrypted: "", Decrypted: ""},
+				Headers: httpClient.Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}},
+			})
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Errorf("sendRequest(...): -want error, +got error: %s (error: %v)", diff, err)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_Observe_ExistsAndUpToDate(t *testing.T) {
+	isRemovedCheck := v1alpha2.ExpectedResponseCheck{
+		Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+		Logic: `.response.body.state == "deleted"`,
+	}
+	expectedResponseCheck := v1alpha2.ExpectedResponseCheck{
+		Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+		Logic: `.response.body.username == .payload.body.username`,
+	}
+
+	type want struct {
+		exists   bool
+		upToDate bool
+	}
+
+	cases := map[string]struct {
+		responseBody string
+		want         want
+	}{
+		"ExistsButNotUpToDate": {
+			responseBody: `{"id":"123","state":"active","username":"jane_doe"}`,
+			want: want{
+				exists:   true,
+				upToDate: false,
+			},
+		},
+		"NotExists": {
+			responseBody: `{"id":"123","state":"deleted","username":"john_doe"}`,
+			want: want{
+				exists: false,
+			},
+		},
+		"ExistsAndUpToDate": {
+			responseBody: `{"id":"123","state":"active","username":"john_doe"}`,
+			want: want{
+				exists:   true,
+				upToDate: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
 				localKube: &test.MockClient{
-					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
-					MockCreate:       test.NewMockCreateFn(nil),
 					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
 				},
-				mg: httpRequest(),
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 200,
+								Body:       tc.responseBody,
+							},
+						}, nil
+					},
+				},
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.IsRemovedCheck = isRemovedCheck
+				r.Spec.ForProvider.ExpectedResponseCheck = expectedResponseCheck
+				r.Status.Response.StatusCode = 200
+				r.Status.Response.Body = `{"id":"123"}`
+			})
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, want{exists: got.ResourceExists, upToDate: got.ResourceUpToDate}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("e.Observe(...): -want observation, +got observation: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_ConnectionDetails(t *testing.T) {
+	want := managed.ConnectionDetails{
+		"endpoint": []byte("db.example.com"),
+		"token":    []byte("s3cr3t"),
+	}
+
+	// The token is both injected in a secret, which masks it in the status, and published as a connection detail.
+	secretData := map[string][]byte{}
+	localKube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			if secret, ok := obj.(*corev1.Secret); ok {
+				secret.Name, secret.Namespace, secret.Data = key.Name, key.Namespace, secretData
+			}
+			return nil
+		},
+		MockUpdate:       test.NewMockUpdateFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	e := &external{
+		localKube: localKube,
+		logger:    logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+				return httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"id":"123","endpoint":"db.example.com","token":"s3cr3t"}`,
+					},
+				}, nil
+			},
+		},
+	}
+
+	cr := httpRequest(func(r *v1alpha2.Request) {
+		r.Spec.ForProvider.ConnectionDetails = []common.ConnectionDetail{
+			{Key: "endpoint", ResponseJQ: ".body.endpoint"},
+			{Key: "token", ResponseJQ: ".body.token"},
+		}
+		r.Spec.ForProvider.SecretInjectionConfigs = []common.SecretInjectionConfig{{
+			SecretRef:   common.SecretRef{Name: "creds", Namespace: testNamespace},
+			KeyMappings: []common.KeyInjection{{SecretKey: "token", ResponseJQ: ".body.token"}},
+		}}
+	})
+	created, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Create(...): unexpected error: %s", err)
+	}
+	if !strings.Contains(cr.Status.Response.Body, "{{creds:"+testNamespace+":token}}") {
+		t.Fatalf("e.Create(...): want the token masked in the status, got %s", cr.Status.Response.Body)
+	}
+	if diff := cmp.Diff(want, created.ConnectionDetails); diff != "" {
+		t.Errorf("e.Create(...): -want connection details, +got connection details: %s", diff)
+	}
+
+	// Without a response received during the reconcile, the masked values of the status are resolved.
+	secretData["token"] = []byte("s3cr3t")
+	stored := &external{localKube: localKube, logger: logging.NewNopLogger()}
+	if diff := cmp.Diff(want, stored.connectionDetails(context.Background(), cr)); diff != "" {
+		t.Errorf("connectionDetails(...): -want connection details, +got connection details: %s", diff)
+	}
+}
+
+func Test_httpExternal_Observe_AtProvider(t *testing.T) {
+	type args struct {
+		mirrorAtProvider bool
+	}
+	type want struct {
+		atProvider *v1alpha2.RequestObservation
+	}
+
+	var gotAuthorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthorization = r.Header.Get("Authorization")
+		if r.Method != http.MethodGet || r.URL.Path != "/users/123" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-1")
+		_, _ = w.Write([]byte(`{"id":"123","username":"john_doe","email":"john.doe@example.com"}`))
+	}))
+	defer server.Close()
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ObservedExchangeMirrored": {
+			args: args{
+				mirrorAtProvider: true,
 			},
 			want: want{
-				err: nil,
+				atProvider: &v1alpha2.RequestObservation{
+					Request: v1alpha2.Mapping{
+						Method:  http.MethodGet,
+						URL:     server.URL + "/users/123",
+						Headers: map[string][]string{"Authorization": {httpClient.RedactedHeaderValue}},
+					},
+					Response: v1alpha2.Response{
+						StatusCode: http.StatusOK,
+						Body:       `{"id":"123","username":"john_doe","email":"john.doe@example.com"}`,
+						Headers:    map[string][]string{"X-Request-Id": {"req-1"}},
+					},
+				},
+			},
+		},
+		"NotMirrored": {
+			args: args{
+				mirrorAtProvider: false,
 			},
 		},
 	}
@@ -313,14 +1927,56 @@ func Test_httpExternal_Delete(t *testing.T) {
 		tc := tc // Create local copies of loop variables
 
 		t.Run(name, func(t *testing.T) {
+			h, err := httpClient.NewClient(logging.NewNopLogger(), 5*time.Second, "")
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
 			e := &external{
-				localKube: tc.args.localKube,
-				logger:    logging.NewNopLogger(),
-				http:      tc.args.http,
+				localKube: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						if secret, ok := obj.(*corev1.Secret); ok {
+							secret.Data = map[string][]byte{"token": []byte("s3cr3t")}
+						}
+						return nil
+					},
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http:   h,
 			}
-			gotErr := e.Delete(context.Background(), tc.args.mg)
-			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
-				t.Fatalf("e.Delete(...): -want error, +got error: %s", diff)
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.Payload.BaseUrl = server.URL + "/users"
+				r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+					testPostMapping,
+					{
+						Method:  http.MethodGet,
+						URL:     testGetMapping.URL,
+						Headers: map[string][]string{"Authorization": {"Bearer {{auth:default:token}}"}},
+					},
+				}
+				r.Spec.ForProvider.MirrorAtProvider = tc.args.mirrorAtProvider
+				r.Status.Response.StatusCode = http.StatusCreated
+				r.Status.Response.Body = `{"id":"123"}`
+				r.Status.RequestDetails.Method = http.MethodPost
+			})
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if !got.ResourceExists || !got.ResourceUpToDate {
+				t.Fatalf("e.Observe(...): want an existing up to date resource, got: %+v", got)
+			}
+			if gotAuthorization != "Bearer s3cr3t" {
+				t.Fatalf("e.Observe(...): want the secret sent to the server, got Authorization: %q", gotAuthorization)
+			}
+
+			// Only the headers set by the server are compared, the others, e.g. Date, vary.
+			if atProvider := cr.Status.AtProvider; atProvider != nil {
+				atProvider.Response.Headers = map[string][]string{"X-Request-Id": atProvider.Response.Headers["X-Request-Id"]}
+			}
+			if diff := cmp.Diff(tc.want.atProvider, cr.Status.AtProvider); diff != "" {
+				t.Errorf("e.Observe(...): -want Status.AtProvider, +got Status.AtProvider: %s", diff)
 			}
 		})
 	}
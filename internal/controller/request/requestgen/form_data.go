@@ -0,0 +1,139 @@
+package requestgen
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestprocessing"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errFormFieldValue = "cannot render the value of form field %s"
+	errFormFieldFile  = "cannot read the file of form field %s"
+	errWriteFormData  = "cannot write the multipart/form-data body"
+
+	contentTypeOctetStream = "application/octet-stream"
+)
+
+// quoteEscaper escapes the quoted parameters of the Content-Disposition headers, like mime/multipart does.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// formPart is a rendered field of a multipart/form-data body.
+type formPart struct {
+	header textproto.MIMEHeader
+	value  httpClient.Data
+}
+
+// generateFormData renders the form fields as a multipart/form-data body, and returns it with its content type.
+// The boundary is derived from the fields shown in the status, so that the body of the same fields doesn't change
+// from one reconcile to the next.
+func generateFormData(ctx context.Context, localKube client.Client, fields []v1alpha2.FormField, jqObject map[string]interface{}, policy string, logger logging.Logger) (httpClient.Data, string, error) {
+	parts := make([]formPart, 0, len(fields))
+	hash := sha256.New()
+	for _, field := range fields {
+		part, err := renderFormField(ctx, localKube, field, jqObject, policy, logger)
+		if err != nil {
+			return httpClient.Data{}, "", err
+		}
+		parts = append(parts, part)
+
+		fmt.Fprintf(hash, "%v\x00%s\x00", part.header, part.value.Encrypted)
+	}
+	boundary := hex.EncodeToString(hash.Sum(nil))[:32]
+
+	encrypted, err := writeFormData(parts, boundary, func(value httpClient.Data) string { return value.Encrypted.(string) })
+	if err != nil {
+		return httpClient.Data{}, "", err
+	}
+	decrypted, err := writeFormData(parts, boundary, func(value httpClient.Data) string { return value.Decrypted.(string) })
+	if err != nil {
+		return httpClient.Data{}, "", err
+	}
+
+	return httpClient.Data{Encrypted: encrypted, Decrypted: decrypted}, "multipart/form-data; boundary=" + boundary, nil
+}
+
+// renderFormField renders the value of a form field, or reads its file part from the referenced ConfigMap or Secret.
+func renderFormField(ctx context.Context, localKube client.Client, field v1alpha2.FormField, jqObject map[string]interface{}, policy string, logger logging.Logger) (formPart, error) {
+	header := textproto.MIMEHeader{}
+	if field.ValueFrom == nil {
+		value, err := requestprocessing.ApplyJQOnStr(utils.NormalizeWhitespace(field.Value), jqObject)
+		if err != nil {
+			return formPart{}, errors.Wrapf(err, errFormFieldValue, field.Name)
+		}
+		sensitiveValue, err := datapatcher.PatchSecretsIntoString(ctx, localKube, value, policy, logger)
+		if err != nil {
+			return formPart{}, errors.Wrapf(err, errFormFieldValue, field.Name)
+		}
+
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(field.Name)))
+		return formPart{header: header, value: httpClient.Data{Encrypted: value, Decrypted: sensitiveValue}}, nil
+	}
+
+	file, err := utils.BodyFrom(ctx, localKube, field.ValueFrom)
+	if err != nil {
+		return formPart{}, errors.Wrapf(err, errFormFieldFile, field.Name)
+	}
+
+	fileName := field.FileName
+	if fileName == "" {
+		fileName = formFileKey(field.ValueFrom)
+	}
+	contentType := field.ContentType
+	if contentType == "" {
+		contentType = contentTypeOctetStream
+	}
+
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(field.Name), quoteEscaper.Replace(fileName)))
+	header.Set("Content-Type", contentType)
+	return formPart{header: header, value: file}, nil
+}
+
+// formFileKey returns the key of the ConfigMap or Secret holding a file part.
+func formFileKey(ref *common.BodyFrom) string {
+	if ref.SecretKeyRef != nil {
+		return ref.SecretKeyRef.Key
+	}
+	return ref.ConfigMapKeyRef.Key
+}
+
+// writeFormData writes the parts as a multipart/form-data body with the given boundary, using the given
+// version of their values.
+func writeFormData(parts []formPart, boundary string, value func(httpClient.Data) string) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return "", errors.Wrap(err, errWriteFormData)
+	}
+
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(part.header)
+		if err != nil {
+			return "", errors.Wrap(err, errWriteFormData)
+		}
+		if _, err := partWriter.Write([]byte(value(part.value))); err != nil {
+			return "", errors.Wrap(err, errWriteFormData)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", errors.Wrap(err, errWriteFormData)
+	}
+
+	return body.String(), nil
+}
@@ -0,0 +1,181 @@
+package requestgen
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+// receivedPart is a part of a multipart/form-data body as parsed by the server.
+type receivedPart struct {
+	Name        string
+	FileName    string
+	ContentType string
+	Value       string
+}
+
+func Test_GenerateRequestDetails_FormData(t *testing.T) {
+	forProvider := v1alpha2.RequestParameters{
+		Payload: v1alpha2.Payload{
+			BaseUrl: "https://api.example.com/documents",
+			Body:    `{"description": "Q3 report, \"final\""}`,
+		},
+	}
+	mapping := v1alpha2.Mapping{
+		Method:       "POST",
+		URL:          ".payload.baseUrl",
+		Headers:      map[string][]string{"Content-Type": {"multipart/form-data"}, "X-Team": {"finance"}},
+		BodyEncoding: v1alpha2.BodyEncodingFormData,
+		FormFields: []v1alpha2.FormField{
+			{Name: "description", Value: ".payload.body.description"},
+			{Name: "token", Value: `"{{api-credentials:default:token}}"`},
+			{
+				Name:      "manifest",
+				ValueFrom: &common.BodyFrom{ConfigMapKeyRef: &common.ConfigMapKeyRef{Name: "documents", Namespace: "default", Key: "manifest.json"}},
+			},
+			{
+				Name:        "report",
+				FileName:    "report.pdf",
+				ContentType: "application/pdf",
+				ValueFrom: &common.BodyFrom{SecretKeyRef: &xpv1.SecretKeySelector{
+					SecretReference: xpv1.SecretReference{Name: "documents", Namespace: "default"},
+					Key:             "report",
+				}},
+			},
+		},
+	}
+
+	localKube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *corev1.ConfigMap:
+				o.Data = map[string]string{"manifest.json": `{"pages": 12}`}
+			case *corev1.Secret:
+				if key.Name == "api-credentials" {
+					o.Data = map[string][]byte{"token": []byte("s3cr3t")}
+				} else {
+					o.Data = map[string][]byte{"report": []byte("%PDF-1.7")}
+				}
+			}
+			return nil
+		},
+	}
+
+	details, err, _ := GenerateRequestDetails(context.Background(), localKube, mapping, forProvider, nil, v1alpha2.Response{}, logging.NewNopLogger())
+	if err != nil {
+		t.Fatalf("GenerateRequestDetails(...): unexpected error: %s", err)
+	}
+
+	// The server parses the multipart body it receives.
+	var received []receivedPart
+	var xTeam string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		xTeam = r.Header.Get("X-Team")
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Errorf("MultipartReader(): unexpected error: %s", err)
+			return
+		}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				t.Errorf("NextPart(): unexpected error: %s", err)
+				return
+			}
+			value, _ := io.ReadAll(part)
+			received = append(received, receivedPart{
+				Name:        part.FormName(),
+				FileName:    part.FileName(),
+				ContentType: part.Header.Get("Content-Type"),
+				Value:       string(value),
+			})
+		}
+	}))
+	defer server.Close()
+
+	c, err := httpClient.NewClient(logging.NewNopLogger(), 5*time.Second, "")
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %s", err)
+	}
+	if _, err := c.SendRequest(context.Background(), http.MethodPost, server.URL, details.Body, details.Headers, false); err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %s", err)
+	}
+
+	want := []receivedPart{
+		{Name: "description", Value: `Q3 report, "final"`},
+		{Name: "token", Value: "s3cr3t"},
+		{Name: "manifest", FileName: "manifest.json", ContentType: "application/octet-stream", Value: `{"pages": 12}`},
+		{Name: "report", FileName: "report.pdf", ContentType: "application/pdf", Value: "%PDF-1.7"},
+	}
+	if diff := cmp.Diff(want, received); diff != "" {
+		t.Errorf("SendRequest(...): -want parts, +got parts: %s", diff)
+	}
+	if diff := cmp.Diff("finance", xTeam); diff != "" {
+		t.Errorf("SendRequest(...): -want X-Team, +got X-Team: %s", diff)
+	}
+
+	// The body shown in the status keeps the secrets masked.
+	encrypted := details.Body.Encrypted.(string)
+	for _, masked := range []string{"{{api-credentials:default:token}}", "{{documents:default:report}}"} {
+		if !strings.Contains(encrypted, masked) {
+			t.Errorf("GenerateRequestDetails(...): the body shown in the status doesn't mask %s: %s", masked, encrypted)
+		}
+	}
+	if strings.Contains(encrypted, "s3cr3t") || strings.Contains(encrypted, "%PDF") {
+		t.Errorf("GenerateRequestDetails(...): the body shown in the status leaks a secret: %s", encrypted)
+	}
+
+	// The boundary doesn't change from one reconcile to the next.
+	again, err, _ := GenerateRequestDetails(context.Background(), localKube, mapping, forProvider, nil, v1alpha2.Response{}, logging.NewNopLogger())
+	if err != nil {
+		t.Fatalf("GenerateRequestDetails(...): unexpected error: %s", err)
+	}
+	contentType := details.Headers.Decrypted.(map[string][]string)["Content-Type"]
+	if diff := cmp.Diff(contentType, again.Headers.Decrypted.(map[string][]string)["Content-Type"]); diff != "" {
+		t.Errorf("GenerateRequestDetails(...): -first Content-Type, +second Content-Type: %s", diff)
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType[0]); err != nil || mediaType != "multipart/form-data" {
+		t.Errorf("GenerateRequestDetails(...): unexpected Content-Type %v", contentType)
+	}
+}
+
+func Test_GenerateRequestDetails_FormDataMissingFile(t *testing.T) {
+	mapping := v1alpha2.Mapping{
+		Method:       "POST",
+		URL:          `"https://api.example.com/documents"`,
+		BodyEncoding: v1alpha2.BodyEncodingFormData,
+		FormFields: []v1alpha2.FormField{
+			{
+				Name:      "manifest",
+				ValueFrom: &common.BodyFrom{ConfigMapKeyRef: &common.ConfigMapKeyRef{Name: "documents", Namespace: "default", Key: "manifest.json"}},
+			},
+		},
+	}
+	localKube := &test.MockClient{MockGet: test.NewMockGetFn(nil)}
+
+	_, err, _ := GenerateRequestDetails(context.Background(), localKube, mapping, v1alpha2.RequestParameters{}, nil, v1alpha2.Response{}, logging.NewNopLogger())
+	want := errors.Wrapf(errors.Errorf("key manifest.json not found in ConfigMap default:documents"), errFormFieldFile, "manifest")
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("GenerateRequestDetails(...): -want error, +got error: %s", diff)
+	}
+}
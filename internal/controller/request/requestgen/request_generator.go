@@ -1,24 +1,63 @@
 package requestgen
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5" //nolint:gosec // Content-MD5 is an integrity check, not a security control.
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"mime"
+	"net/textproto"
+	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/bodyformat"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestmapping"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestprocessing"
 	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
 	json_util "github.com/crossplane-contrib/provider-http/internal/json"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 
 	"golang.org/x/exp/maps"
 )
 
+const (
+	errBodyFromPreviousCycle = "bodyFromPrevious forms a cycle through the %s mapping"
+	errBodyNotObject         = "body of the %s mapping must be a JSON object to be combined with bodyFromPrevious"
+	errLayerNotObject        = "body layer %s must return a JSON object or null"
+	errFragmentNotObject     = "body fragment %s must return a JSON object or null"
+	errHeadersNotObject      = "headersTransform must return a JSON object, got %v"
+	errHeaderValueNotString  = "headersTransform must return a string or an array of strings for header %s, got %v"
+	errNDJSONBodyNotArray    = "ndjson body encoding requires the body to be a JSON array"
+	errURLEncodedNotObject   = "urlencoded body encoding requires the body to be a JSON object"
+	errChecksumAlgorithm     = "unsupported checksum algorithm %s for header %s"
+	errChecksumEncoding      = "unsupported checksum encoding %s for header %s"
+	errMappingCondition      = "cannot evaluate the condition of the %s mapping"
+)
+
+const (
+	headerContentType = "Content-Type"
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+// bodyEncodingContentTypes are the Content-Type headers set by default for the body encodings.
+var bodyEncodingContentTypes = map[string]string{
+	v1alpha2.BodyEncodingNDJSON:     contentTypeNDJSON,
+	v1alpha2.BodyEncodingURLEncoded: "application/x-www-form-urlencoded",
+}
+
 type RequestDetails struct {
 	Url     string
 	Body    httpClient.Data
@@ -26,8 +65,16 @@ type RequestDetails struct {
 }
 
 // GenerateRequestDetails generates request details.
-func GenerateRequestDetails(ctx context.Context, localKube client.Client, methodMapping v1alpha2.Mapping, forProvider v1alpha2.RequestParameters, response v1alpha2.Response, logger logging.Logger) (RequestDetails, error, bool) {
-	jqObject := GenerateRequestObject(forProvider, response)
+func GenerateRequestDetails(ctx context.Context, localKube client.Client, methodMapping v1alpha2.Mapping, forProvider v1alpha2.RequestParameters, meta metav1.Object, response v1alpha2.Response, logger logging.Logger) (RequestDetails, error, bool) {
+	jqObject, err := GenerateRequestObject(forProvider, meta, response)
+	if err != nil {
+		return RequestDetails{}, err, false
+	}
+
+	if err := addReferencedResources(ctx, localKube, forProvider.ResourceRefs, jqObject); err != nil {
+		return RequestDetails{}, err, false
+	}
+
 	url, err := generateURL(methodMapping.URL, jqObject)
 	if err != nil {
 		return RequestDetails{}, err, false
@@ -37,12 +84,31 @@ func GenerateRequestDetails(ctx context.Context, localKube client.Client, method
 		return RequestDetails{}, errors.Errorf(utils.ErrInvalidURL, url), false
 	}
 
-	bodyData, err := generateBody(ctx, localKube, methodMapping.Body, jqObject, logger)
+	var bodyData httpClient.Data
+	var formDataContentType string
+	if methodMapping.BodyEncoding == v1alpha2.BodyEncodingFormData {
+		bodyData, formDataContentType, err = generateFormData(ctx, localKube, methodMapping.FormFields, jqObject, forProvider.UnresolvedSecretPolicy, logger)
+	} else {
+		bodyData, err = generateBody(ctx, localKube, forProvider, methodMapping, jqObject, logger)
+	}
 	if err != nil {
 		return RequestDetails{}, err, false
 	}
 
-	headersData, err := generateHeaders(ctx, localKube, coalesceHeaders(methodMapping.Headers, forProvider.Headers), jqObject, logger)
+	headers := defaultContentType(coalesceHeaders(methodMapping.Headers, forProvider.Headers), methodMapping.BodyEncoding)
+	headersData, err := generateHeaders(ctx, localKube, headers, forProvider.HeadersTransform, jqObject, forProvider.UnresolvedSecretPolicy, logger)
+	if err != nil {
+		return RequestDetails{}, err, false
+	}
+
+	if formDataContentType != "" {
+		headersData = httpClient.Data{
+			Encrypted: withFormDataContentType(headersData.Encrypted.(map[string][]string), formDataContentType),
+			Decrypted: withFormDataContentType(headersData.Decrypted.(map[string][]string), formDataContentType),
+		}
+	}
+
+	headersData, err = addBodyChecksums(headersData, bodyData, methodMapping.BodyChecksums)
 	if err != nil {
 		return RequestDetails{}, err, false
 	}
@@ -51,17 +117,99 @@ func GenerateRequestDetails(ctx context.Context, localKube client.Client, method
 }
 
 // GenerateRequestObject creates a JSON-compatible map from the specified Request's ForProvider and Response fields.
-// It merges the two maps, converts JSON strings to nested maps, and returns the resulting map.
-func GenerateRequestObject(forProvider v1alpha2.RequestParameters, response v1alpha2.Response) map[string]interface{} {
+// It merges the two maps, converts JSON strings to nested maps, and returns the resulting map. When meta is given,
+// the Request's name, labels and annotations are exposed under the meta key. When preserveRawBody is set, the
+// response body is also exposed verbatim under response.rawBody.
+func GenerateRequestObject(forProvider v1alpha2.RequestParameters, meta metav1.Object, response v1alpha2.Response) (map[string]interface{}, error) {
 	baseMap, _ := json_util.StructToMap(forProvider)
 	statusMap, _ := json_util.StructToMap(map[string]interface{}{
 		"response": response,
 	})
 
 	maps.Copy(baseMap, statusMap)
-	json_util.ConvertJSONStringsToMaps(&baseMap)
+	if err := json_util.ConvertJSONStringsToMaps(&baseMap); err != nil {
+		return nil, err
+	}
+
+	if responseMap, ok := baseMap["response"].(map[string]interface{}); ok {
+		if err := bodyformat.SetBody(responseMap, response.Body, forProvider.ResponseBodyFormat); err != nil {
+			return nil, err
+		}
+		if len(response.Headers) != 0 {
+			responseMap["headers"] = canonicalHeaders(response.Headers)
+		}
+	}
+
+	// The metadata is added after the conversion so that JSON annotation values are kept as strings.
+	if meta != nil {
+		baseMap["meta"] = metaObject(meta)
+	}
+
+	// The forEach value is also added after the conversion, so that the values that are not JSON are kept
+	// as strings.
+	if item := forProvider.ForEachItem; item != nil {
+		baseMap["each"] = forEachValue(item.Value)
+		baseMap["index"] = item.Index
+	}
+
+	// The raw body is also added after the conversion so that it is kept verbatim.
+	if responseMap, ok := baseMap["response"].(map[string]interface{}); ok && forProvider.PreserveRawBody {
+		responseMap["rawBody"] = response.Body
+	}
 
-	return baseMap
+	return baseMap, nil
+}
+
+// forEachValue returns the parsed forEach value, or the value itself when it is not JSON.
+func forEachValue(value string) interface{} {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		return value
+	}
+
+	return parsed
+}
+
+// canonicalHeaders returns the response headers exposed to jq filters, keyed by their canonical form (e.g. Location)
+// regardless of how they were stored, so that they can be referenced in the templates of later mappings. The values
+// of keys sharing the same canonical form are merged in the order of their keys.
+func canonicalHeaders(headers map[string][]string) map[string]interface{} {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make(map[string]interface{}, len(headers))
+	for _, key := range keys {
+		canonicalKey := textproto.CanonicalMIMEHeaderKey(key)
+		values, _ := result[canonicalKey].([]interface{})
+		for _, value := range headers[key] {
+			values = append(values, value)
+		}
+		result[canonicalKey] = values
+	}
+
+	return result
+}
+
+// metaObject returns the metadata of the resource exposed to jq filters.
+func metaObject(meta metav1.Object) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        meta.GetName(),
+		"labels":      stringMapToInterfaceMap(meta.GetLabels()),
+		"annotations": stringMapToInterfaceMap(meta.GetAnnotations()),
+	}
+}
+
+// stringMapToInterfaceMap converts a string map to a map that can be queried by jq.
+func stringMapToInterfaceMap(m map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		result[key] = value
+	}
+
+	return result
 }
 
 // GenerateValidRequestDetails generates valid request details based on the given Request resource and Mapping configuration.
@@ -70,12 +218,12 @@ func GenerateRequestObject(forProvider v1alpha2.RequestParameters, response v1al
 // and attempts to generate request details again. The function returns the generated request details or an error if the
 // generation process fails.
 func GenerateValidRequestDetails(ctx context.Context, cr *v1alpha2.Request, mapping *v1alpha2.Mapping, localKube client.Client, logger logging.Logger) (RequestDetails, error) {
-	requestDetails, _, ok := GenerateRequestDetails(ctx, localKube, *mapping, cr.Spec.ForProvider, cr.Status.Response, logger)
+	requestDetails, _, ok := GenerateRequestDetails(ctx, localKube, *mapping, cr.Spec.ForProvider, cr, cr.Status.Response, logger)
 	if IsRequestValid(requestDetails) && ok {
 		return requestDetails, nil
 	}
 
-	requestDetails, err, _ := GenerateRequestDetails(ctx, localKube, *mapping, cr.Spec.ForProvider, cr.Status.Cache.Response, logger)
+	requestDetails, err, _ := GenerateRequestDetails(ctx, localKube, *mapping, cr.Spec.ForProvider, cr, cr.Status.Cache.Response, logger)
 	if err != nil {
 		return RequestDetails{}, err
 	}
@@ -83,6 +231,32 @@ func GenerateValidRequestDetails(ctx context.Context, cr *v1alpha2.Request, mapp
 	return requestDetails, nil
 }
 
+// MappingConditionMet returns true if the mapping has no condition, or if its condition evaluated against the
+// payload and the last response of the Request returns true.
+func MappingConditionMet(cr *v1alpha2.Request, mapping *v1alpha2.Mapping) (bool, error) {
+	return MappingConditionMetFor(cr, mapping, cr.Status.Response)
+}
+
+// MappingConditionMetFor is MappingConditionMet evaluating the condition against the given response, e.g.
+// the one just observed, instead of the last response recorded in the status.
+func MappingConditionMetFor(cr *v1alpha2.Request, mapping *v1alpha2.Mapping, response v1alpha2.Response) (bool, error) {
+	if mapping.Condition == "" {
+		return true, nil
+	}
+
+	jqObject, err := GenerateRequestObject(cr.Spec.ForProvider, cr, response)
+	if err != nil {
+		return false, errors.Wrapf(err, errMappingCondition, mapping.Method)
+	}
+
+	met, err := jq.ParseBool(utils.NormalizeWhitespace(mapping.Condition), jqObject)
+	if err != nil {
+		return false, errors.Wrapf(err, errMappingCondition, mapping.Method)
+	}
+
+	return met, nil
+}
+
 // IsRequestValid checks if the request details are valid.
 func IsRequestValid(requestDetails RequestDetails) bool {
 	return (!strings.Contains(fmt.Sprint(requestDetails), "null")) && (requestDetails.Url != "")
@@ -96,6 +270,139 @@ func coalesceHeaders(mappingHeaders, defaultHeaders map[string][]string) map[str
 	return defaultHeaders
 }
 
+// defaultContentType returns the headers with the Content-Type of the body encoding when they don't set one.
+// The given headers are never modified.
+func defaultContentType(headers map[string][]string, bodyEncoding string) map[string][]string {
+	contentType, ok := bodyEncodingContentTypes[bodyEncoding]
+	if !ok {
+		return headers
+	}
+
+	for key := range headers {
+		if strings.EqualFold(key, headerContentType) {
+			return headers
+		}
+	}
+
+	withContentType := maps.Clone(headers)
+	if withContentType == nil {
+		withContentType = map[string][]string{}
+	}
+	withContentType[headerContentType] = []string{contentType}
+	return withContentType
+}
+
+// withFormDataContentType returns the evaluated headers with the Content-Type of a multipart body when they
+// don't set one. A multipart Content-Type they set, e.g. multipart/related, is kept with the boundary of the
+// body, and any other Content-Type they set is kept as is. The given headers are never modified.
+func withFormDataContentType(headers map[string][]string, contentType string) map[string][]string {
+	for key, values := range headers {
+		if !strings.EqualFold(key, headerContentType) || len(values) == 0 {
+			continue
+		}
+
+		mediaType, params, err := mime.ParseMediaType(values[0])
+		if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+			return headers
+		}
+
+		_, bodyParams, _ := mime.ParseMediaType(contentType)
+		params["boundary"] = bodyParams["boundary"]
+		return withContentType(headers, mime.FormatMediaType(mediaType, params))
+	}
+
+	return withContentType(headers, contentType)
+}
+
+// withContentType returns the evaluated headers with the given Content-Type, replacing the one they set.
+// The given headers are never modified.
+func withContentType(headers map[string][]string, contentType string) map[string][]string {
+	withContentType := make(map[string][]string, len(headers)+1)
+	for key, values := range headers {
+		if !strings.EqualFold(key, headerContentType) {
+			withContentType[key] = values
+		}
+	}
+	withContentType[headerContentType] = []string{contentType}
+	return withContentType
+}
+
+// addBodyChecksums sets the checksum headers of the finalized body. The headers sent get the checksum of the
+// body sent, with the secrets patched in, while the headers shown in the status get the checksum of the body
+// shown in the status. The given headers are never modified.
+func addBodyChecksums(headers httpClient.Data, body httpClient.Data, checksums []v1alpha2.BodyChecksum) (httpClient.Data, error) {
+	if len(checksums) == 0 {
+		return headers, nil
+	}
+
+	encrypted, err := withBodyChecksums(headers.Encrypted, body.Encrypted, checksums)
+	if err != nil {
+		return httpClient.Data{}, err
+	}
+
+	decrypted, err := withBodyChecksums(headers.Decrypted, body.Decrypted, checksums)
+	if err != nil {
+		return httpClient.Data{}, err
+	}
+
+	return httpClient.Data{
+		Encrypted: encrypted,
+		Decrypted: decrypted,
+	}, nil
+}
+
+// withBodyChecksums returns a copy of the headers with the checksum headers of the body. A header set
+// by the checksums replaces the header of the same name, whatever its case.
+func withBodyChecksums(headers interface{}, body interface{}, checksums []v1alpha2.BodyChecksum) (map[string][]string, error) {
+	headersMap, _ := headers.(map[string][]string)
+	bodyStr, _ := body.(string)
+
+	withChecksums := maps.Clone(headersMap)
+	if withChecksums == nil {
+		withChecksums = map[string][]string{}
+	}
+
+	for _, checksum := range checksums {
+		value, err := bodyChecksum([]byte(bodyStr), checksum)
+		if err != nil {
+			return nil, err
+		}
+
+		for key := range withChecksums {
+			if strings.EqualFold(key, checksum.Header) {
+				delete(withChecksums, key)
+			}
+		}
+		withChecksums[checksum.Header] = []string{value}
+	}
+
+	return withChecksums, nil
+}
+
+// bodyChecksum computes the checksum of the body with the algorithm and encoding of the given checksum.
+func bodyChecksum(body []byte, checksum v1alpha2.BodyChecksum) (string, error) {
+	var sum []byte
+	switch checksum.Algorithm {
+	case v1alpha2.ChecksumAlgorithmMD5:
+		md5Sum := md5.Sum(body) //nolint:gosec // Content-MD5 is an integrity check, not a security control.
+		sum = md5Sum[:]
+	case v1alpha2.ChecksumAlgorithmSHA256:
+		sha256Sum := sha256.Sum256(body)
+		sum = sha256Sum[:]
+	default:
+		return "", errors.Errorf(errChecksumAlgorithm, checksum.Algorithm, checksum.Header)
+	}
+
+	switch checksum.Encoding {
+	case "", v1alpha2.ChecksumEncodingHex:
+		return hex.EncodeToString(sum), nil
+	case v1alpha2.ChecksumEncodingBase64:
+		return base64.StdEncoding.EncodeToString(sum), nil
+	default:
+		return "", errors.Errorf(errChecksumEncoding, checksum.Encoding, checksum.Header)
+	}
+}
+
 // generateURL applies a JQ filter to generate a URL.
 func generateURL(urlJQFilter string, jqObject map[string]interface{}) (string, error) {
 	getURL, err := requestprocessing.ApplyJQOnStr(urlJQFilter, jqObject)
@@ -107,23 +414,51 @@ func generateURL(urlJQFilter string, jqObject map[string]interface{}) (string, e
 }
 
 // generateBody applies a mapping body to generate the request body.
-func generateBody(ctx context.Context, localKube client.Client, mappingBody string, jqObject map[string]interface{}, logger logging.Logger) (httpClient.Data, error) {
-	if mappingBody == "" {
+func generateBody(ctx context.Context, localKube client.Client, forProvider v1alpha2.RequestParameters, mapping v1alpha2.Mapping, jqObject map[string]interface{}, logger logging.Logger) (httpClient.Data, error) {
+	if mapping.BodyFrom != nil && mapping.Body == "" && mapping.LayeredBody == nil && len(mapping.BodyFragments) == 0 {
+		return utils.BodyFrom(ctx, localKube, mapping.BodyFrom)
+	}
+
+	body, err := renderBody(forProvider, mapping, jqObject, map[string]bool{}, logger)
+	if err != nil {
+		return httpClient.Data{}, err
+	}
+
+	if forProvider.CompactBody {
+		body = compactBody(body)
+	}
+
+	if forProvider.DisableHTMLEscaping {
+		body = unescapeHTML(body)
+	}
+
+	if mapping.BodyEncoding == v1alpha2.BodyEncodingNDJSON {
+		body, err = encodeNDJSON(body)
+		if err != nil {
+			return httpClient.Data{}, err
+		}
+	}
+
+	if body == "" {
 		return httpClient.Data{
 			Encrypted: "",
 			Decrypted: "",
 		}, nil
 	}
 
-	jqQuery := utils.NormalizeWhitespace(mappingBody)
-	body, err := requestprocessing.ApplyJQOnStr(jqQuery, jqObject)
+	sensitiveBody, err := datapatcher.PatchSecretsIntoString(ctx, localKube, body, forProvider.UnresolvedSecretPolicy, logger)
 	if err != nil {
 		return httpClient.Data{}, err
 	}
 
-	sensitiveBody, err := datapatcher.PatchSecretsIntoString(ctx, localKube, body, logger)
-	if err != nil {
-		return httpClient.Data{}, err
+	// The form is encoded once the secrets are injected, as the placeholders would be escaped otherwise.
+	if mapping.BodyEncoding == v1alpha2.BodyEncodingURLEncoded {
+		if body, err = encodeURLEncoded(body); err != nil {
+			return httpClient.Data{}, err
+		}
+		if sensitiveBody, err = encodeURLEncoded(sensitiveBody); err != nil {
+			return httpClient.Data{}, err
+		}
 	}
 
 	return httpClient.Data{
@@ -132,14 +467,280 @@ func generateBody(ctx context.Context, localKube client.Client, mappingBody stri
 	}, nil
 }
 
-// generateHeaders applies JQ queries to generate headers.
-func generateHeaders(ctx context.Context, localKube client.Client, headers map[string][]string, jqObject map[string]interface{}, logger logging.Logger) (httpClient.Data, error) {
+// compactBody removes the insignificant whitespace of a JSON body. Bodies that are not JSON are returned unchanged.
+func compactBody(body string) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(body)); err != nil {
+		return body
+	}
+
+	return compacted.String()
+}
+
+// unescapeHTML serializes a JSON body again without escaping the characters <, > and &, which the default
+// JSON serialization of the rendered bodies escapes. Bodies that are not JSON are returned unchanged.
+func unescapeHTML(body string) string {
+	if !strings.Contains(body, `\u00`) {
+		return body
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return body
+	}
+
+	var unescaped bytes.Buffer
+	encoder := json.NewEncoder(&unescaped)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return body
+	}
+
+	return strings.TrimSuffix(unescaped.String(), "\n")
+}
+
+// encodeNDJSON serializes a JSON array body as newline-delimited JSON: every element of the array is
+// written compacted on its own line, each line ending with a newline. An empty body is returned as is.
+func encodeNDJSON(body string) (string, error) {
+	if body == "" {
+		return "", nil
+	}
+
+	if !strings.HasPrefix(strings.TrimSpace(body), "[") {
+		return "", errors.New(errNDJSONBodyNotArray)
+	}
+
+	var documents []json.RawMessage
+	if err := json.Unmarshal([]byte(body), &documents); err != nil {
+		return "", errors.Wrap(err, errNDJSONBodyNotArray)
+	}
+
+	var encoded bytes.Buffer
+	for _, document := range documents {
+		if err := json.Compact(&encoded, document); err != nil {
+			return "", errors.Wrap(err, errNDJSONBodyNotArray)
+		}
+		encoded.WriteByte('\n')
+	}
+
+	return encoded.String(), nil
+}
+
+// encodeURLEncoded serializes a JSON object body as an application/x-www-form-urlencoded form, sorted by key.
+// Strings are sent as is, arrays as a repeated key, null values are left out and any other value is sent as
+// JSON, e.g. numbers and booleans.
+func encodeURLEncoded(body string) (string, error) {
+	if !strings.HasPrefix(strings.TrimSpace(body), "{") {
+		return "", errors.New(errURLEncodedNotObject)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(body), &fields); err != nil {
+		return "", errors.Wrap(err, errURLEncodedNotObject)
+	}
+
+	form := url.Values{}
+	for key, value := range fields {
+		var values []json.RawMessage
+		if err := json.Unmarshal(value, &values); err != nil {
+			values = []json.RawMessage{value}
+		}
+		for _, value := range values {
+			var str string
+			switch {
+			case string(value) == "null":
+				continue
+			case json.Unmarshal(value, &str) == nil:
+				form.Add(key, str)
+			default:
+				form.Add(key, string(value))
+			}
+		}
+	}
+
+	return form.Encode(), nil
+}
+
+// renderBody renders the body of the mapping. When the mapping sets bodyFromPrevious, the rendered body of the
+// referenced mapping is used as a base, overridden by the fields of the mapping's own body. The visited mappings
+// are tracked by their resolved action to detect cycles, including through mappings only setting a method.
+func renderBody(forProvider v1alpha2.RequestParameters, mapping v1alpha2.Mapping, jqObject map[string]interface{}, visited map[string]bool, logger logging.Logger) (string, error) {
+	body, err := renderOwnBody(mapping, jqObject)
+	if err != nil {
+		return "", err
+	}
+
+	if mapping.BodyFromPrevious == "" {
+		return body, nil
+	}
+
+	visited[requestmapping.ResolvedAction(mapping)] = true
+	if visited[mapping.BodyFromPrevious] {
+		return "", errors.Errorf(errBodyFromPreviousCycle, mapping.BodyFromPrevious)
+	}
+
+	previousMapping, err := requestmapping.GetMapping(&forProvider, mapping.BodyFromPrevious, logger)
+	if err != nil {
+		return "", err
+	}
+
+	previousBody, err := renderBody(forProvider, *previousMapping, jqObject, visited, logger)
+	if err != nil {
+		return "", err
+	}
+
+	return mergeBodies(previousBody, body, mapping.BodyFromPrevious, mapping.Action)
+}
+
+// ValidateBodyFromPrevious returns an error if the bodyFromPrevious references of the mappings, or of the
+// mapping template, form a cycle. References to missing mappings are reported when the body is rendered.
+func ValidateBodyFromPrevious(forProvider v1alpha2.RequestParameters, logger logging.Logger) error {
+	for _, mappings := range [][]v1alpha2.Mapping{forProvider.Mappings, forProvider.MappingTemplate} {
+		params := forProvider
+		params.Mappings = mappings
+		for _, mapping := range mappings {
+			visited := map[string]bool{requestmapping.ResolvedAction(mapping): true}
+			for mapping.BodyFromPrevious != "" {
+				if visited[mapping.BodyFromPrevious] {
+					return errors.Errorf(errBodyFromPreviousCycle, mapping.BodyFromPrevious)
+				}
+				visited[mapping.BodyFromPrevious] = true
+
+				previousMapping, err := requestmapping.GetMapping(&params, mapping.BodyFromPrevious, logger)
+				if err != nil {
+					break
+				}
+				mapping = *previousMapping
+			}
+		}
+	}
+
+	return nil
+}
+
+// renderOwnBody renders the body defined by the mapping itself, either layered or from a single jq filter.
+func renderOwnBody(mapping v1alpha2.Mapping, jqObject map[string]interface{}) (string, error) {
+	if mapping.LayeredBody != nil {
+		return renderLayeredBody(mapping.LayeredBody, jqObject)
+	}
+
+	if len(mapping.BodyFragments) > 0 {
+		return renderBodyFragments(mapping.BodyFragments, jqObject)
+	}
+
+	if mapping.Body == "" {
+		return "", nil
+	}
+
+	if mapping.BodyEncoding == v1alpha2.BodyEncodingNDJSON || mapping.PatchStrategy == v1alpha2.PatchStrategyJSONPatch {
+		return renderDocuments(mapping.Body, jqObject)
+	}
+
+	return requestprocessing.ApplyJQOnStr(utils.NormalizeWhitespace(mapping.Body), jqObject)
+}
+
+// renderDocuments renders a body whose jq filter returns any JSON value, e.g. the array of documents of
+// an ndjson body or the operations of a JSON patch. A string result is returned as is.
+func renderDocuments(body string, jqObject map[string]interface{}) (string, error) {
+	result, err := jq.ParseInterface(utils.NormalizeWhitespace(body), jqObject)
+	if err != nil {
+		return "", err
+	}
+
+	if str, ok := result.(string); ok {
+		return str, nil
+	}
+
+	documents, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(documents), nil
+}
+
+// renderLayeredBody merges the defaults with the observed object, then overlays the desired fields.
+func renderLayeredBody(layers *v1alpha2.LayeredBody, jqObject map[string]interface{}) (string, error) {
+	return mergeRenderedObjects([]string{layers.Defaults, layers.Observed, layers.Desired}, jqObject, errLayerNotObject)
+}
+
+// renderBodyFragments deep merges the objects returned by the fragments, later fragments taking precedence.
+func renderBodyFragments(fragments []string, jqObject map[string]interface{}) (string, error) {
+	return mergeRenderedObjects(fragments, jqObject, errFragmentNotObject)
+}
+
+// mergeRenderedObjects deep merges the objects returned by the jq filters in order, skipping the empty filters and
+// the ones returning null. A filter returning anything else than an object fails with the notObject error format.
+func mergeRenderedObjects(filters []string, jqObject map[string]interface{}, notObject string) (string, error) {
+	merged := map[string]interface{}{}
+	for _, filter := range filters {
+		if filter == "" {
+			continue
+		}
+
+		rendered, err := jq.ParseInterface(utils.NormalizeWhitespace(filter), jqObject)
+		if err != nil {
+			return "", err
+		}
+		if rendered == nil {
+			continue
+		}
+
+		renderedMap, ok := rendered.(map[string]interface{})
+		if !ok {
+			return "", errors.Errorf(notObject, filter)
+		}
+		merged = json_util.DeepMerge(merged, renderedMap)
+	}
+
+	return json_util.ConvertMapToJson(merged)
+}
+
+// mergeBodies overlays the fields of the body on top of the base body.
+func mergeBodies(base, overlay, baseAction, overlayAction string) (string, error) {
+	if overlay == "" {
+		return base, nil
+	}
+	if base == "" {
+		return overlay, nil
+	}
+
+	baseMap := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(base), &baseMap); err != nil {
+		return "", errors.Errorf(errBodyNotObject, baseAction)
+	}
+
+	overlayMap := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(overlay), &overlayMap); err != nil {
+		return "", errors.Errorf(errBodyNotObject, overlayAction)
+	}
+
+	maps.Copy(baseMap, overlayMap)
+	merged, err := json.Marshal(baseMap)
+	if err != nil {
+		return "", err
+	}
+
+	return string(merged), nil
+}
+
+// generateHeaders applies JQ queries to generate headers, then the headers transform when set.
+func generateHeaders(ctx context.Context, localKube client.Client, headers map[string][]string, transform string, jqObject map[string]interface{}, policy string, logger logging.Logger) (httpClient.Data, error) {
 	generatedHeaders, err := requestprocessing.ApplyJQOnMapStrings(headers, jqObject)
 	if err != nil {
 		return httpClient.Data{}, err
 	}
 
-	sensitiveHeaders, err := datapatcher.PatchSecretsIntoHeaders(ctx, localKube, generatedHeaders, logger)
+	if transform != "" {
+		generatedHeaders, err = transformHeaders(transform, generatedHeaders, jqObject)
+		if err != nil {
+			return httpClient.Data{}, err
+		}
+	}
+
+	sensitiveHeaders, err := datapatcher.PatchSecretsIntoHeaders(ctx, localKube, generatedHeaders, policy, logger)
 	if err != nil {
 		return httpClient.Data{}, err
 	}
@@ -149,3 +750,54 @@ func generateHeaders(ctx context.Context, localKube client.Client, headers map[s
 		Decrypted: sensitiveHeaders,
 	}, nil
 }
+
+// transformHeaders applies the headers transform to the evaluated headers. The transform receives the request
+// object with the headers replaced by the evaluated ones, and returns the headers to send. Headers set to null
+// are dropped.
+func transformHeaders(transform string, headers map[string][]string, jqObject map[string]interface{}) (map[string][]string, error) {
+	headersObject := make(map[string]interface{}, len(headers))
+	for key, values := range headers {
+		headerValues := make([]interface{}, len(values))
+		for i, value := range values {
+			headerValues[i] = value
+		}
+		headersObject[key] = headerValues
+	}
+
+	input := maps.Clone(jqObject)
+	input["headers"] = headersObject
+
+	result, err := jq.ParseInterface(utils.NormalizeWhitespace(transform), input)
+	if err != nil {
+		return nil, err
+	}
+
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf(errHeadersNotObject, result)
+	}
+
+	transformed := make(map[string][]string, len(resultMap))
+	for key, value := range resultMap {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			transformed[key] = []string{v}
+		case []interface{}:
+			values := make([]string, len(v))
+			for i, item := range v {
+				str, ok := item.(string)
+				if !ok {
+					return nil, errors.Errorf(errHeaderValueNotString, key, value)
+				}
+				values[i] = str
+			}
+			transformed[key] = values
+		default:
+			return nil, errors.Errorf(errHeaderValueNotString, key, value)
+		}
+	}
+
+	return transformed, nil
+}
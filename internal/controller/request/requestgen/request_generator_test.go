@@ -4,9 +4,17 @@ import (
 	"context"
 	"testing"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
@@ -50,6 +58,23 @@ var (
 	}
 )
 
+var (
+	testCreateMapping = v1alpha2.Mapping{
+		Action: v1alpha2.ActionCreate,
+		Method: "POST",
+		Body:   "{ username: .payload.body.username, email: .payload.body.email }",
+		URL:    ".payload.baseUrl",
+	}
+
+	testUpdateFromCreateMapping = v1alpha2.Mapping{
+		Action:           v1alpha2.ActionUpdate,
+		Method:           "PUT",
+		Body:             "{ username: \"john_doe_new_username\" }",
+		BodyFromPrevious: v1alpha2.ActionCreate,
+		URL:              "(.payload.baseUrl + \"/\" + .response.body.id)",
+	}
+)
+
 var (
 	testForProvider = v1alpha2.RequestParameters{
 		Payload: v1alpha2.Payload{
@@ -77,6 +102,7 @@ func Test_GenerateRequestDetails(t *testing.T) {
 	type args struct {
 		methodMapping v1alpha2.Mapping
 		forProvider   v1alpha2.RequestParameters
+		meta          metav1.Object
 		response      v1alpha2.Response
 		logger        logging.Logger
 		localKube     client.Client
@@ -90,6 +116,223 @@ func Test_GenerateRequestDetails(t *testing.T) {
 		args args
 		want want
 	}{
+		"SuccessLayeredBody": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "PUT",
+					LayeredBody: &v1alpha2.LayeredBody{
+						Defaults: `{ region: "eu-west-1", tags: { team: "core", env: "dev" } }`,
+						Observed: ".response.body",
+						Desired:  `{ name: .payload.body.username, tags: { env: "prod" } }`,
+					},
+					URL: "(.payload.baseUrl + \"/\" + .response.body.id)",
+				},
+				forProvider: testForProvider,
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123","name":"old_name","region":"us-east-1","tags":{"owner":"john"}}`,
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users/123",
+					Body: httpClient.Data{
+						Encrypted: `{"id":"123","name":"john_doe","region":"us-east-1","tags":{"env":"prod","owner":"john","team":"core"}}`,
+						Decrypted: `{"id":"123","name":"john_doe","region":"us-east-1","tags":{"env":"prod","owner":"john","team":"core"}}`,
+					},
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessURLFromLocationHeader": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "PUT",
+					Body:   "{ username: .payload.body.username }",
+					URL:    "(.payload.baseUrl + \"/\" + (.response.headers.Location[0] | split(\"/\") | last))",
+				},
+				forProvider: testForProvider,
+				response: v1alpha2.Response{
+					StatusCode: 201,
+					Headers: map[string][]string{
+						"location": {"https://api.example.com/users/123"},
+					},
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users/123",
+					Body: httpClient.Data{
+						Encrypted: `{"username":"john_doe"}`,
+						Decrypted: `{"username":"john_doe"}`,
+					},
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessBodyFragments": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "PUT",
+					BodyFragments: []string{
+						`{ name: .payload.body.username, settings: { theme: "light", locale: "en" } }`,
+						`{ email: .payload.body.email, settings: { theme: "dark" } }`,
+						`null`,
+					},
+					URL: "(.payload.baseUrl + \"/\" + .response.body.id)",
+				},
+				forProvider: testForProvider,
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123"}`,
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users/123",
+					Body: httpClient.Data{
+						Encrypted: `{"email":"john.doe@example.com","name":"john_doe","settings":{"locale":"en","theme":"dark"}}`,
+						Decrypted: `{"email":"john.doe@example.com","name":"john_doe","settings":{"locale":"en","theme":"dark"}}`,
+					},
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"FailBodyFragmentNotObject": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method:        "PUT",
+					BodyFragments: []string{`{ name: .payload.body.username }`, `.payload.body.email`},
+					URL:           "(.payload.baseUrl + \"/\" + .response.body.id)",
+				},
+				forProvider: testForProvider,
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123"}`,
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				err: errors.Errorf(errFragmentNotObject, ".payload.body.email"),
+				ok:  false,
+			},
+		},
+		"SuccessBodyFromPrevious": {
+			args: args{
+				methodMapping: testUpdateFromCreateMapping,
+				forProvider: v1alpha2.RequestParameters{
+					Payload:  testForProvider.Payload,
+					Mappings: []v1alpha2.Mapping{testCreateMapping, testUpdateFromCreateMapping},
+				},
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123"}`,
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users/123",
+					Body: httpClient.Data{
+						Encrypted: `{"email":"john.doe@example.com","username":"john_doe_new_username"}`,
+						Decrypted: `{"email":"john.doe@example.com","username":"john_doe_new_username"}`,
+					},
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"FailBodyFromPreviousCycle": {
+			args: args{
+				methodMapping: testUpdateFromCreateMapping,
+				forProvider: v1alpha2.RequestParameters{
+					Payload: testForProvider.Payload,
+					Mappings: []v1alpha2.Mapping{
+						{Action: v1alpha2.ActionCreate, Method: "POST", URL: ".payload.baseUrl", BodyFromPrevious: v1alpha2.ActionUpdate},
+						testUpdateFromCreateMapping,
+					},
+				},
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123"}`,
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				err: errors.Errorf(errBodyFromPreviousCycle, v1alpha2.ActionUpdate),
+				ok:  false,
+			},
+		},
+		"FailBodyFromPreviousMethodOnlyCycle": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{Method: "POST", URL: ".payload.baseUrl", BodyFromPrevious: v1alpha2.ActionUpdate},
+				forProvider: v1alpha2.RequestParameters{
+					Payload: testForProvider.Payload,
+					Mappings: []v1alpha2.Mapping{
+						{Method: "POST", URL: ".payload.baseUrl", BodyFromPrevious: v1alpha2.ActionUpdate},
+						{Method: "PUT", URL: ".payload.baseUrl", BodyFromPrevious: v1alpha2.ActionCreate},
+					},
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				err: errors.Errorf(errBodyFromPreviousCycle, v1alpha2.ActionCreate),
+				ok:  false,
+			},
+		},
+		"SuccessBodyFromLabel": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "POST",
+					Body:   `{ team: .meta.labels["team"], name: .meta.name }`,
+					URL:    `(.payload.baseUrl + "?owner=" + .meta.labels.team)`,
+				},
+				forProvider: testForProvider,
+				meta: &metav1.ObjectMeta{
+					Name:   "users",
+					Labels: map[string]string{"team": "platform"},
+				},
+				response: v1alpha2.Response{},
+				logger:   logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users?owner=platform",
+					Body: httpClient.Data{
+						Encrypted: `{"name":"users","team":"platform"}`,
+						Decrypted: `{"name":"users","team":"platform"}`,
+					},
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
 		"SuccessPost": {
 			args: args{
 				methodMapping: testPostMapping,
@@ -194,10 +437,108 @@ func Test_GenerateRequestDetails(t *testing.T) {
 				ok:  true,
 			},
 		},
+		"SuccessReferencedResource": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "POST",
+					Body:   "{ ip: .resources.vm.status.atProvider.publicIp, count: .resources.vm.spec.forProvider.count, zone: .resources.vm.metadata.labels.zone }",
+					URL:    ".payload.baseUrl",
+				},
+				forProvider: withResourceRefs(testForProvider, testResourceRef),
+				localKube:   referencedResourceKube(nil),
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users",
+					Body: httpClient.Data{
+						Encrypted: `{"count":2,"ip":"10.0.0.7","zone":"eu-west-1a"}`,
+						Decrypted: `{"count":2,"ip":"10.0.0.7","zone":"eu-west-1a"}`,
+					},
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+				},
+				ok: true,
+			},
+		},
+		"ReferencedResourceNotFound": {
+			args: args{
+				methodMapping: testPostMapping,
+				forProvider:   withResourceRefs(testForProvider, testResourceRef),
+				localKube:     referencedResourceKube(errBoom),
+				logger:        logging.NewNopLogger(),
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errGetReferencedResource, "Instance", "vm-1", "vm"),
+				ok:  false,
+			},
+		},
+		"ReferencedSecretRejected": {
+			args: args{
+				methodMapping: testPostMapping,
+				forProvider: withResourceRefs(testForProvider, v1alpha2.ResourceRef{
+					Name: "creds", APIVersion: "v1", Kind: "Secret", ResourceName: "creds", Namespace: "default",
+				}),
+				localKube: referencedResourceKube(nil),
+				logger:    logging.NewNopLogger(),
+			},
+			want: want{
+				err: errors.Errorf(errSecretReference, "creds"),
+				ok:  false,
+			},
+		},
+		"ReferencedKindNotAllowed": {
+			args: args{
+				methodMapping: testPostMapping,
+				forProvider: withResourceRefs(testForProvider, v1alpha2.ResourceRef{
+					Name: "settings", APIVersion: "v1", Kind: "ConfigMap", ResourceName: "settings", Namespace: "default",
+				}),
+				localKube: referencedResourceKube(nil),
+				logger:    logging.NewNopLogger(),
+			},
+			want: want{
+				err: errors.Errorf(errKindNotAllowed, "settings", "ConfigMap"),
+				ok:  false,
+			},
+		},
+		"UnresolvedSecretLeftInHeaders": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method:  "GET",
+					URL:     ".payload.baseUrl",
+					Headers: map[string][]string{"Authorization": {"Bearer {{creds:default:token}}"}},
+				},
+				forProvider: func() v1alpha2.RequestParameters {
+					forProvider := testForProvider
+					forProvider.UnresolvedSecretPolicy = common.UnresolvedSecretPolicyLeaveUnresolved
+					return forProvider
+				}(),
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "creds")),
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:  "https://api.example.com/users",
+					Body: httpClient.Data{Encrypted: "", Decrypted: ""},
+					Headers: httpClient.Data{
+						Encrypted: map[string][]string{"Authorization": {"Bearer {{creds:default:token}}"}},
+						Decrypted: map[string][]string{"Authorization": {"Bearer {{creds:default:token}}"}},
+					},
+				},
+				ok: true,
+			},
+		},
 	}
+	utils.SetResourceRefKinds([]string{"*.ec2.aws.upbound.io"})
+	defer utils.SetResourceRefKinds(nil)
+
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got, gotErr, ok := GenerateRequestDetails(context.Background(), tc.args.localKube, tc.args.methodMapping, tc.args.forProvider, tc.args.response, tc.args.logger)
+			got, gotErr, ok := GenerateRequestDetails(context.Background(), tc.args.localKube, tc.args.methodMapping, tc.args.forProvider, tc.args.meta, tc.args.response, tc.args.logger)
 			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
 				t.Fatalf("GenerateRequestDetails(...): -want error, +got error: %s", diff)
 			}
@@ -443,14 +784,782 @@ func Test_generateRequestObject(t *testing.T) {
 				},
 			},
 		},
+		"CanonicalHeaderKeys": {
+			args: args{
+				forProvider: v1alpha2.RequestParameters{},
+				response: v1alpha2.Response{
+					StatusCode: 201,
+					Headers: map[string][]string{
+						"location":   {"https://api.example.com/users/123"},
+						"x-trace-id": {"abc"},
+						"X-Trace-Id": {"def"},
+					},
+				},
+			},
+			want: want{
+				result: map[string]any{
+					"expectedResponseCheck": map[string]any{},
+					"isRemovedCheck":        map[string]any{},
+					"mappings":              nil,
+					"payload":               map[string]any{},
+					"response": map[string]any{
+						"statusCode": float64(201),
+						"headers": map[string]any{
+							"Location":   []any{"https://api.example.com/users/123"},
+							"X-Trace-Id": []any{"def", "abc"},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got := GenerateRequestObject(tc.args.forProvider, tc.args.response)
+			got, err := GenerateRequestObject(tc.args.forProvider, nil, tc.args.response)
+			if err != nil {
+				t.Fatalf("generateRequestObject(...): unexpected error: %s", err)
+			}
 			if diff := cmp.Diff(tc.want.result, got); diff != "" {
 				t.Fatalf("generateRequestObject(...): -want result, +got result: %s", diff)
 			}
 		})
 	}
 }
+
+func Test_transformHeaders(t *testing.T) {
+	conditionalTransform := `(if .payload.body.env == "prod" then .headers | del(.["X-Debug"]) else .headers end) + {"X-Tenant": .payload.body.tenant}`
+
+	type args struct {
+		transform string
+		headers   map[string][]string
+		jqObject  map[string]interface{}
+	}
+	type want struct {
+		headers map[string][]string
+		err     error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DropsHeaderConditionallyAndAddsAnother": {
+			args: args{
+				transform: conditionalTransform,
+				headers: map[string][]string{
+					"Content-Type": {"application/json"},
+					"X-Debug":      {"true"},
+				},
+				jqObject: map[string]interface{}{
+					"payload": map[string]interface{}{
+						"body": map[string]interface{}{"env": "prod", "tenant": "acme"},
+					},
+				},
+			},
+			want: want{
+				headers: map[string][]string{
+					"Content-Type": {"application/json"},
+					"X-Tenant":     {"acme"},
+				},
+			},
+		},
+		"KeepsHeaderWhenConditionIsNotMet": {
+			args: args{
+				transform: conditionalTransform,
+				headers: map[string][]string{
+					"Content-Type": {"application/json"},
+					"X-Debug":      {"true"},
+				},
+				jqObject: map[string]interface{}{
+					"payload": map[string]interface{}{
+						"body": map[string]interface{}{"env": "dev", "tenant": "acme"},
+					},
+				},
+			},
+			want: want{
+				headers: map[string][]string{
+					"Content-Type": {"application/json"},
+					"X-Debug":      {"true"},
+					"X-Tenant":     {"acme"},
+				},
+			},
+		},
+		"NullHeaderIsDropped": {
+			args: args{
+				transform: `.headers + {"X-Debug": null}`,
+				headers: map[string][]string{
+					"X-Debug": {"true"},
+				},
+				jqObject: map[string]interface{}{},
+			},
+			want: want{
+				headers: map[string][]string{},
+			},
+		},
+		"FailNotObject": {
+			args: args{
+				transform: `.headers | keys`,
+				headers: map[string][]string{
+					"X-Debug": {"true"},
+				},
+				jqObject: map[string]interface{}{},
+			},
+			want: want{
+				err: errors.Errorf(errHeadersNotObject, []interface{}{"X-Debug"}),
+			},
+		},
+		"FailValueNotString": {
+			args: args{
+				transform: `{"X-Retries": 3}`,
+				headers:   map[string][]string{},
+				jqObject:  map[string]interface{}{},
+			},
+			want: want{
+				err: errors.Errorf(errHeaderValueNotString, "X-Retries", 3),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := transformHeaders(tc.args.transform, tc.args.headers, tc.args.jqObject)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("transformHeaders(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.headers, got); diff != "" {
+				t.Fatalf("transformHeaders(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_generateBody_CompactBody(t *testing.T) {
+	prettyBody := "{\n \"username\": \"john_doe\",\n \"tags\": [\"a\", \"b\"]\n}"
+
+	type args struct {
+		compactBody bool
+	}
+	type want struct {
+		body string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"CompactsPrettyBody": {
+			args: args{
+				compactBody: true,
+			},
+			want: want{
+				body: `{"username":"john_doe","tags":["a","b"]}`,
+			},
+		},
+		"PreservesBodyWhenDisabled": {
+			args: args{
+				compactBody: false,
+			},
+			want: want{
+				body: prettyBody,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			forProvider := v1alpha2.RequestParameters{CompactBody: tc.args.compactBody}
+			mapping := v1alpha2.Mapping{
+				Method: "POST",
+				// A jq string literal, rendered as is.
+				Body: `"{\n \"username\": \"john_doe\",\n \"tags\": [\"a\", \"b\"]\n}"`,
+			}
+
+			got, err := generateBody(context.Background(), nil, forProvider, mapping, map[string]interface{}{}, logging.NewNopLogger())
+			if err != nil {
+				t.Fatalf("generateBody(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.body, got.Encrypted); diff != "" {
+				t.Fatalf("generateBody(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, got.Decrypted); diff != "" {
+				t.Fatalf("generateBody(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_generateBody_DisableHTMLEscaping(t *testing.T) {
+	type args struct {
+		disableHTMLEscaping bool
+		body                string
+	}
+	type want struct {
+		body string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"EscapedByDefault": {
+			args: args{
+				body: `{ query: "status=open&owner=me", limit: 10 }`,
+			},
+			want: want{
+				body: `{"limit":10,"query":"status=open\u0026owner=me"}`,
+			},
+		},
+		"SentUnescaped": {
+			args: args{
+				disableHTMLEscaping: true,
+				body:                `{ query: "status=open&owner=me", limit: 10 }`,
+			},
+			want: want{
+				body: `{"limit":10,"query":"status=open&owner=me"}`,
+			},
+		},
+		"NestedValuesSentUnescaped": {
+			args: args{
+				disableHTMLEscaping: true,
+				body:                `{ filter: { html: "<b>R&D</b>" }, ids: [1.50, 2] }`,
+			},
+			want: want{
+				body: `{"filter":{"html":"<b>R&D</b>"},"ids":[1.5,2]}`,
+			},
+		},
+		"EscapedBackslashKept": {
+			args: args{
+				disableHTMLEscaping: true,
+				body:                `{ pattern: "\\u0026 & more" }`,
+			},
+			want: want{
+				body: `{"pattern":"\\u0026 & more"}`,
+			},
+		},
+		"NotJSONSentAsIs": {
+			args: args{
+				disableHTMLEscaping: true,
+				body:                `"a=1&b=\\u0026"`,
+			},
+			want: want{
+				body: `a=1&b=\u0026`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			forProvider := v1alpha2.RequestParameters{DisableHTMLEscaping: tc.args.disableHTMLEscaping}
+			mapping := v1alpha2.Mapping{
+				Method: "POST",
+				Body:   tc.args.body,
+			}
+
+			got, err := generateBody(context.Background(), nil, forProvider, mapping, map[string]interface{}{}, logging.NewNopLogger())
+			if err != nil {
+				t.Fatalf("generateBody(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.body, got.Decrypted); diff != "" {
+				t.Fatalf("generateBody(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_generateBody_NDJSON(t *testing.T) {
+	type args struct {
+		body     string
+		jqObject map[string]interface{}
+	}
+	type want struct {
+		body string
+		err  error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ArrayToLines": {
+			args: args{
+				body: `.payload.body.events`,
+				jqObject: map[string]interface{}{
+					"payload": map[string]interface{}{
+						"body": map[string]interface{}{
+							"events": []interface{}{
+								map[string]interface{}{"id": "1"},
+								map[string]interface{}{"id": "2"},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				body: "{\"id\":\"1\"}\n{\"id\":\"2\"}\n",
+			},
+		},
+		"EmptyArray": {
+			args: args{
+				body:     `[]`,
+				jqObject: map[string]interface{}{},
+			},
+			want: want{
+				body: "",
+			},
+		},
+		"NotAnArray": {
+			args: args{
+				body:     `{ id: "1" }`,
+				jqObject: map[string]interface{}{},
+			},
+			want: want{
+				err: errors.New(errNDJSONBodyNotArray),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			mapping := v1alpha2.Mapping{
+				Method:       "POST",
+				Body:         tc.args.body,
+				BodyEncoding: v1alpha2.BodyEncodingNDJSON,
+			}
+
+			got, err := generateBody(context.Background(), nil, v1alpha2.RequestParameters{}, mapping, tc.args.jqObject, logging.NewNopLogger())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("generateBody(...): -want error, +got error: %s", diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.body, got.Encrypted); diff != "" {
+				t.Fatalf("generateBody(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_generateBody_URLEncoded(t *testing.T) {
+	type args struct {
+		body     string
+		jqObject map[string]interface{}
+	}
+	type want struct {
+		body      string
+		sensitive string
+		err       error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"TokenRequest": {
+			args: args{
+				body: `{ grant_type: "client_credentials", scope: .payload.body.scope }`,
+				jqObject: map[string]interface{}{
+					"payload": map[string]interface{}{
+						"body": map[string]interface{}{"scope": "read write"},
+					},
+				},
+			},
+			want: want{
+				body:      "grant_type=client_credentials&scope=read+write",
+				sensitive: "grant_type=client_credentials&scope=read+write",
+			},
+		},
+		"SpecialCharacters": {
+			args: args{
+				body:     `{ "redirect_uri": "https://app.example.com/cb?a=1&b=2", "name": "Jürgen & Søn", "note": "100% = done/#" }`,
+				jqObject: map[string]interface{}{},
+			},
+			want: want{
+				body:      "name=J%C3%BCrgen+%26+S%C3%B8n&note=100%25+%3D+done%2F%23&redirect_uri=https%3A%2F%2Fapp.example.com%2Fcb%3Fa%3D1%26b%3D2",
+				sensitive: "name=J%C3%BCrgen+%26+S%C3%B8n&note=100%25+%3D+done%2F%23&redirect_uri=https%3A%2F%2Fapp.example.com%2Fcb%3Fa%3D1%26b%3D2",
+			},
+		},
+		"ArraysNumbersAndNulls": {
+			args: args{
+				body:     `{ tag: ["a", "b"], limit: 10, enabled: true, cursor: null }`,
+				jqObject: map[string]interface{}{},
+			},
+			want: want{
+				body:      "enabled=true&limit=10&tag=a&tag=b",
+				sensitive: "enabled=true&limit=10&tag=a&tag=b",
+			},
+		},
+		"SecretInjected": {
+			args: args{
+				body:     `{ client_id: "my-app", client_secret: "{{oauth:default:secret}}" }`,
+				jqObject: map[string]interface{}{},
+			},
+			want: want{
+				body:      "client_id=my-app&client_secret=%7B%7Boauth%3Adefault%3Asecret%7D%7D",
+				sensitive: "client_id=my-app&client_secret=p%40ss+w%26rd",
+			},
+		},
+		"NotAnObject": {
+			args: args{
+				body:     `"grant_type=client_credentials"`,
+				jqObject: map[string]interface{}{},
+			},
+			want: want{
+				err: errors.New(errURLEncodedNotObject),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			mapping := v1alpha2.Mapping{
+				Method:       "POST",
+				Body:         tc.args.body,
+				BodyEncoding: v1alpha2.BodyEncodingURLEncoded,
+			}
+			localKube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					if secret, ok := obj.(*corev1.Secret); ok {
+						secret.Data = map[string][]byte{"secret": []byte("p@ss w&rd")}
+					}
+					return nil
+				}),
+			}
+
+			got, err := generateBody(context.Background(), localKube, v1alpha2.RequestParameters{}, mapping, tc.args.jqObject, logging.NewNopLogger())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("generateBody(...): -want error, +got error: %s", diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.body, got.Encrypted); diff != "" {
+				t.Errorf("generateBody(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sensitive, got.Decrypted); diff != "" {
+				t.Errorf("generateBody(...): -want sent body, +got sent body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_defaultContentType(t *testing.T) {
+	type args struct {
+		headers      map[string][]string
+		bodyEncoding string
+	}
+	type want struct {
+		headers map[string][]string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NDJSONDefault": {
+			args: args{
+				headers:      map[string][]string{"Authorization": {"Bearer token"}},
+				bodyEncoding: v1alpha2.BodyEncodingNDJSON,
+			},
+			want: want{
+				headers: map[string][]string{"Authorization": {"Bearer token"}, "Content-Type": {"application/x-ndjson"}},
+			},
+		},
+		"NDJSONWithoutHeaders": {
+			args: args{
+				bodyEncoding: v1alpha2.BodyEncodingNDJSON,
+			},
+			want: want{
+				headers: map[string][]string{"Content-Type": {"application/x-ndjson"}},
+			},
+		},
+		"ExplicitContentTypeKept": {
+			args: args{
+				headers:      map[string][]string{"content-type": {"application/json-seq"}},
+				bodyEncoding: v1alpha2.BodyEncodingNDJSON,
+			},
+			want: want{
+				headers: map[string][]string{"content-type": {"application/json-seq"}},
+			},
+		},
+		"URLEncodedDefault": {
+			args: args{
+				bodyEncoding: v1alpha2.BodyEncodingURLEncoded,
+			},
+			want: want{
+				headers: map[string][]string{"Content-Type": {"application/x-www-form-urlencoded"}},
+			},
+		},
+		"URLEncodedExplicitContentTypeKept": {
+			args: args{
+				headers:      map[string][]string{"Content-Type": {"application/x-www-form-urlencoded; charset=UTF-8"}},
+				bodyEncoding: v1alpha2.BodyEncodingURLEncoded,
+			},
+			want: want{
+				headers: map[string][]string{"Content-Type": {"application/x-www-form-urlencoded; charset=UTF-8"}},
+			},
+		},
+		"JSONUnchanged": {
+			args: args{
+				headers: map[string][]string{"Authorization": {"Bearer token"}},
+			},
+			want: want{
+				headers: map[string][]string{"Authorization": {"Bearer token"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got := defaultContentType(tc.args.headers, tc.args.bodyEncoding)
+			if diff := cmp.Diff(tc.want.headers, got); diff != "" {
+				t.Fatalf("defaultContentType(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_withFormDataContentType(t *testing.T) {
+	const bodyContentType = "multipart/form-data; boundary=b0undary"
+
+	type args struct {
+		headers map[string][]string
+	}
+	type want struct {
+		headers map[string][]string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Default": {
+			args: args{
+				headers: map[string][]string{"Authorization": {"Bearer token"}},
+			},
+			want: want{
+				headers: map[string][]string{"Authorization": {"Bearer token"}, "Content-Type": {bodyContentType}},
+			},
+		},
+		"ExplicitMultipartGetsBoundary": {
+			args: args{
+				headers: map[string][]string{"content-type": {`multipart/related; type="application/json"`}},
+			},
+			want: want{
+				headers: map[string][]string{"Content-Type": {`multipart/related; boundary=b0undary; type="application/json"`}},
+			},
+		},
+		"ExplicitBoundaryReplaced": {
+			args: args{
+				headers: map[string][]string{"Content-Type": {"multipart/form-data; boundary=stale"}},
+			},
+			want: want{
+				headers: map[string][]string{"Content-Type": {bodyContentType}},
+			},
+		},
+		"ExplicitContentTypeKept": {
+			args: args{
+				headers: map[string][]string{"Content-Type": {"application/octet-stream"}},
+			},
+			want: want{
+				headers: map[string][]string{"Content-Type": {"application/octet-stream"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got := withFormDataContentType(tc.args.headers, bodyContentType)
+			if diff := cmp.Diff(tc.want.headers, got); diff != "" {
+				t.Fatalf("withFormDataContentType(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_addBodyChecksums(t *testing.T) {
+	type args struct {
+		headers   httpClient.Data
+		body      httpClient.Data
+		checksums []v1alpha2.BodyChecksum
+	}
+	type want struct {
+		headers httpClient.Data
+		err     error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"MD5Base64": {
+			args: args{
+				headers: httpClient.Data{
+					Encrypted: map[string][]string{"Authorization": {"Bearer token"}},
+					Decrypted: map[string][]string{"Authorization": {"Bearer token"}},
+				},
+				body: httpClient.Data{
+					Encrypted: `{"username":"john_doe"}`,
+					Decrypted: `{"username":"john_doe"}`,
+				},
+				checksums: []v1alpha2.BodyChecksum{
+					{Header: "Content-MD5", Algorithm: v1alpha2.ChecksumAlgorithmMD5, Encoding: v1alpha2.ChecksumEncodingBase64},
+				},
+			},
+			want: want{
+				headers: httpClient.Data{
+					Encrypted: map[string][]string{"Authorization": {"Bearer token"}, "Content-MD5": {"cuPEiHh7n58WcX5lsG1pTw=="}},
+					Decrypted: map[string][]string{"Authorization": {"Bearer token"}, "Content-MD5": {"cuPEiHh7n58WcX5lsG1pTw=="}},
+				},
+			},
+		},
+		"SHA256HexByDefault": {
+			args: args{
+				body: httpClient.Data{
+					Encrypted: `{"username":"john_doe"}`,
+					Decrypted: `{"username":"john_doe"}`,
+				},
+				checksums: []v1alpha2.BodyChecksum{
+					{Header: "X-Content-SHA256", Algorithm: v1alpha2.ChecksumAlgorithmSHA256},
+				},
+			},
+			want: want{
+				headers: httpClient.Data{
+					Encrypted: map[string][]string{"X-Content-SHA256": {"ced5cde21b115b4f556ebb0a023935853366917fde315a9cb8312fcb433a7559"}},
+					Decrypted: map[string][]string{"X-Content-SHA256": {"ced5cde21b115b4f556ebb0a023935853366917fde315a9cb8312fcb433a7559"}},
+				},
+			},
+		},
+		"ChecksumOfSentBodyWithSecrets": {
+			args: args{
+				body: httpClient.Data{
+					Encrypted: `{"password":"{{ pw:ns:key }}"}`,
+					Decrypted: `{"password":"secret"}`,
+				},
+				checksums: []v1alpha2.BodyChecksum{
+					{Header: "Content-MD5", Algorithm: v1alpha2.ChecksumAlgorithmMD5, Encoding: v1alpha2.ChecksumEncodingHex},
+				},
+			},
+			want: want{
+				headers: httpClient.Data{
+					Encrypted: map[string][]string{"Content-MD5": {"617297b43c3a30d5e7d0cd63eeff21bf"}},
+					Decrypted: map[string][]string{"Content-MD5": {"d3a383e1627dd50e27d24966a97cc1d4"}},
+				},
+			},
+		},
+		"EmptyBody": {
+			args: args{
+				body: httpClient.Data{Encrypted: "", Decrypted: ""},
+				checksums: []v1alpha2.BodyChecksum{
+					{Header: "X-Content-SHA256", Algorithm: v1alpha2.ChecksumAlgorithmSHA256, Encoding: v1alpha2.ChecksumEncodingBase64},
+				},
+			},
+			want: want{
+				headers: httpClient.Data{
+					Encrypted: map[string][]string{"X-Content-SHA256": {"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="}},
+					Decrypted: map[string][]string{"X-Content-SHA256": {"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="}},
+				},
+			},
+		},
+		"ReplacesExistingHeader": {
+			args: args{
+				headers: httpClient.Data{
+					Encrypted: map[string][]string{"content-md5": {"stale"}},
+					Decrypted: map[string][]string{"content-md5": {"stale"}},
+				},
+				body: httpClient.Data{
+					Encrypted: `{"username":"john_doe"}`,
+					Decrypted: `{"username":"john_doe"}`,
+				},
+				checksums: []v1alpha2.BodyChecksum{
+					{Header: "Content-MD5", Algorithm: v1alpha2.ChecksumAlgorithmMD5, Encoding: v1alpha2.ChecksumEncodingBase64},
+				},
+			},
+			want: want{
+				headers: httpClient.Data{
+					Encrypted: map[string][]string{"Content-MD5": {"cuPEiHh7n58WcX5lsG1pTw=="}},
+					Decrypted: map[string][]string{"Content-MD5": {"cuPEiHh7n58WcX5lsG1pTw=="}},
+				},
+			},
+		},
+		"NoChecksums": {
+			args: args{
+				headers: httpClient.Data{
+					Encrypted: map[string][]string{"Authorization": {"Bearer token"}},
+					Decrypted: map[string][]string{"Authorization": {"Bearer token"}},
+				},
+			},
+			want: want{
+				headers: httpClient.Data{
+					Encrypted: map[string][]string{"Authorization": {"Bearer token"}},
+					Decrypted: map[string][]string{"Authorization": {"Bearer token"}},
+				},
+			},
+		},
+		"UnsupportedAlgorithm": {
+			args: args{
+				checksums: []v1alpha2.BodyChecksum{
+					{Header: "X-Content-SHA1", Algorithm: "sha1"},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errChecksumAlgorithm, "sha1", "X-Content-SHA1"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := addBodyChecksums(tc.args.headers, tc.args.body, tc.args.checksums)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("addBodyChecksums(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.headers, got); diff != "" {
+				t.Fatalf("addBodyChecksums(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+var errBoom = errors.New("boom")
+
+var testResourceRef = v1alpha2.ResourceRef{
+	Name:         "vm",
+	APIVersion:   "ec2.aws.upbound.io/v1beta1",
+	Kind:         "Instance",
+	ResourceName: "vm-1",
+}
+
+// withResourceRefs returns a copy of the parameters referencing the given resources.
+func withResourceRefs(forProvider v1alpha2.RequestParameters, refs ...v1alpha2.ResourceRef) v1alpha2.RequestParameters {
+	forProvider.ResourceRefs = refs
+	return forProvider
+}
+
+// referencedResourceKube returns a client getting the resource of testResourceRef, or failing with the given error.
+func referencedResourceKube(err error) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			if err != nil {
+				return err
+			}
+			u := obj.(*unstructured.Unstructured)
+			if u.GetKind() != testResourceRef.Kind || key.Name != testResourceRef.ResourceName {
+				return errors.Errorf("unexpected get of %s %s", u.GetKind(), key.Name)
+			}
+			u.Object = map[string]interface{}{
+				"apiVersion": testResourceRef.APIVersion,
+				"kind":       testResourceRef.Kind,
+				"metadata": map[string]interface{}{
+					"name":          "vm-1",
+					"labels":        map[string]interface{}{"zone": "eu-west-1a"},
+					"managedFields": []interface{}{map[string]interface{}{"manager": "crossplane"}},
+				},
+				"spec":   map[string]interface{}{"forProvider": map[string]interface{}{"count": int64(2)}},
+				"status": map[string]interface{}{"atProvider": map[string]interface{}{"publicIp": "10.0.0.7"}},
+			}
+			return nil
+		},
+	}
+}
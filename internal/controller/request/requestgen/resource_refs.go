@@ -0,0 +1,78 @@
+package requestgen
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	// resourcesKey is the key of the referenced resources in the jq context of the mappings.
+	resourcesKey = "resources"
+
+	errGetReferencedResource = "cannot get the %s %s referenced as %s"
+	errSecretReference       = "referenced resource %s can't be a Secret, use secret placeholders instead"
+	errKindNotAllowed        = "referenced resource %s of kind %s is not allowed by the provider, allow its kind with --resource-ref-kinds"
+	errConvertResource       = "cannot convert referenced resource %s"
+)
+
+// addReferencedResources exposes the metadata, spec and status of the referenced resources in the jq
+// context of the mappings, under .resources.<name>.
+func addReferencedResources(ctx context.Context, localKube client.Client, refs []v1alpha2.ResourceRef, jqObject map[string]interface{}) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	resources := make(map[string]interface{}, len(refs))
+	for _, ref := range refs {
+		resource, err := referencedResource(ctx, localKube, ref)
+		if err != nil {
+			return err
+		}
+		resources[ref.Name] = resource
+	}
+
+	jqObject[resourcesKey] = resources
+	return nil
+}
+
+// referencedResource returns the fields of the referenced resource exposed to the mappings.
+func referencedResource(ctx context.Context, localKube client.Client, ref v1alpha2.ResourceRef) (map[string]interface{}, error) {
+	gvk := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind)
+	if gvk.Group == "" && gvk.Kind == "Secret" {
+		return nil, errors.Errorf(errSecretReference, ref.Name)
+	}
+	if !utils.ResourceRefKindAllowed(gvk.GroupKind()) {
+		return nil, errors.Errorf(errKindNotAllowed, ref.Name, gvk.GroupKind())
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	if err := localKube.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.ResourceName}, u); err != nil {
+		return nil, errors.Wrapf(err, errGetReferencedResource, ref.Kind, ref.ResourceName, ref.Name)
+	}
+
+	// The managed fields are left out, and the numbers are converted to the types jq supports.
+	resource, err := json_util.StructToMap(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":        u.GetName(),
+			"namespace":   u.GetNamespace(),
+			"labels":      u.GetLabels(),
+			"annotations": u.GetAnnotations(),
+		},
+		"spec":   u.Object["spec"],
+		"status": u.Object["status"],
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, errConvertResource, ref.Name)
+	}
+
+	return resource, nil
+}
@@ -63,6 +63,22 @@ func GetMapping(requestParams *v1alpha2.RequestParameters, action string, logger
 	return nil, errors.Errorf(ErrMappingNotFound, action, method)
 }
 
+// ResolvedAction returns the action of the mapping, i.e. its action, or the action whose default method is its
+// method when it doesn't set one. It returns an empty string for a mapping that can't be found by action.
+func ResolvedAction(mapping v1alpha2.Mapping) string {
+	if mapping.Action != "" {
+		return mapping.Action
+	}
+
+	for action, method := range actionToMathodFactoryMap {
+		if mapping.Method == method {
+			return action
+		}
+	}
+
+	return ""
+}
+
 // getDefaultMethodByAction returns the default HTTP method for the given action.
 func getDefaultMethodByAction(action string) string {
 	if defaultAction, ok := actionToMathodFactoryMap[action]; ok {
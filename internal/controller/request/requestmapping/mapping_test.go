@@ -360,3 +360,32 @@ func Test_getDefaultMethodByAction(t *testing.T) {
 		})
 	}
 }
+
+func Test_ResolvedAction(t *testing.T) {
+	cases := map[string]struct {
+		mapping v1alpha2.Mapping
+		want    string
+	}{
+		"Action": {
+			mapping: v1alpha2.Mapping{Action: v1alpha2.ActionUpdate, Method: "PATCH"},
+			want:    v1alpha2.ActionUpdate,
+		},
+		"DefaultMethod": {
+			mapping: v1alpha2.Mapping{Method: "PUT"},
+			want:    v1alpha2.ActionUpdate,
+		},
+		"OtherMethod": {
+			mapping: v1alpha2.Mapping{Method: "PATCH"},
+			want:    "",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ResolvedAction(tc.mapping)); diff != "" {
+				t.Fatalf("ResolvedAction(...): -want action, +got action: %s", diff)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
 )
 
 // ApplyJQOnStr applies a jq query to a Request, returning the result as a string.
@@ -30,3 +31,13 @@ func ApplyJQOnStr(jqQuery string, baseMap map[string]interface{}) (string, error
 func ApplyJQOnMapStrings(keyToJQQueries map[string][]string, baseMap map[string]interface{}) (map[string][]string, error) {
 	return jq.ParseMapStrings(keyToJQQueries, baseMap)
 }
+
+// ApplyJQOnBody applies a jq query to a JSON response body, returning the transformed body as a string.
+// Bodies that are not JSON objects are returned unchanged.
+func ApplyJQOnBody(jqQuery string, body string) (string, error) {
+	if !json_util.IsJSONString(body) {
+		return body, nil
+	}
+
+	return ApplyJQOnStr(jqQuery, json_util.JsonStringToMap(body))
+}
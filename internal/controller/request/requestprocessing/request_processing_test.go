@@ -147,3 +147,48 @@ func Test_ApplyJQOnMapStrings(t *testing.T) {
 		})
 	}
 }
+
+func Test_ApplyJQOnBody(t *testing.T) {
+	type args struct {
+		jqQuery string
+		body    string
+	}
+	type want struct {
+		result string
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"TransformJSONBody": {
+			args: args{
+				jqQuery: "{ id: .id }",
+				body:    `{"id": "123", "name": "john_doe"}`,
+			},
+			want: want{
+				result: `{"id":"123"}`,
+			},
+		},
+		"NonJSONBodyUnchanged": {
+			args: args{
+				jqQuery: "{ id: .id }",
+				body:    "plain text",
+			},
+			want: want{
+				result: "plain text",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ApplyJQOnBody(tc.args.jqQuery, tc.args.body)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ApplyJQOnBody(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("ApplyJQOnBody(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package request
+
+import (
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/observe"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+// stillCreating returns true if the result of the OBSERVE request means that the object is still being
+// created rather than missing or failed, as it is within the response delay tolerance of its CREATE.
+func stillCreating(cr *v1alpha2.Request, observed ObserveRequestDetails, err error) bool {
+	tolerance := cr.Spec.ForProvider.ResponseDelayTolerance
+	if tolerance == nil || tolerance.Duration <= 0 {
+		return false
+	}
+
+	created := meta.GetExternalCreateSucceeded(cr)
+	if created.IsZero() || time.Since(created) >= tolerance.Duration {
+		return false
+	}
+
+	if err != nil {
+		return err.Error() == observe.ErrObjectNotFound
+	}
+
+	return utils.IsHTTPError(observed.Details.HttpResponse.StatusCode)
+}
@@ -0,0 +1,126 @@
+package request
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_httpExternal_Observe_ResponseDelayTolerance(t *testing.T) {
+	type args struct {
+		tolerance  *metav1.Duration
+		createdAgo time.Duration
+		statusCode int
+	}
+	type want struct {
+		exists   bool
+		upToDate bool
+		failed   int32
+	}
+
+	tolerance := &metav1.Duration{Duration: time.Minute}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotFoundWithinTolerance": {
+			args: args{
+				tolerance:  tolerance,
+				createdAgo: 10 * time.Second,
+				statusCode: 404,
+			},
+			want: want{exists: true, upToDate: true},
+		},
+		"ErrorWithinTolerance": {
+			args: args{
+				tolerance:  tolerance,
+				createdAgo: 10 * time.Second,
+				statusCode: 503,
+			},
+			want: want{exists: true, upToDate: true},
+		},
+		"NotFoundAfterTolerance": {
+			args: args{
+				tolerance:  tolerance,
+				createdAgo: 2 * time.Minute,
+				statusCode: 404,
+			},
+			want: want{exists: false},
+		},
+		"ErrorAfterTolerance": {
+			args: args{
+				tolerance:  tolerance,
+				createdAgo: 2 * time.Minute,
+				statusCode: 503,
+			},
+			want: want{exists: true, failed: 1},
+		},
+		"FoundWithinTolerance": {
+			args: args{
+				tolerance:  tolerance,
+				createdAgo: 10 * time.Second,
+				statusCode: 200,
+			},
+			// The response is checked as usual, the PUT mapping body differing from the response.
+			want: want{exists: true, upToDate: false},
+		},
+		"NotFoundWithoutTolerance": {
+			args: args{
+				createdAgo: 10 * time.Second,
+				statusCode: 404,
+			},
+			want: want{exists: false},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpRequest:  httpClient.HttpRequest{Method: method, URL: url},
+							HttpResponse: httpClient.HttpResponse{StatusCode: tc.args.statusCode, Body: `{"username":"john_doe","email":"john.doe@example.com"}`},
+						}, nil
+					},
+				},
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.ResponseDelayTolerance = tc.args.tolerance
+				r.Status.RequestDetails = v1alpha2.Mapping{Method: "POST", URL: "https://api.example.com/users"}
+				r.Status.Response = v1alpha2.Response{StatusCode: 201, Body: `{"id":"123"}`}
+				meta.SetExternalCreateSucceeded(r, time.Now().Add(-tc.args.createdAgo))
+			})
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.exists, got.ResourceExists); diff != "" {
+				t.Errorf("e.Observe(...): -want exists, +got exists: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, got.ResourceUpToDate); diff != "" {
+				t.Errorf("e.Observe(...): -want up to date, +got up to date: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.failed, cr.Status.Failed); diff != "" {
+				t.Errorf("e.Observe(...): -want failures, +got failures: %s", diff)
+			}
+		})
+	}
+}
@@ -11,5 +11,7 @@ func HttpResponseToV1alpha1Response(httpResponse httpClient.HttpResponse) v1alph
 		StatusCode: httpResponse.StatusCode,
 		Body:       httpResponse.Body,
 		Headers:    httpResponse.Headers,
+		DurationMs: httpResponse.DurationMs,
+		Proto:      httpResponse.Proto,
 	}
 }
@@ -32,6 +32,8 @@ func Test_HttpResponseToV1alpha1Response(t *testing.T) {
 					Body:       `{"email":"john.doe@example.com","name":"john_doe"}`,
 					Headers:    testHeaders,
 					StatusCode: 200,
+					DurationMs: 87,
+					Proto:      "HTTP/2.0",
 				},
 			},
 			want: want{
@@ -39,6 +41,8 @@ func Test_HttpResponseToV1alpha1Response(t *testing.T) {
 					Body:       `{"email":"john.doe@example.com","name":"john_doe"}`,
 					Headers:    testHeaders,
 					StatusCode: 200,
+					DurationMs: 87,
+					Proto:      "HTTP/2.0",
 				},
 			},
 		},
@@ -0,0 +1,111 @@
+package statushandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/pkg/errors"
+)
+
+const (
+	errParseResponseBodyTemplate  = "failed to parse the responseBodyTemplate"
+	errRenderResponseBodyTemplate = "failed to render the responseBodyTemplate"
+)
+
+// templateFuncs are the helpers available to the responseBodyTemplate. Like their sprig counterparts,
+// they take the piped value as their last argument, e.g. {{ .body.state | default "unknown" | upper }}.
+var templateFuncs = template.FuncMap{
+	"default":    defaultValue,
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"join":       join,
+	"quote":      func(v interface{}) string { return strconv.Quote(fmt.Sprint(v)) },
+	"toJson":     toJSON,
+}
+
+// responseMessage renders the responseBodyTemplate against the response. False is returned when
+// no template is set or it can't be rendered.
+func (r *requestStatusHandler) responseMessage() (string, bool) {
+	tmpl := r.forProvider.ResponseBodyTemplate
+	if tmpl == "" {
+		return "", false
+	}
+
+	message, err := renderResponseBodyTemplate(tmpl, r.resource.HttpResponse)
+	if err != nil {
+		r.logger.Debug(fmt.Sprintf("Failed to render the status message of the response: %s", err))
+		return "", false
+	}
+
+	return message, true
+}
+
+// renderResponseBodyTemplate renders the Go template with the status code, headers and body of the response.
+// A JSON body is exposed as an object.
+func renderResponseBodyTemplate(tmpl string, response interface{}) (string, error) {
+	t, err := template.New("responseBodyTemplate").Funcs(templateFuncs).Option("missingkey=zero").Parse(tmpl)
+	if err != nil {
+		return "", errors.Wrap(err, errParseResponseBodyTemplate)
+	}
+
+	responseMap, err := json_util.StructToMap(response)
+	if err != nil {
+		return "", errors.Wrap(err, errRenderResponseBodyTemplate)
+	}
+	if err := json_util.ConvertJSONStringsToMaps(&responseMap); err != nil {
+		return "", errors.Wrap(err, errRenderResponseBodyTemplate)
+	}
+
+	var message strings.Builder
+	if err := t.Execute(&message, responseMap); err != nil {
+		return "", errors.Wrap(err, errRenderResponseBodyTemplate)
+	}
+
+	return message.String(), nil
+}
+
+// defaultValue returns the given default when the value is empty.
+func defaultValue(def interface{}, value interface{}) interface{} {
+	if value == nil {
+		return def
+	}
+
+	v := reflect.ValueOf(value)
+	if v.IsZero() || ((v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.Len() == 0) {
+		return def
+	}
+
+	return value
+}
+
+// join joins the elements of a list with the separator.
+func join(sep string, values []interface{}) string {
+	elements := make([]string, len(values))
+	for i, value := range values {
+		elements[i] = fmt.Sprint(value)
+	}
+
+	return strings.Join(elements, sep)
+}
+
+// toJSON returns the JSON encoding of the value.
+func toJSON(value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,88 @@
+package statushandler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_SetRequestStatus_ResponseBodyTemplate(t *testing.T) {
+	response := httpClient.HttpResponse{
+		StatusCode: 200,
+		Body:       `{"name":"orders-db","state":"RUNNING","zones":["a","b"]}`,
+		Headers:    map[string][]string{"X-Request-Id": {"abc"}},
+	}
+
+	type args struct {
+		template string
+	}
+	type want struct {
+		message string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ResponseFields": {
+			args: args{
+				template: `{{ .body.name }} is {{ .body.state | lower }} ({{ .statusCode }})`,
+			},
+			want: want{
+				message: "orders-db is running (200)",
+			},
+		},
+		"Helpers": {
+			args: args{
+				template: `{{ .body.zones | join ", " }} / {{ .body.owner | default "no owner" | upper }} / {{ index .headers "X-Request-Id" 0 }}`,
+			},
+			want: want{
+				message: "a, b / NO OWNER / abc",
+			},
+		},
+		"InvalidTemplate": {
+			args: args{
+				template: `{{ .body.name `,
+			},
+			want: want{},
+		},
+		"NoTemplate": {
+			args: args{},
+			want: want{},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Request{
+				Spec: v1alpha2.RequestSpec{
+					ForProvider: testForProvider,
+				},
+			}
+			cr.Spec.ForProvider.ResponseBodyTemplate = tc.args.template
+
+			localKube := &test.MockClient{
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				MockGet:          test.NewMockGetFn(nil),
+			}
+			details := httpClient.HttpDetails{
+				HttpResponse: response,
+				HttpRequest:  httpClient.HttpRequest{Method: "GET", URL: "http://example.com/databases/orders-db"},
+			}
+
+			r, _ := NewStatusHandler(context.Background(), cr, details, nil, localKube, logging.NewNopLogger())
+			if err := r.SetRequestStatus(); err != nil {
+				t.Fatalf("SetRequestStatus(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.message, cr.Status.Message); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want Status.Message, +got Status.Message: %s", diff)
+			}
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
 	"github.com/crossplane-contrib/provider-http/internal/controller/request/responseconverter"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/pkg/errors"
@@ -17,6 +19,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	errResponseFailed = "HTTP request failed with status code %d: %s"
+)
+
 // RequestStatusHandler is the interface to interact with status setting for v1alpha2.Request
 type RequestStatusHandler interface {
 	SetRequestStatus() error
@@ -43,11 +49,14 @@ func (r *requestStatusHandler) SetRequestStatus() error {
 		return r.setErrorAndReturn(r.responseError)
 	}
 
-	basicSetters := []utils.SetRequestStatusFunc{
-		r.resource.SetStatusCode(),
-		r.resource.SetHeaders(),
-		r.resource.SetBody(),
-		r.resource.SetRequestDetails(),
+	basicSetters := r.responseSetters()
+
+	if r.forProvider.MirrorAtProvider {
+		basicSetters = append(basicSetters, r.resource.SetAtProvider())
+	}
+
+	if message, ok := r.responseMessage(); ok {
+		basicSetters = append(basicSetters, r.resource.SetMessage(message))
 	}
 
 	basicSetters = append(basicSetters, *r.extraSetters...)
@@ -67,10 +76,30 @@ func (r *requestStatusHandler) SetRequestStatus() error {
 	return nil
 }
 
-// setErrorAndReturn sets the error message in the status of the Request.
+// responseSetters returns the setters recording the response and the request it answers.
+func (r *requestStatusHandler) responseSetters() []utils.SetRequestStatusFunc {
+	return []utils.SetRequestStatusFunc{
+		r.resource.SetStatusCode(),
+		r.resource.SetHeaders(),
+		r.resource.SetBody(),
+		r.resource.SetDurationMs(),
+		r.resource.SetProto(),
+		r.resource.SetRequestDetails(),
+	}
+}
+
+// setErrorAndReturn sets the error message in the status of the Request. When a response was received, e.g.
+// with an unexpected status code, it is recorded too, so that e.g. an object created with an unexpected status
+// code is observed rather than created again.
 func (r *requestStatusHandler) setErrorAndReturn(err error) error {
 	r.logger.Debug("Error occurred during HTTP request", "error", err)
-	if settingError := utils.SetRequestResourceStatus(*r.resource, r.resource.SetError(err)); settingError != nil {
+	var setters []utils.SetRequestStatusFunc
+	if r.resource.HttpResponse.StatusCode != 0 {
+		setters = r.responseSetters()
+	}
+	setters = append(setters, r.resource.SetError(err))
+
+	if settingError := utils.SetRequestResourceStatus(*r.resource, setters...); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
 
@@ -78,15 +107,47 @@ func (r *requestStatusHandler) setErrorAndReturn(err error) error {
 }
 
 // incrementFailures increments the failures counter and sets the error message in the status of the Request.
+// When the error message of the API can be extracted from the response, it is also returned as an error so
+// that it is surfaced in the Synced condition.
 func (r *requestStatusHandler) incrementFailures(combinedSetters []utils.SetRequestStatusFunc) error {
-	combinedSetters = append(combinedSetters, r.resource.SetError(nil)) // should increment failures counter
+	var responseErr error
+	if message := r.responseErrorMessage(); message != "" {
+		responseErr = errors.Errorf(errResponseFailed, r.resource.HttpResponse.StatusCode, message)
+	}
+
+	combinedSetters = append(combinedSetters, r.resource.SetError(responseErr)) // should increment failures counter
 
 	if settingError := utils.SetRequestResourceStatus(*r.resource, combinedSetters...); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
 
 	r.logger.Debug(fmt.Sprintf("HTTP %s request failed with status code %s, and response %s", strconv.Itoa(r.resource.HttpResponse.StatusCode), strconv.Itoa(r.resource.HttpResponse.StatusCode), r.resource.HttpResponse.Body))
-	return nil
+	return responseErr
+}
+
+// responseErrorMessage extracts the error message of the failed response using the responseErrorMessagePath.
+// An empty message is returned when the path isn't set or doesn't select a string.
+func (r *requestStatusHandler) responseErrorMessage() string {
+	path := r.forProvider.ResponseErrorMessagePath
+	if path == "" {
+		return ""
+	}
+
+	responseMap, err := json_util.StructToMap(r.resource.HttpResponse)
+	if err != nil {
+		return ""
+	}
+	if err := json_util.ConvertJSONStringsToMaps(&responseMap); err != nil {
+		return ""
+	}
+
+	message, err := jq.ParseString(utils.NormalizeWhitespace(path), responseMap)
+	if err != nil {
+		r.logger.Debug(fmt.Sprintf("Failed to extract the error message of the response using %s: %s", path, err))
+		return ""
+	}
+
+	return message
 }
 
 func (r *requestStatusHandler) appendExtraSetters(forProvider v1alpha2.RequestParameters, combinedSetters *[]utils.SetRequestStatusFunc) {
@@ -105,7 +166,7 @@ func (r *requestStatusHandler) appendExtraSetters(forProvider v1alpha2.RequestPa
 func (r *requestStatusHandler) shouldSetCache(forProvider v1alpha2.RequestParameters) bool {
 	for _, mapping := range forProvider.Mappings {
 		response := responseconverter.HttpResponseToV1alpha1Response(r.resource.HttpResponse)
-		requestDetails, _, ok := requestgen.GenerateRequestDetails(r.resource.RequestContext, r.resource.LocalClient, mapping, forProvider, response, r.logger)
+		requestDetails, _, ok := requestgen.GenerateRequestDetails(r.resource.RequestContext, r.resource.LocalClient, mapping, forProvider, r.resource.Resource, response, r.logger)
 		if !(requestgen.IsRequestValid(requestDetails) && ok) {
 			return false
 		}
@@ -2,12 +2,14 @@ package statushandler
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request/requestgen"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
@@ -246,3 +248,195 @@ func Test_SetRequestStatus(t *testing.T) {
 		})
 	}
 }
+
+func Test_SetRequestStatus_ResponseErrorMessage(t *testing.T) {
+	type args struct {
+		responseErrorMessagePath string
+		body                     string
+	}
+	type want struct {
+		err         error
+		statusError string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"MessageExtracted": {
+			args: args{
+				responseErrorMessagePath: ".body.error.message",
+				body:                     `{"error":{"message":"name is already taken"}}`,
+			},
+			want: want{
+				err:         errors.Errorf(errResponseFailed, 409, "name is already taken"),
+				statusError: "HTTP request failed with status code 409: name is already taken",
+			},
+		},
+		"MessageNotFound": {
+			args: args{
+				responseErrorMessagePath: ".body.error.message",
+				body:                     `{"errors":["name is already taken"]}`,
+			},
+			want: want{},
+		},
+		"NoPath": {
+			args: args{
+				body: `{"error":{"message":"name is already taken"}}`,
+			},
+			want: want{},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Request{
+				Spec: v1alpha2.RequestSpec{
+					ForProvider: testForProvider,
+				},
+			}
+			cr.Spec.ForProvider.ResponseErrorMessagePath = tc.args.responseErrorMessagePath
+
+			localKube := &test.MockClient{
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				MockGet:          test.NewMockGetFn(nil),
+			}
+			details := httpClient.HttpDetails{
+				HttpResponse: httpClient.HttpResponse{
+					StatusCode: 409,
+					Body:       tc.args.body,
+				},
+				HttpRequest: testRequest,
+			}
+
+			r, _ := NewStatusHandler(context.Background(), cr, details, nil, localKube, logging.NewNopLogger())
+			gotErr := r.SetRequestStatus()
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.statusError, cr.Status.Error); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want Status.Error, +got Status.Error: %s", diff)
+			}
+			if diff := cmp.Diff(int32(1), cr.Status.Failed); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want Status.Failed, +got Status.Failed: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SetRequestStatus_AtProvider(t *testing.T) {
+	maskedRequest := httpClient.HttpRequest{
+		Method:  "POST",
+		URL:     "http://example.com/users",
+		Body:    `{"username":"john_doe","password":"{{user-secret:default:password}}"}`,
+		Headers: map[string][]string{"Authorization": {"Bearer {{auth:default:token}}"}},
+	}
+	maskedResponse := httpClient.HttpResponse{
+		StatusCode: 201,
+		Body:       `{"id":"123","apiKey":"{{user-secret:default:apiKey}}"}`,
+		Headers:    map[string][]string{"Content-Type": {"application/json"}},
+	}
+
+	type args struct {
+		mirrorAtProvider bool
+	}
+	type want struct {
+		atProvider *v1alpha2.RequestObservation
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Mirrored": {
+			args: args{
+				mirrorAtProvider: true,
+			},
+			want: want{
+				atProvider: &v1alpha2.RequestObservation{
+					Request: v1alpha2.Mapping{
+						Method:  maskedRequest.Method,
+						URL:     maskedRequest.URL,
+						Body:    maskedRequest.Body,
+						Headers: maskedRequest.Headers,
+					},
+					Response: v1alpha2.Response{
+						StatusCode: maskedResponse.StatusCode,
+						Body:       maskedResponse.Body,
+						Headers:    maskedResponse.Headers,
+					},
+				},
+			},
+		},
+		"NotMirrored": {
+			args: args{
+				mirrorAtProvider: false,
+			},
+			want: want{},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Request{
+				Spec: v1alpha2.RequestSpec{
+					ForProvider: testForProvider,
+				},
+			}
+			cr.Spec.ForProvider.MirrorAtProvider = tc.args.mirrorAtProvider
+
+			localKube := &test.MockClient{
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				MockGet:          test.NewMockGetFn(nil),
+			}
+			details := httpClient.HttpDetails{
+				HttpResponse: maskedResponse,
+				HttpRequest:  maskedRequest,
+			}
+
+			r, _ := NewStatusHandler(context.Background(), cr, details, nil, localKube, logging.NewNopLogger())
+			if err := r.SetRequestStatus(); err != nil {
+				t.Fatalf("SetRequestStatus(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.atProvider, cr.Status.AtProvider); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want Status.AtProvider, +got Status.AtProvider: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SetRequestStatus_LargeBody(t *testing.T) {
+	// A body larger than the bodies DisposableRequests may truncate with maxStatusBodyBytes.
+	body := `{"id":"123","padding":"` + strings.Repeat("x", 64<<10) + `"}`
+
+	cr := &v1alpha2.Request{
+		Spec: v1alpha2.RequestSpec{
+			ForProvider: testForProvider,
+		},
+	}
+	localKube := &test.MockClient{
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		MockGet:          test.NewMockGetFn(nil),
+	}
+	details := httpClient.HttpDetails{
+		HttpResponse: httpClient.HttpResponse{StatusCode: 201, Body: body},
+		HttpRequest:  testRequest,
+	}
+
+	r, _ := NewStatusHandler(context.Background(), cr, details, nil, localKube, logging.NewNopLogger())
+	if err := r.SetRequestStatus(); err != nil {
+		t.Fatalf("SetRequestStatus(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(body, cr.Status.Response.Body); diff != "" {
+		t.Fatalf("SetRequestStatus(...): -want Status.Response.Body, +got Status.Response.Body: %s", diff)
+	}
+
+	// The next mapping reads the stored response.
+	requestDetails, err := requestgen.GenerateValidRequestDetails(context.Background(), cr, &testPutMapping, localKube, logging.NewNopLogger())
+	if err != nil {
+		t.Fatalf("GenerateValidRequestDetails(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff("https://api.example.com/users/123", requestDetails.Url); diff != "" {
+		t.Fatalf("GenerateValidRequestDetails(...): -want url, +got url: %s", diff)
+	}
+}
@@ -0,0 +1,29 @@
+package request
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+// recordSyncedUpdate records in the status whether the response of an UPDATE request has one of the status
+// codes of updateConsideredSyncedOn, so that the current generation of the Request is considered synced.
+func recordSyncedUpdate(cr *v1alpha2.Request, details httpClient.HttpDetails, responseErr error) {
+	codes := cr.Spec.ForProvider.UpdateConsideredSyncedOn
+	if responseErr == nil && slices.Contains(codes, details.HttpResponse.StatusCode) {
+		cr.Status.LastSyncedUpdate = &v1alpha2.DriftCheck{Time: metav1.Now(), Generation: cr.Generation}
+		return
+	}
+
+	cr.Status.LastSyncedUpdate = nil
+}
+
+// syncedByUpdate returns true if the last UPDATE of the current generation of the Request had one of the
+// status codes of updateConsideredSyncedOn, in which case no drift comparison is needed.
+func syncedByUpdate(cr *v1alpha2.Request) bool {
+	update := cr.Status.LastSyncedUpdate
+	return len(cr.Spec.ForProvider.UpdateConsideredSyncedOn) > 0 && update != nil && update.Generation == cr.Generation
+}
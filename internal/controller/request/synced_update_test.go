@@ -0,0 +1,124 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_httpExternal_UpdateConsideredSyncedOn(t *testing.T) {
+	type args struct {
+		syncedOn         []int
+		updateStatusCode int
+		bumpGeneration   bool
+	}
+	type want struct {
+		recorded bool
+		upToDate bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoContentUpdateIsSynced": {
+			args: args{
+				syncedOn:         []int{http.StatusNoContent},
+				updateStatusCode: http.StatusNoContent,
+			},
+			want: want{
+				recorded: true,
+				upToDate: true,
+			},
+		},
+		"OtherStatusCodeChecksDrift": {
+			args: args{
+				syncedOn:         []int{http.StatusNoContent},
+				updateStatusCode: http.StatusOK,
+			},
+			want: want{
+				recorded: false,
+				upToDate: false,
+			},
+		},
+		"NotConfiguredChecksDrift": {
+			args: args{
+				updateStatusCode: http.StatusNoContent,
+			},
+			want: want{
+				recorded: false,
+				upToDate: false,
+			},
+		},
+		"ChangedGenerationChecksDrift": {
+			args: args{
+				syncedOn:         []int{http.StatusNoContent},
+				updateStatusCode: http.StatusNoContent,
+				bumpGeneration:   true,
+			},
+			want: want{
+				recorded: true,
+				upToDate: false,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method == http.MethodPut {
+							return httpClient.HttpDetails{
+								HttpResponse: httpClient.HttpResponse{StatusCode: tc.args.updateStatusCode},
+							}, nil
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: http.StatusOK,
+								Body:       `{"username":"jane_doe","email":"jane.doe@example.com"}`,
+							},
+						}, nil
+					},
+				},
+			}
+
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Generation = 1
+				r.Spec.ForProvider.UpdateConsideredSyncedOn = tc.args.syncedOn
+			})
+
+			if _, err := e.Update(context.Background(), cr); err != nil {
+				t.Fatalf("e.Update(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.recorded, cr.Status.LastSyncedUpdate != nil); diff != "" {
+				t.Fatalf("e.Update(...): -want synced update recorded, +got synced update recorded: %s", diff)
+			}
+
+			if tc.args.bumpGeneration {
+				cr.Generation++
+			}
+
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, got.ResourceUpToDate); diff != "" {
+				t.Fatalf("e.Observe(...): -want up to date, +got up to date: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// Package cron parses the standard 5-field cron expressions and computes their next fire times.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errFieldCount = "expected 5 fields (minute, hour, day of month, month and day of week), got %d"
+	errField      = "invalid %s field %q"
+	errValue      = "value %d out of range [%d-%d]"
+	errStep       = "invalid step %q"
+	errNumber     = "invalid number %q"
+
+	// maxSearchYears bounds the search of the next fire time, for expressions that never fire like 0 0 30 2 *.
+	maxSearchYears = 5
+)
+
+// macros are the supported shorthands of the common expressions.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// bounds are the name and the allowed values of a field.
+type bounds struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteBounds     = bounds{name: "minute", min: 0, max: 59}
+	hourBounds       = bounds{name: "hour", min: 0, max: 23}
+	dayOfMonthBounds = bounds{name: "day of month", min: 1, max: 31}
+	monthBounds      = bounds{name: "month", min: 1, max: 12}
+	// 7 is also Sunday, as in most cron implementations.
+	dayOfWeekBounds = bounds{name: "day of week", min: 0, max: 7}
+)
+
+// Schedule is a parsed cron expression. Its fire times are computed in UTC.
+type Schedule struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek map[int]bool
+
+	// The day of month and the day of week are or-ed when both are restricted (don't start with *),
+	// and-ed otherwise.
+	dayOfMonthRestricted, dayOfWeekRestricted bool
+}
+
+// Parse parses a cron expression made of the minute, hour, day of month, month and day of week
+// fields, each being *, a number, a range (1-5), a list (1,15) or a step (*/10, 0-30/5), or one
+// of the @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly macros.
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if expanded, ok := macros[spec]; ok {
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.Errorf(errFieldCount, len(fields))
+	}
+
+	s := &Schedule{
+		dayOfMonthRestricted: !strings.HasPrefix(fields[2], "*"),
+		dayOfWeekRestricted:  !strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	if s.minutes, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hours, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if s.daysOfMonth, err = parseField(fields[2], dayOfMonthBounds); err != nil {
+		return nil, err
+	}
+	if s.months, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if s.daysOfWeek, err = parseField(fields[4], dayOfWeekBounds); err != nil {
+		return nil, err
+	}
+	if s.daysOfWeek[7] {
+		s.daysOfWeek[0] = true
+	}
+
+	return s, nil
+}
+
+// parseField returns the values of a comma-separated field.
+func parseField(field string, b bounds) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		if err := parsePart(part, b, values); err != nil {
+			return nil, errors.Wrapf(err, errField, b.name, field)
+		}
+	}
+
+	return values, nil
+}
+
+// parsePart adds the values of a part of a field, * or a number or a range, with an optional step.
+func parsePart(part string, b bounds, values map[int]bool) error {
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+	step := 1
+	if hasStep {
+		var err error
+		if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+			return errors.Errorf(errStep, stepPart)
+		}
+	}
+
+	start, end := b.min, b.max
+	if rangePart != "*" {
+		low, high, isRange := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = parseValue(low, b); err != nil {
+			return err
+		}
+		end = start
+		if isRange {
+			if end, err = parseValue(high, b); err != nil {
+				return err
+			}
+		} else if hasStep {
+			// 5/15 is every 15 from 5 to the maximum value.
+			end = b.max
+		}
+	}
+
+	for v := start; v <= end; v += step {
+		values[v] = true
+	}
+
+	return nil
+}
+
+func parseValue(value string, b bounds) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.Errorf(errNumber, value)
+	}
+	if v < b.min || v > b.max {
+		return 0, errors.Errorf(errValue, v, b.min, b.max)
+	}
+
+	return v, nil
+}
+
+// Next returns the first fire time of the schedule strictly after the given time, or the zero time
+// when the schedule never fires.
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !s.months[int(t.Month())]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !s.hours[t.Hour()]:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !s.minutes[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// dayMatches returns true if the day of the given time matches the day of month and the day of week.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dayOfMonth, dayOfWeek := s.daysOfMonth[t.Day()], s.daysOfWeek[int(t.Weekday())]
+	if s.dayOfMonthRestricted && s.dayOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+
+	return dayOfMonth && dayOfWeek
+}
@@ -0,0 +1,131 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_Parse(t *testing.T) {
+	cases := map[string]struct {
+		spec string
+		err  error
+	}{
+		"Valid": {
+			spec: "0 */6 * * *",
+		},
+		"ListsRangesAndSteps": {
+			spec: "0,30 8-18 1-15/2 1,6 1-5",
+		},
+		"Macro": {
+			spec: "@daily",
+		},
+		"MissingField": {
+			spec: "0 */6 * *",
+			err:  errors.Errorf(errFieldCount, 4),
+		},
+		"OutOfRange": {
+			spec: "60 * * * *",
+			err:  errors.Wrapf(errors.Errorf(errValue, 60, 0, 59), errField, "minute", "60"),
+		},
+		"InvalidStep": {
+			spec: "* */0 * * *",
+			err:  errors.Wrapf(errors.Errorf(errStep, "0"), errField, "hour", "*/0"),
+		},
+		"InvalidNumber": {
+			spec: "* * * jan *",
+			err:  errors.Wrapf(errors.Errorf(errNumber, "jan"), errField, "month", "jan"),
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(tc.spec)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Parse(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Next(t *testing.T) {
+	// A Wednesday.
+	after := time.Date(2024, time.May, 15, 10, 17, 42, 0, time.UTC)
+
+	cases := map[string]struct {
+		spec  string
+		after time.Time
+		want  time.Time
+	}{
+		"EverySixHours": {
+			spec:  "0 */6 * * *",
+			after: after,
+			want:  time.Date(2024, time.May, 15, 12, 0, 0, 0, time.UTC),
+		},
+		"EveryMinute": {
+			spec:  "* * * * *",
+			after: after,
+			want:  time.Date(2024, time.May, 15, 10, 18, 0, 0, time.UTC),
+		},
+		"StrictlyAfter": {
+			spec:  "0 12 * * *",
+			after: time.Date(2024, time.May, 15, 12, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, time.May, 16, 12, 0, 0, 0, time.UTC),
+		},
+		"Weekdays": {
+			spec:  "30 9 * * 1-5",
+			after: time.Date(2024, time.May, 17, 10, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, time.May, 20, 9, 30, 0, 0, time.UTC),
+		},
+		"SundayAsSeven": {
+			spec:  "0 0 * * 7",
+			after: after,
+			want:  time.Date(2024, time.May, 19, 0, 0, 0, 0, time.UTC),
+		},
+		"DayOfMonthOrDayOfWeek": {
+			spec:  "0 0 1 * 5",
+			after: after,
+			want:  time.Date(2024, time.May, 17, 0, 0, 0, 0, time.UTC),
+		},
+		"NextYear": {
+			spec:  "@yearly",
+			after: after,
+			want:  time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"LeapDay": {
+			spec:  "0 0 29 2 *",
+			after: after,
+			want:  time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"InUTC": {
+			spec:  "0 0 * * *",
+			after: time.Date(2024, time.May, 15, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60)),
+			want:  time.Date(2024, time.May, 17, 0, 0, 0, 0, time.UTC),
+		},
+		"NeverFires": {
+			spec:  "0 0 30 2 *",
+			after: after,
+			want:  time.Time{},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			s, err := Parse(tc.spec)
+			if err != nil {
+				t.Fatalf("Parse(...): unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, s.Next(tc.after)); diff != "" {
+				t.Errorf("Next(...): -want time, +got time: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package datapatcher
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+// ConnectionDetails returns the connection details extracted from the response. The details whose field
+// is missing from the response are not published. The values are sensitive and are never logged.
+func ConnectionDetails(logger logging.Logger, response *httpClient.HttpResponse, details []common.ConnectionDetail, preserveRawBody bool) managed.ConnectionDetails {
+	if len(details) == 0 || response.StatusCode == 0 {
+		return nil
+	}
+
+	dataMap, err := prepareDataMap(response, preserveRawBody)
+	if err != nil {
+		logger.Info("Failed to parse the response, no connection detail is published", "error", err.Error())
+		return nil
+	}
+
+	connectionDetails := managed.ConnectionDetails{}
+	for _, detail := range details {
+		if value := extractValueToPatch(logger, dataMap, detail.ResponseJQ); value != "" {
+			connectionDetails[detail.Key] = []byte(value)
+		}
+	}
+
+	return connectionDetails
+}
+
+// StoredConnectionDetails returns the connection details extracted from a response stored in the status, whose
+// injected values are masked by secret placeholders. The placeholders are resolved first, so that the actual
+// values are published rather than the placeholders.
+func StoredConnectionDetails(ctx context.Context, localKube client.Client, logger logging.Logger, response httpClient.HttpResponse, details []common.ConnectionDetail, preserveRawBody bool, policy string) managed.ConnectionDetails {
+	if len(details) == 0 || response.StatusCode == 0 {
+		return nil
+	}
+
+	body, err := PatchSecretsIntoString(ctx, localKube, response.Body, policy, logger)
+	if err == nil {
+		response.Headers, err = PatchSecretsIntoHeaders(ctx, localKube, response.Headers, policy, logger)
+	}
+	if err != nil {
+		logger.Info("Failed to resolve the secrets of the stored response, no connection detail is published", "error", err.Error())
+		return nil
+	}
+	response.Body = body
+
+	return ConnectionDetails(logger, &response, details, preserveRawBody)
+}
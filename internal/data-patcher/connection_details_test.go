@@ -0,0 +1,97 @@
+package datapatcher
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func TestConnectionDetails(t *testing.T) {
+	type args struct {
+		response *httpClient.HttpResponse
+		details  []common.ConnectionDetail
+	}
+
+	response := &httpClient.HttpResponse{
+		StatusCode: 201,
+		Headers:    map[string][]string{"Location": {"https://api.example.com/databases/42"}},
+		Body:       `{"endpoint": "db.example.com", "port": 5432, "tls": true, "credentials": {"password": "s3cr3t"}}`,
+	}
+
+	cases := map[string]struct {
+		args args
+		want managed.ConnectionDetails
+	}{
+		"FromResponseBody": {
+			args: args{
+				response: response,
+				details: []common.ConnectionDetail{
+					{Key: "endpoint", ResponseJQ: ".body.endpoint"},
+					{Key: "port", ResponseJQ: ".body.port"},
+					{Key: "tls", ResponseJQ: ".body.tls"},
+					{Key: "password", ResponseJQ: ".body.credentials.password"},
+				},
+			},
+			want: managed.ConnectionDetails{
+				"endpoint": []byte("db.example.com"),
+				"port":     []byte("5432"),
+				"tls":      []byte("true"),
+				"password": []byte("s3cr3t"),
+			},
+		},
+		"FromResponseHeadersAndStatusCode": {
+			args: args{
+				response: response,
+				details: []common.ConnectionDetail{
+					{Key: "url", ResponseJQ: ".headers.Location[0]"},
+					{Key: "status", ResponseJQ: ".statusCode"},
+				},
+			},
+			want: managed.ConnectionDetails{
+				"url":    []byte("https://api.example.com/databases/42"),
+				"status": []byte("201"),
+			},
+		},
+		"MissingFieldNotPublished": {
+			args: args{
+				response: response,
+				details: []common.ConnectionDetail{
+					{Key: "endpoint", ResponseJQ: ".body.endpoint"},
+					{Key: "username", ResponseJQ: ".body.credentials.username"},
+				},
+			},
+			want: managed.ConnectionDetails{
+				"endpoint": []byte("db.example.com"),
+			},
+		},
+		"NoConnectionDetails": {
+			args: args{
+				response: response,
+			},
+			want: nil,
+		},
+		"NoResponse": {
+			args: args{
+				response: &httpClient.HttpResponse{},
+				details:  []common.ConnectionDetail{{Key: "endpoint", ResponseJQ: ".body.endpoint"}},
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got := ConnectionDetails(logging.NewNopLogger(), tc.args.response, tc.args.details, false)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ConnectionDetails(...): -want connection details, +got connection details: %s", diff)
+			}
+		})
+	}
+}
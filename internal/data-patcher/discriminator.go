@@ -0,0 +1,38 @@
+package datapatcher
+
+import (
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const errDiscriminator = "Warning, couldn't evaluate the secret injection discriminator, only the configs without discriminator value are applied: %s"
+
+// selectSecretConfigs returns the secret injection configs applying to the response: all of them without
+// discriminator, or else the ones whose discriminator value is the result of the discriminator, along with
+// the ones without discriminator value.
+func selectSecretConfigs(logger logging.Logger, response *httpClient.HttpResponse, secretConfigs []common.SecretInjectionConfig, discriminator string, preserveRawBody bool) []common.SecretInjectionConfig {
+	if discriminator == "" {
+		return secretConfigs
+	}
+
+	value := ""
+	dataMap, err := prepareDataMap(response, preserveRawBody)
+	if err != nil {
+		logger.Info(fmt.Sprintf(errDiscriminator, err.Error()))
+	} else {
+		value = extractValueToPatch(logger, dataMap, discriminator)
+	}
+
+	selected := make([]common.SecretInjectionConfig, 0, len(secretConfigs))
+	for _, config := range secretConfigs {
+		if config.DiscriminatorValue == "" || config.DiscriminatorValue == value {
+			selected = append(selected, config)
+		}
+	}
+
+	return selected
+}
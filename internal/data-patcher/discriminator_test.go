@@ -0,0 +1,91 @@
+package datapatcher
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_selectSecretConfigs(t *testing.T) {
+	discriminator := `if .body.error then "error" else "success" end`
+
+	successConfig := common.SecretInjectionConfig{
+		SecretRef:          common.SecretRef{Name: "credentials", Namespace: "default"},
+		KeyMappings:        []common.KeyInjection{{SecretKey: "token", ResponseJQ: ".body.token"}},
+		DiscriminatorValue: "success",
+	}
+	errorConfig := common.SecretInjectionConfig{
+		SecretRef:          common.SecretRef{Name: "last-error", Namespace: "default"},
+		KeyMappings:        []common.KeyInjection{{SecretKey: "code", ResponseJQ: ".body.error.code"}},
+		DiscriminatorValue: "error",
+	}
+	commonConfig := common.SecretInjectionConfig{
+		SecretRef:   common.SecretRef{Name: "status", Namespace: "default"},
+		KeyMappings: []common.KeyInjection{{SecretKey: "status", ResponseJQ: ".statusCode"}},
+	}
+	configs := []common.SecretInjectionConfig{successConfig, errorConfig, commonConfig}
+
+	type args struct {
+		response      *httpClient.HttpResponse
+		discriminator string
+	}
+	type want struct {
+		configs []common.SecretInjectionConfig
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SuccessShapedResponse": {
+			args: args{
+				response:      &httpClient.HttpResponse{StatusCode: 200, Body: `{"token":"s3cr3t"}`},
+				discriminator: discriminator,
+			},
+			want: want{
+				configs: []common.SecretInjectionConfig{successConfig, commonConfig},
+			},
+		},
+		"ErrorShapedResponse": {
+			args: args{
+				response:      &httpClient.HttpResponse{StatusCode: 200, Body: `{"error":{"code":"QUOTA_EXCEEDED"}}`},
+				discriminator: discriminator,
+			},
+			want: want{
+				configs: []common.SecretInjectionConfig{errorConfig, commonConfig},
+			},
+		},
+		"DiscriminatorNotMatching": {
+			args: args{
+				response:      &httpClient.HttpResponse{StatusCode: 200, Body: `{"token":"s3cr3t"}`},
+				discriminator: `.body.kind`,
+			},
+			want: want{
+				configs: []common.SecretInjectionConfig{commonConfig},
+			},
+		},
+		"NoDiscriminator": {
+			args: args{
+				response: &httpClient.HttpResponse{StatusCode: 200, Body: `{"error":{"code":"QUOTA_EXCEEDED"}}`},
+			},
+			want: want{
+				configs: configs,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got := selectSecretConfigs(logging.NewNopLogger(), tc.args.response, configs, tc.args.discriminator, false)
+			if diff := cmp.Diff(tc.want.configs, got); diff != "" {
+				t.Errorf("selectSecretConfigs(...): -want configs, +got configs: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package datapatcher
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+const (
+	errUnknownEncoding = "unknown encoding %s"
+	errDecodeValue     = "cannot decode the value as %s"
+)
+
+// encodeValue encodes the value with the given encoding, or decodes it when decode is true.
+// Values are returned as is without encoding.
+func encodeValue(value string, encoding string, decode bool) (string, error) {
+	var decoded []byte
+	var err error
+
+	switch encoding {
+	case "", common.EncodingNone:
+		return value, nil
+	case common.EncodingBase64:
+		if !decode {
+			return base64.StdEncoding.EncodeToString([]byte(value)), nil
+		}
+		decoded, err = base64.StdEncoding.DecodeString(value)
+	case common.EncodingBase64URL:
+		if !decode {
+			return base64.URLEncoding.EncodeToString([]byte(value)), nil
+		}
+		decoded, err = base64.URLEncoding.DecodeString(value)
+	case common.EncodingHex:
+		if !decode {
+			return hex.EncodeToString([]byte(value)), nil
+		}
+		decoded, err = hex.DecodeString(value)
+	default:
+		return "", errors.Errorf(errUnknownEncoding, encoding)
+	}
+
+	if err != nil {
+		return "", errors.Wrapf(err, errDecodeValue, encoding)
+	}
+
+	return string(decoded), nil
+}
@@ -0,0 +1,88 @@
+package datapatcher
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+func TestEncodeValue(t *testing.T) {
+	type args struct {
+		value    string
+		encoding string
+		decode   bool
+	}
+	type want struct {
+		value string
+		err   error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoEncoding": {
+			args: args{value: "s3cr3t?"},
+			want: want{value: "s3cr3t?"},
+		},
+		"NoneEncoding": {
+			args: args{value: "s3cr3t?", encoding: common.EncodingNone, decode: true},
+			want: want{value: "s3cr3t?"},
+		},
+		"EncodeBase64": {
+			args: args{value: "s3cr3t?>", encoding: common.EncodingBase64},
+			want: want{value: "czNjcjN0Pz4="},
+		},
+		"EncodeBase64URL": {
+			args: args{value: "\xfb\xff", encoding: common.EncodingBase64URL},
+			want: want{value: "-_8="},
+		},
+		"EncodeHex": {
+			args: args{value: "s3cr3t", encoding: common.EncodingHex},
+			want: want{value: "733363723374"},
+		},
+		"DecodeBase64": {
+			args: args{value: "czNjcjN0Pz4=", encoding: common.EncodingBase64, decode: true},
+			want: want{value: "s3cr3t?>"},
+		},
+		"DecodeBase64URL": {
+			args: args{value: "-_8=", encoding: common.EncodingBase64URL, decode: true},
+			want: want{value: "\xfb\xff"},
+		},
+		"DecodeHex": {
+			args: args{value: "733363723374", encoding: common.EncodingHex, decode: true},
+			want: want{value: "s3cr3t"},
+		},
+		"DecodeInvalidBase64": {
+			args: args{value: "not base64!", encoding: common.EncodingBase64, decode: true},
+			want: want{err: errors.Wrapf(base64.CorruptInputError(3), errDecodeValue, common.EncodingBase64)},
+		},
+		"DecodeInvalidHex": {
+			args: args{value: "zz", encoding: common.EncodingHex, decode: true},
+			want: want{err: errors.Wrapf(errors.New("encoding/hex: invalid byte: U+007A 'z'"), errDecodeValue, common.EncodingHex)},
+		},
+		"UnknownEncoding": {
+			args: args{value: "s3cr3t", encoding: "base32"},
+			want: want{err: errors.Errorf(errUnknownEncoding, "base32")},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, err := encodeValue(tc.args.value, tc.args.encoding, tc.args.decode)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("encodeValue(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.value, got); diff != "" {
+				t.Errorf("encodeValue(...): -want value, +got value: %s", diff)
+			}
+		})
+	}
+}
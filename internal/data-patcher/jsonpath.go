@@ -0,0 +1,74 @@
+package datapatcher
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+)
+
+// extractValue extracts a value from a data map with the given engine, jq unless jsonpath is set.
+func extractValue(logger logging.Logger, dataMap map[string]interface{}, requestFieldPath string, engine string) string {
+	if engine == common.ExtractionEngineJSONPath {
+		return extractJSONPathValueToPatch(logger, dataMap, requestFieldPath)
+	}
+
+	return extractValueToPatch(logger, dataMap, requestFieldPath)
+}
+
+// isFieldPath returns true if the value is a field path of the given engine rather than a literal.
+func isFieldPath(value string, engine string) bool {
+	if engine == common.ExtractionEngineJSONPath {
+		return isJSONPath(value)
+	}
+
+	return jq.IsJQQuery(value)
+}
+
+// isJSONPath returns true if the value is a JSONPath expression, e.g. $.body.token or {.body.token}.
+func isJSONPath(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.HasPrefix(value, "$") || strings.HasPrefix(value, "{")
+}
+
+// extractJSONPathValueToPatch extracts a value from a data map with a JSONPath expression, either
+// standard, e.g. $.body.token, or in the kubectl template format, e.g. {.body.token}. Strings are
+// returned as is, booleans and numbers are converted to strings, and other values or missing
+// fields result in an empty string.
+func extractJSONPathValueToPatch(logger logging.Logger, dataMap map[string]interface{}, requestFieldPath string) string {
+	template := strings.TrimSpace(requestFieldPath)
+	if !strings.HasPrefix(template, "{") {
+		template = "{" + template + "}"
+	}
+
+	parser := jsonpath.New(requestFieldPath)
+	if err := parser.Parse(template); err != nil {
+		logger.Info(fmt.Sprintf("Failed to parse the JSONPath %s: %s, setting an empty string instead.", requestFieldPath, err))
+		return ""
+	}
+
+	results, err := parser.FindResults(dataMap)
+	if err != nil || len(results) == 0 || len(results[0]) == 0 {
+		logger.Info(fmt.Sprintf("Failed to find the field %s: %v, setting an empty string instead.", requestFieldPath, err))
+		return ""
+	}
+
+	switch value := results[0][0].Interface().(type) {
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(value)
+	default:
+		logger.Info(fmt.Sprintf("Failed to parse the field %s as a string, boolean, or number, setting an empty string instead.", requestFieldPath))
+		return ""
+	}
+}
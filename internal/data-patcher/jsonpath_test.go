@@ -0,0 +1,133 @@
+package datapatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func TestExtractJSONPathValueToPatch(t *testing.T) {
+	type args struct {
+		dataMap          map[string]interface{}
+		requestFieldPath string
+	}
+
+	cases := map[string]struct {
+		args args
+		want string
+	}{
+		"ShouldExtractStringValue": {
+			args: args{
+				dataMap:          map[string]interface{}{"stringField": "testString"},
+				requestFieldPath: "$.stringField",
+			},
+			want: "testString",
+		},
+		"ShouldExtractStringValueFromTemplate": {
+			args: args{
+				dataMap:          map[string]interface{}{"stringField": "testString"},
+				requestFieldPath: "{.stringField}",
+			},
+			want: "testString",
+		},
+		"ShouldExtractBooleanValueAsString": {
+			args: args{
+				dataMap:          map[string]interface{}{"booleanField": true},
+				requestFieldPath: "$.booleanField",
+			},
+			want: "true",
+		},
+		"ShouldExtractNumericValueAsString": {
+			args: args{
+				dataMap:          map[string]interface{}{"numberField": 123.45},
+				requestFieldPath: "$.numberField",
+			},
+			want: "123.45",
+		},
+		"ShouldExtractArrayElementWithBracketNotation": {
+			args: args{
+				dataMap: map[string]interface{}{
+					"headers": map[string]interface{}{"X-Token": []interface{}{"token"}},
+				},
+				requestFieldPath: "$.headers['X-Token'][0]",
+			},
+			want: "token",
+		},
+		"ShouldReturnEmptyStringIfFieldNotFound": {
+			args: args{
+				dataMap:          map[string]interface{}{"existingField": "value"},
+				requestFieldPath: "$.nonExistentField",
+			},
+			want: "",
+		},
+		"ShouldReturnEmptyStringIfUnsupportedType": {
+			args: args{
+				dataMap:          map[string]interface{}{"arrayField": []string{"value1", "value2"}},
+				requestFieldPath: "$.arrayField",
+			},
+			want: "",
+		},
+		"ShouldReturnEmptyStringIfInvalidExpression": {
+			args: args{
+				dataMap:          map[string]interface{}{"stringField": "testString"},
+				requestFieldPath: "$.stringField[",
+			},
+			want: "",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			result := extractJSONPathValueToPatch(logging.NewNopLogger(), tc.args.dataMap, tc.args.requestFieldPath)
+			if diff := cmp.Diff(tc.want, result); diff != "" {
+				t.Errorf("extractJSONPathValueToPatch(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func TestApplySecretConfig_JSONPath(t *testing.T) {
+	data := &httpClient.HttpResponse{
+		StatusCode: 200,
+		Body:       `{"token":"secret-token","id":42}`,
+	}
+	secretConfig := common.SecretInjectionConfig{
+		SecretRef: common.SecretRef{Name: "creds", Namespace: "ns"},
+		Engine:    common.ExtractionEngineJSONPath,
+		KeyMappings: []common.KeyInjection{
+			{SecretKey: "token", ResponseJQ: "$.body.token"},
+			{SecretKey: "status", ResponseJQ: "$.statusCode"},
+		},
+		Metadata: common.Metadata{
+			Labels: map[string]string{"id": "{.body.id}", "team": "payments"},
+		},
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "ns"}}
+	localKube := &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}
+	if err := applySecretConfig(context.Background(), localKube, logging.NewNopLogger(), data, secretConfig, secret, false); err != nil {
+		t.Fatalf("applySecretConfig(...): unexpected error: %s", err)
+	}
+
+	wantData := map[string][]byte{"token": []byte("secret-token"), "status": []byte("200")}
+	if diff := cmp.Diff(wantData, secret.Data); diff != "" {
+		t.Errorf("applySecretConfig(...): -want data, +got data: %s", diff)
+	}
+	wantLabels := map[string]string{"id": "42", "team": "payments"}
+	if diff := cmp.Diff(wantLabels, secret.Labels); diff != "" {
+		t.Errorf("applySecretConfig(...): -want labels, +got labels: %s", diff)
+	}
+	if diff := cmp.Diff(`{"token":"{{creds:ns:token}}","id":42}`, data.Body); diff != "" {
+		t.Errorf("applySecretConfig(...): -want body, +got body: %s", diff)
+	}
+}
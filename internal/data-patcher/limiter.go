@@ -0,0 +1,47 @@
+package datapatcher
+
+import (
+	"context"
+	"sync"
+)
+
+// secretOperations bounds the number of concurrent secret reads and patches of all the reconciles, so that
+// many resources injecting secrets at once don't overload the API server. Nil means unbounded.
+var (
+	secretOperationsMu sync.RWMutex
+	secretOperations   chan struct{}
+)
+
+// SetMaxConcurrentSecretOperations bounds the number of concurrent secret reads and patches shared by all the
+// reconciles. Values that are not positive remove the bound.
+func SetMaxConcurrentSecretOperations(maxOperations int) {
+	secretOperationsMu.Lock()
+	defer secretOperationsMu.Unlock()
+
+	if maxOperations <= 0 {
+		secretOperations = nil
+		return
+	}
+	secretOperations = make(chan struct{}, maxOperations)
+}
+
+// withSecretOperationSlot runs the given secret operation once a slot is available, or returns the error of
+// the context when it is done first.
+func withSecretOperationSlot(ctx context.Context, operation func() error) error {
+	secretOperationsMu.RLock()
+	slots := secretOperations
+	secretOperationsMu.RUnlock()
+
+	if slots == nil {
+		return operation()
+	}
+
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-slots }()
+
+	return operation()
+}
@@ -0,0 +1,132 @@
+package datapatcher
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// inFlightClient is a mock client recording the maximum number of concurrent secret operations.
+type inFlightClient struct {
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (c *inFlightClient) operation() error {
+	current := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+
+	for {
+		observed := c.maxInFlight.Load()
+		if current <= observed || c.maxInFlight.CompareAndSwap(observed, current) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	return nil
+}
+
+func (c *inFlightClient) client() client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Name = key.Name
+			return c.operation()
+		},
+		MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+			return c.operation()
+		},
+	}
+}
+
+func Test_withSecretOperationSlot_Throttles(t *testing.T) {
+	const injections = 10
+
+	cases := map[string]struct {
+		maxOperations int
+		want          int32
+	}{
+		"ThrottledToTheLimit": {
+			maxOperations: 2,
+			want:          2,
+		},
+		"SingleOperation": {
+			maxOperations: 1,
+			want:          1,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			SetMaxConcurrentSecretOperations(tc.maxOperations)
+			defer SetMaxConcurrentSecretOperations(0)
+
+			kube := &inFlightClient{}
+			secretConfig := common.SecretInjectionConfig{
+				SecretRef:   common.SecretRef{Name: "creds", Namespace: "ns"},
+				KeyMappings: []common.KeyInjection{{SecretKey: "token", ResponseJQ: ".body.token"}},
+			}
+
+			var wg sync.WaitGroup
+			errs := make(chan error, injections)
+			for i := 0; i < injections; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					response := &httpClient.HttpResponse{StatusCode: 200, Body: `{"token":"new-token"}`}
+					errs <- patchResponseDataToSecret(context.Background(), kube.client(), logging.NewNopLogger(), response, nil, secretConfig, false)
+				}()
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				if err != nil {
+					t.Fatalf("patchResponseDataToSecret(...): unexpected error: %s", err)
+				}
+			}
+			if diff := cmp.Diff(tc.want, kube.maxInFlight.Load()); diff != "" {
+				t.Errorf("patchResponseDataToSecret(...): -want max concurrent operations, +got max concurrent operations: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_withSecretOperationSlot_ContextDone(t *testing.T) {
+	SetMaxConcurrentSecretOperations(1)
+	defer SetMaxConcurrentSecretOperations(0)
+
+	release := make(chan struct{})
+	acquired := make(chan struct{})
+	go func() {
+		_ = withSecretOperationSlot(context.Background(), func() error {
+			close(acquired)
+			<-release
+			return nil
+		})
+	}()
+	<-acquired
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := withSecretOperationSlot(ctx, func() error {
+		t.Error("withSecretOperationSlot(...): operation ran without a slot")
+		return nil
+	})
+	if diff := cmp.Diff(context.Canceled, err, test.EquateErrors()); diff != "" {
+		t.Errorf("withSecretOperationSlot(...): -want error, +got error: %s", diff)
+	}
+}
@@ -7,7 +7,9 @@ import (
 
 	"context"
 
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
@@ -18,6 +20,9 @@ const (
 	errEmptyKey    = "Warning, value at field %s is empty, skipping secret update for: %s"
 	errConvertData = "failed to convert data to map"
 	errPatchFailed = "failed to patch secret, %s"
+
+	errPlaceholderUnresolved  = "cannot resolve the secret placeholder %s"
+	errPlaceholderKeyNotFound = "key %s not found in secret %s:%s"
 )
 
 const (
@@ -63,8 +68,9 @@ func replacePlaceholderWithSecretValue(originalString, old string, secret *corev
 	return strings.ReplaceAll(originalString, old, replacementString)
 }
 
-// patchSecretsToValue patches secrets referenced in the provided value.
-func patchSecretsToValue(ctx context.Context, localKube client.Client, valueToHandle string, logger logging.Logger) (string, error) {
+// patchSecretsToValue patches secrets referenced in the provided value. The placeholders referencing a missing
+// secret or key are handled according to the given unresolved secret policy.
+func patchSecretsToValue(ctx context.Context, localKube client.Client, valueToHandle string, policy string, logger logging.Logger) (string, error) {
 	placeholders := removeDuplicates(findPlaceholders(valueToHandle))
 	for _, placeholder := range placeholders {
 
@@ -72,10 +78,24 @@ func patchSecretsToValue(ctx context.Context, localKube client.Client, valueToHa
 		if !ok {
 			return valueToHandle, nil
 		}
-		secret, err := kubehandler.GetSecret(ctx, localKube, name, namespace)
+		var secret *corev1.Secret
+		err := withSecretOperationSlot(ctx, func() (err error) {
+			secret, err = kubehandler.GetSecret(ctx, localKube, name, namespace)
+			return err
+		})
+		if kerrors.IsNotFound(err) && leaveUnresolved(policy) {
+			continue
+		}
 		if err != nil {
 			logger.Info(fmt.Sprintf(errPatchFailed, err.Error()))
-			return "", err
+			return "", errors.Wrapf(err, errPlaceholderUnresolved, placeholder)
+		}
+
+		if _, ok := secret.Data[key]; !ok {
+			if leaveUnresolved(policy) {
+				continue
+			}
+			return "", errors.Wrapf(errors.Errorf(errPlaceholderKeyNotFound, key, namespace, name), errPlaceholderUnresolved, placeholder)
 		}
 
 		valueToHandle = replacePlaceholderWithSecretValue(valueToHandle, placeholder, secret, key)
@@ -86,24 +106,24 @@ func patchSecretsToValue(ctx context.Context, localKube client.Client, valueToHa
 }
 
 // patchSecretsInMap traverses a map and patches secrets into any string values.
-func patchSecretsInMap(ctx context.Context, localKube client.Client, data map[string]interface{}, logger logging.Logger) error {
+func patchSecretsInMap(ctx context.Context, localKube client.Client, data map[string]interface{}, policy string, logger logging.Logger) error {
 	for key, value := range data {
 		switch v := value.(type) {
 		case string:
-			patchedValue, err := patchSecretsToValue(ctx, localKube, v, logger)
+			patchedValue, err := patchSecretsToValue(ctx, localKube, v, policy, logger)
 			if err != nil {
 				return err
 			}
 			data[key] = patchedValue
 
 		case map[string]interface{}:
-			err := patchSecretsInMap(ctx, localKube, v, logger)
+			err := patchSecretsInMap(ctx, localKube, v, policy, logger)
 			if err != nil {
 				return err
 			}
 
 		case []interface{}:
-			err := patchSecretsInSlice(ctx, localKube, v, logger)
+			err := patchSecretsInSlice(ctx, localKube, v, policy, logger)
 			if err != nil {
 				return err
 			}
@@ -8,9 +8,14 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	errorspkg "github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
 )
 
 func createSpecificSecret(name, namespace, key, value string) *corev1.Secret {
@@ -295,7 +300,7 @@ func Test_patchSecretsToValue(t *testing.T) {
 		tc := tc // Create local copies of loop variables
 
 		t.Run(name, func(t *testing.T) {
-			got, err := patchSecretsToValue(context.Background(), tc.args.localKube, tc.args.valueToHandle, logging.NewNopLogger())
+			got, err := patchSecretsToValue(context.Background(), tc.args.localKube, tc.args.valueToHandle, "", logging.NewNopLogger())
 			if err != nil {
 				t.Fatalf("patchSecretsToValue(...): unexpected error: %v", err)
 			}
@@ -305,3 +310,91 @@ func Test_patchSecretsToValue(t *testing.T) {
 		})
 	}
 }
+
+func Test_patchSecretsToValue_Unresolved(t *testing.T) {
+	placeholderData := "{{name:namespace:key}}"
+	errNotFound := kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "name")
+	errBoom := errors.New("boom")
+
+	missingSecret := &test.MockClient{MockGet: test.NewMockGetFn(errNotFound)}
+	missingKey := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			*obj.(*corev1.Secret) = *createSpecificSecret("name", "namespace", "another-key", "value")
+			return nil
+		}),
+	}
+
+	type args struct {
+		policy    string
+		localKube client.Client
+	}
+
+	type want struct {
+		result string
+		err    error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"MissingSecretFails": {
+			args: args{
+				localKube: missingSecret,
+			},
+			want: want{
+				err: errorspkg.Wrapf(errorspkg.Wrap(errNotFound, "failed to get secret name:namespace"), errPlaceholderUnresolved, placeholderData),
+			},
+		},
+		"MissingKeyFails": {
+			args: args{
+				policy:    common.UnresolvedSecretPolicyFail,
+				localKube: missingKey,
+			},
+			want: want{
+				err: errorspkg.Wrapf(errorspkg.Errorf(errPlaceholderKeyNotFound, "key", "namespace", "name"), errPlaceholderUnresolved, placeholderData),
+			},
+		},
+		"MissingSecretLeftUnresolved": {
+			args: args{
+				policy:    common.UnresolvedSecretPolicyLeaveUnresolved,
+				localKube: missingSecret,
+			},
+			want: want{
+				result: "data -> " + placeholderData,
+			},
+		},
+		"MissingKeyLeftUnresolved": {
+			args: args{
+				policy:    common.UnresolvedSecretPolicyLeaveUnresolved,
+				localKube: missingKey,
+			},
+			want: want{
+				result: "data -> " + placeholderData,
+			},
+		},
+		"GetSecretErrorFailsWhenLeftUnresolved": {
+			args: args{
+				policy:    common.UnresolvedSecretPolicyLeaveUnresolved,
+				localKube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			want: want{
+				err: errorspkg.Wrapf(errorspkg.Wrap(errBoom, "failed to get secret name:namespace"), errPlaceholderUnresolved, placeholderData),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := patchSecretsToValue(context.Background(), tc.args.localKube, "data -> "+placeholderData, tc.args.policy, logging.NewNopLogger())
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("patchSecretsToValue(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("patchSecretsToValue(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
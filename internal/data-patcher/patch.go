@@ -7,6 +7,7 @@ import (
 	"github.com/crossplane-contrib/provider-http/apis/common"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
@@ -15,13 +16,15 @@ import (
 )
 
 const (
-	errPatchToReferencedSecret = "cannot patch to referenced secret"
-	errPatchDataToSecret       = "Warning, couldn't patch data from request to secret %s:%s, error: %s"
+	errPatchToReferencedSecret  = "cannot patch to referenced secret"
+	errPatchDataToSecret        = "Warning, couldn't patch data from request to secret %s:%s, error: %s"
+	errAtomicPatchDataToSecrets = "Warning, couldn't patch data from request to secrets, error: %s"
 )
 
-// PatchSecretsIntoString patches secrets into the provided string.
-func PatchSecretsIntoString(ctx context.Context, localKube client.Client, str string, logger logging.Logger) (string, error) {
-	return patchSecretsToValue(ctx, localKube, str, logger)
+// PatchSecretsIntoString patches secrets into the provided string. The placeholders referencing a missing
+// secret or key are handled according to the given unresolved secret policy.
+func PatchSecretsIntoString(ctx context.Context, localKube client.Client, str string, policy string, logger logging.Logger) (string, error) {
+	return patchSecretsToValue(ctx, localKube, str, policy, logger)
 }
 
 // PatchSecretsIntoHeaders takes a map of headers and applies security measures to
@@ -29,12 +32,12 @@ func PatchSecretsIntoString(ctx context.Context, localKube client.Client, str st
 // to avoid modifying the original map and iterates over the copied map
 // to process each list of headers. It then applies the necessary modifications
 // to each header using patchSecretsToValue function.
-func PatchSecretsIntoHeaders(ctx context.Context, localKube client.Client, headers map[string][]string, logger logging.Logger) (map[string][]string, error) {
+func PatchSecretsIntoHeaders(ctx context.Context, localKube client.Client, headers map[string][]string, policy string, logger logging.Logger) (map[string][]string, error) {
 	headersCopy := copyHeaders(headers)
 
 	for _, headersList := range headersCopy {
 		for i, header := range headersList {
-			newHeader, err := patchSecretsToValue(ctx, localKube, header, logger)
+			newHeader, err := patchSecretsToValue(ctx, localKube, header, policy, logger)
 			if err != nil {
 				return nil, err
 			}
@@ -55,51 +58,82 @@ func copyHeaders(headers map[string][]string) map[string][]string {
 	return headersCopy
 }
 
-// patchResponseDataToSecret patches response data into a Kubernetes secret.
-func patchResponseDataToSecret(ctx context.Context, localKube client.Client, logger logging.Logger, data *httpClient.HttpResponse, owner metav1.Object, secretConfig common.SecretInjectionConfig) error {
-	secret, err := kubehandler.GetOrCreateSecret(ctx, localKube, secretConfig.SecretRef.Name, secretConfig.SecretRef.Namespace, owner)
-	if err != nil {
-		return err
-	}
+// patchResponseDataToSecret patches response data into a Kubernetes secret. The patch is retried on conflicts
+// with the latest version of the secret, and the sensitive values are only masked in the response once it succeeds.
+func patchResponseDataToSecret(ctx context.Context, localKube client.Client, logger logging.Logger, data *httpClient.HttpResponse, owner metav1.Object, secretConfig common.SecretInjectionConfig, preserveRawBody bool) error {
+	return utils.RetryOnConflict(ctx, utils.DefaultConflictRetry, func() error {
+		return withSecretOperationSlot(ctx, func() error {
+			secret, err := kubehandler.GetOrCreateSecret(ctx, localKube, secretConfig.SecretRef.Name, secretConfig.SecretRef.Namespace, owner)
+			if err != nil {
+				return err
+			}
 
-	err = applySecretConfig(ctx, localKube, logger, data, secretConfig, secret)
-	if err != nil {
-		return err
-	}
+			attempt := copyResponse(data)
+			if err := applySecretConfig(ctx, localKube, logger, attempt, secretConfig, secret, preserveRawBody); err != nil {
+				return err
+			}
 
-	return nil
+			*data = *attempt
+			return nil
+		})
+	})
 }
 
-// applySecretConfig applies the secret configuration to the secret.
-func applySecretConfig(ctx context.Context, localKube client.Client, logger logging.Logger, data *httpClient.HttpResponse, secretConfig common.SecretInjectionConfig, secret *v1.Secret) error {
-	var err error
+// copyResponse returns a copy of the response that can be masked without modifying the original.
+func copyResponse(data *httpClient.HttpResponse) *httpClient.HttpResponse {
+	responseCopy := *data
+	if data.Headers != nil {
+		responseCopy.Headers = copyHeaders(data.Headers)
+	}
+	return &responseCopy
+}
 
-	if secretConfig.KeyMappings != nil {
-		for _, mapping := range secretConfig.KeyMappings {
-			err = updateSecretWithPatchedValue(ctx, localKube, logger, data, secret, mapping.SecretKey, mapping.ResponseJQ)
-			if err != nil {
-				return errors.Wrap(err, errPatchToReferencedSecret)
-			}
-		}
-	} else {
-		err = updateSecretWithPatchedValue(ctx, localKube, logger, data, secret, secretConfig.SecretKey, secretConfig.ResponsePath)
-		if err != nil {
-			return errors.Wrap(err, errPatchToReferencedSecret)
-		}
+// applySecretConfig applies the secret configuration to the secret. All its keys, labels and annotations
+// are patched in a single update of the secret.
+func applySecretConfig(ctx context.Context, localKube client.Client, logger logging.Logger, data *httpClient.HttpResponse, secretConfig common.SecretInjectionConfig, secret *v1.Secret, preserveRawBody bool) error {
+	mappings := secretConfig.KeyMappings
+	if mappings == nil {
+		mappings = []common.KeyInjection{{SecretKey: secretConfig.SecretKey, ResponseJQ: secretConfig.ResponsePath}}
+	}
+
+	dataUpdated, err := patchSecretData(logger, data, secret, mappings, secretConfig.MissingFieldStrategy, secretConfig.Engine, preserveRawBody)
+	if err != nil {
+		return errors.Wrap(err, errPatchToReferencedSecret)
 	}
 
-	err = updateSecretLabelsAndAnnotations(ctx, localKube, logger, data, secret, secretConfig.Metadata.Labels, secretConfig.Metadata.Annotations)
+	metadataUpdated, err := patchSecretLabelsAndAnnotations(logger, data, secret, secretConfig.Metadata.Labels, secretConfig.Metadata.Annotations, secretConfig.Engine, preserveRawBody)
 	if err != nil {
 		return errors.Wrap(err, errPatchToReferencedSecret)
 	}
 
+	if !dataUpdated && !metadataUpdated {
+		return nil
+	}
+
+	if err := kubehandler.UpdateSecret(ctx, localKube, secret); err != nil {
+		return errors.Wrap(err, errPatchToReferencedSecret)
+	}
+
 	return nil
 }
 
 // ApplyResponseDataToSecrets applies response data to Kubernetes Secrets as specified in the resource's SecretInjectionConfigs.
 // For each SecretInjectionConfig, it extracts a value from the HTTP response and patches it into the referenced Secret.
-// Ownership of the Secret is optionally set based on the configuration.
-func ApplyResponseDataToSecrets(ctx context.Context, localKube client.Client, logger logging.Logger, response *httpClient.HttpResponse, secretConfigs []common.SecretInjectionConfig, cr metav1.Object) {
+// Ownership of the Secret is optionally set based on the configuration. When atomic is true, the configs are applied
+// all or nothing and the secrets already patched are rolled back when one of them fails, which is returned as an
+// error. When a discriminator is given, only the configs matching its result, or without discriminator value, are
+// applied. The response body is also exposed verbatim, as rawBody, when preserveRawBody is true.
+func ApplyResponseDataToSecrets(ctx context.Context, localKube client.Client, logger logging.Logger, response *httpClient.HttpResponse, secretConfigs []common.SecretInjectionConfig, discriminator string, atomic bool, preserveRawBody bool, cr metav1.Object) error {
+	secretConfigs = selectSecretConfigs(logger, response, secretConfigs, discriminator, preserveRawBody)
+
+	if atomic {
+		err := applyResponseDataToSecretsAtomically(ctx, localKube, logger, response, secretConfigs, preserveRawBody, cr)
+		if err != nil {
+			logger.Info(fmt.Sprintf(errAtomicPatchDataToSecrets, err.Error()))
+		}
+		return err
+	}
+
 	for _, ref := range secretConfigs {
 		var owner metav1.Object = nil
 
@@ -107,19 +141,21 @@ func ApplyResponseDataToSecrets(ctx context.Context, localKube client.Client, lo
 			owner = cr
 		}
 
-		err := patchResponseDataToSecret(ctx, localKube, logger, response, owner, ref)
+		err := patchResponseDataToSecret(ctx, localKube, logger, response, owner, ref, preserveRawBody)
 		if err != nil {
 			logger.Info(fmt.Sprintf(errPatchDataToSecret, ref.SecretRef.Name, ref.SecretRef.Namespace, err.Error()))
 		}
 	}
+
+	return nil
 }
 
 // PatchSecretsIntoMap takes a map of string to interface{} and patches secrets
 // into any string values within the map, including nested maps and slices.
-func PatchSecretsIntoMap(ctx context.Context, localKube client.Client, data map[string]interface{}, logger logging.Logger) (map[string]interface{}, error) {
+func PatchSecretsIntoMap(ctx context.Context, localKube client.Client, data map[string]interface{}, policy string, logger logging.Logger) (map[string]interface{}, error) {
 	dataCopy := copyMap(data)
 
-	err := patchSecretsInMap(ctx, localKube, dataCopy, logger)
+	err := patchSecretsInMap(ctx, localKube, dataCopy, policy, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -153,12 +189,12 @@ func deepCopy(value interface{}) interface{} {
 }
 
 // patchSecretsInSlice traverses a slice and patches secrets into any string values.
-func patchSecretsInSlice(ctx context.Context, localKube client.Client, data []interface{}, logger logging.Logger) error {
+func patchSecretsInSlice(ctx context.Context, localKube client.Client, data []interface{}, policy string, logger logging.Logger) error {
 	for i, item := range data {
 		switch v := item.(type) {
 		case string:
 			// Patch secrets in string values
-			patchedValue, err := patchSecretsToValue(ctx, localKube, v, logger)
+			patchedValue, err := patchSecretsToValue(ctx, localKube, v, policy, logger)
 			if err != nil {
 				return err
 			}
@@ -166,14 +202,14 @@ func patchSecretsInSlice(ctx context.Context, localKube client.Client, data []in
 
 		case map[string]interface{}:
 			// Recursively patch secrets in nested maps
-			err := patchSecretsInMap(ctx, localKube, v, logger)
+			err := patchSecretsInMap(ctx, localKube, v, policy, logger)
 			if err != nil {
 				return err
 			}
 
 		case []interface{}:
 			// Recursively patch secrets in nested slices
-			err := patchSecretsInSlice(ctx, localKube, v, logger)
+			err := patchSecretsInSlice(ctx, localKube, v, policy, logger)
 			if err != nil {
 				return err
 			}
@@ -4,11 +4,17 @@ import (
 	"context"
 	"testing"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -76,7 +82,7 @@ func TestPatchSecretsIntoBody(t *testing.T) {
 		tc := tc // Create local copies of loop variables
 
 		t.Run(name, func(t *testing.T) {
-			got, gotErr := PatchSecretsIntoString(tc.args.ctx, tc.args.localKube, tc.args.body, logging.NewNopLogger())
+			got, gotErr := PatchSecretsIntoString(tc.args.ctx, tc.args.localKube, tc.args.body, "", logging.NewNopLogger())
 			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
 				t.Fatalf("isUpToDate(...): -want error, +got error: %s", diff)
 			}
@@ -185,7 +191,7 @@ func TestPatchSecretsIntoHeaders(t *testing.T) {
 		tc := tc // Create local copies of loop variables
 
 		t.Run(name, func(t *testing.T) {
-			got, gotErr := PatchSecretsIntoHeaders(tc.args.ctx, tc.args.localKube, tc.args.headers, logging.NewNopLogger())
+			got, gotErr := PatchSecretsIntoHeaders(tc.args.ctx, tc.args.localKube, tc.args.headers, "", logging.NewNopLogger())
 			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
 				t.Fatalf("isUpToDate(...): -want error, +got error: %s", diff)
 			}
@@ -195,3 +201,236 @@ func TestPatchSecretsIntoHeaders(t *testing.T) {
 		})
 	}
 }
+
+func Test_patchResponseDataToSecret_Conflicts(t *testing.T) {
+	secretConfig := common.SecretInjectionConfig{
+		SecretRef:   common.SecretRef{Name: "creds", Namespace: "ns"},
+		KeyMappings: []common.KeyInjection{{SecretKey: "token", ResponseJQ: ".body.token"}},
+	}
+
+	type want struct {
+		secret   map[string][]byte
+		body     string
+		conflict bool
+	}
+
+	cases := map[string]struct {
+		conflicts int
+		want      want
+	}{
+		"SucceedsAfterConflicts": {
+			conflicts: utils.DefaultConflictRetry.Attempts - 1,
+			want: want{
+				secret: map[string][]byte{"token": []byte("new-token")},
+				body:   `{"token":"{{creds:ns:token}}"}`,
+			},
+		},
+		"GivesUpAfterTheCap": {
+			conflicts: utils.DefaultConflictRetry.Attempts,
+			want: want{
+				secret:   map[string][]byte{"token": []byte("old-token")},
+				body:     `{"token":"new-token"}`,
+				conflict: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			store := &secretStore{
+				secrets:   map[string]map[string][]byte{"creds": {"token": []byte("old-token")}},
+				conflicts: map[string]int{"creds": tc.conflicts},
+			}
+			response := &httpClient.HttpResponse{StatusCode: 200, Body: `{"token":"new-token"}`}
+
+			err := patchResponseDataToSecret(context.Background(), store.client(), logging.NewNopLogger(), response, nil, secretConfig, false)
+			if diff := cmp.Diff(tc.want.conflict, kerrors.IsConflict(err)); diff != "" {
+				t.Fatalf("patchResponseDataToSecret(...): -want conflict, +got conflict: %s (error: %v)", diff, err)
+			}
+			if !tc.want.conflict && err != nil {
+				t.Fatalf("patchResponseDataToSecret(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.secret, store.secrets["creds"]); diff != "" {
+				t.Errorf("patchResponseDataToSecret(...): -want secret, +got secret: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, response.Body); diff != "" {
+				t.Errorf("patchResponseDataToSecret(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_patchResponseDataToSecret_CrossNamespace(t *testing.T) {
+	// The service account of the provider is only allowed to write secrets in the given namespaces.
+	writableNamespaces := map[string]bool{"crossplane-system": true, "team-b": true}
+
+	type want struct {
+		created   map[string]map[string][]byte
+		body      string
+		forbidden bool
+		err       string
+	}
+
+	cases := map[string]struct {
+		namespace string
+		want      want
+	}{
+		"InjectedInOtherNamespace": {
+			namespace: "team-b",
+			want: want{
+				created: map[string]map[string][]byte{"team-b/creds": {"token": []byte("new-token")}},
+				body:    `{"token":"{{creds:team-b:token}}"}`,
+			},
+		},
+		"PermissionDenied": {
+			namespace: "team-c",
+			want: want{
+				created:   map[string]map[string][]byte{},
+				body:      `{"token":"new-token"}`,
+				forbidden: true,
+				err: "the provider is not allowed to create secret creds:team-c, grant its service account the create verb on secrets in namespace team-c: " +
+					`secrets "creds" is forbidden: User "system:serviceaccount:crossplane-system:provider-http" cannot create resource "secrets" in API group "" in the namespace "team-c"`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			created := map[string]map[string][]byte{}
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, _ client.Object) error {
+					return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+				},
+				MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					if !writableNamespaces[obj.GetNamespace()] {
+						return kerrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, obj.GetName(),
+							errors.Errorf(`User "system:serviceaccount:crossplane-system:provider-http" cannot create resource "secrets" in API group "" in the namespace "%s"`, obj.GetNamespace()))
+					}
+					created[obj.GetNamespace()+"/"+obj.GetName()] = obj.(*corev1.Secret).Data
+					return nil
+				},
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					created[obj.GetNamespace()+"/"+obj.GetName()] = obj.(*corev1.Secret).Data
+					return nil
+				},
+			}
+			secretConfig := common.SecretInjectionConfig{
+				SecretRef:   common.SecretRef{Name: "creds", Namespace: tc.namespace},
+				KeyMappings: []common.KeyInjection{{SecretKey: "token", ResponseJQ: ".body.token"}},
+			}
+			response := &httpClient.HttpResponse{StatusCode: 200, Body: `{"token":"new-token"}`}
+
+			err := patchResponseDataToSecret(context.Background(), kube, logging.NewNopLogger(), response, nil, secretConfig, false)
+			if diff := cmp.Diff(tc.want.forbidden, kerrors.IsForbidden(err)); diff != "" {
+				t.Fatalf("patchResponseDataToSecret(...): -want forbidden, +got forbidden: %s (error: %v)", diff, err)
+			}
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.want.err, gotErr); diff != "" {
+				t.Errorf("patchResponseDataToSecret(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.created, created); diff != "" {
+				t.Errorf("patchResponseDataToSecret(...): -want secrets, +got secrets: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, response.Body); diff != "" {
+				t.Errorf("patchResponseDataToSecret(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_applySecretConfig_SingleUpdate(t *testing.T) {
+	secretConfig := common.SecretInjectionConfig{
+		SecretRef: common.SecretRef{Name: "oauth", Namespace: "ns"},
+		KeyMappings: []common.KeyInjection{
+			{SecretKey: "access-token", ResponseJQ: ".body.access_token"},
+			{SecretKey: "refresh-token", ResponseJQ: ".body.refresh_token"},
+			{SecretKey: "id-token", ResponseJQ: ".body.id_token"},
+		},
+		Metadata: common.Metadata{
+			Labels: map[string]string{"token-type": ".body.token_type"},
+		},
+	}
+
+	type want struct {
+		data    map[string][]byte
+		updates int
+		err     error
+	}
+
+	cases := map[string]struct {
+		missingFieldStrategy string
+		want                 want
+	}{
+		"AllKeysInOneUpdate": {
+			want: want{
+				data: map[string][]byte{
+					"access-token":  []byte("eyJhbGciOi"),
+					"refresh-token": []byte("def50200"),
+					"id-token":      []byte(""),
+				},
+				updates: 1,
+			},
+		},
+		"PreservedKeysInOneUpdate": {
+			missingFieldStrategy: common.MissingFieldStrategyPreserve,
+			want: want{
+				data: map[string][]byte{
+					"access-token":  []byte("eyJhbGciOi"),
+					"refresh-token": []byte("def50200"),
+					"id-token":      []byte("old-id"),
+				},
+				updates: 1,
+			},
+		},
+		"NoUpdateOnMissingField": {
+			missingFieldStrategy: common.MissingFieldStrategyFail,
+			want: want{
+				data:    map[string][]byte{"id-token": []byte("old-id")},
+				updates: 0,
+				err:     errors.Wrap(errors.Errorf(errMissingResponseField, ".body.id_token", "id-token"), errPatchToReferencedSecret),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			updates := 0
+			localKube := &test.MockClient{
+				MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+					updates++
+					return nil
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "oauth", Namespace: "ns"},
+				Data:       map[string][]byte{"id-token": []byte("old-id")},
+			}
+			config := secretConfig
+			config.MissingFieldStrategy = tc.missingFieldStrategy
+			response := &httpClient.HttpResponse{
+				StatusCode: 200,
+				Body:       `{"access_token":"eyJhbGciOi","refresh_token":"def50200","token_type":"Bearer"}`,
+			}
+
+			err := applySecretConfig(context.Background(), localKube, logging.NewNopLogger(), response, config, secret, false)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("applySecretConfig(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.data, secret.Data); diff != "" {
+				t.Errorf("applySecretConfig(...): -want data, +got data: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.updates, updates); diff != "" {
+				t.Errorf("applySecretConfig(...): -want updates, +got updates: %s", diff)
+			}
+		})
+	}
+}
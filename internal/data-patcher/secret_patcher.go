@@ -1,93 +1,131 @@
 package datapatcher
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/jq"
 	json_util "github.com/crossplane-contrib/provider-http/internal/json"
-	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// statusCodeFieldPath is the field path of the HTTP status code within the response data map.
+	statusCodeFieldPath = ".statusCode"
+
+	// rawBodyKey is the key of the verbatim response body within the response data map.
+	rawBodyKey = "rawBody"
 )
 
 const (
 	logUpdateSecretLabelsAndAnnotations = "Updating labels and annotations for Secret [%s/%s]"
 	logNoUpdatesRequired                = "No updates required for labels and annotations of Secret [%s/%s]"
+	logPreserveMissingField             = "The field %s is missing from the response, preserving the secret key %s"
+
+	errMissingResponseField = "the field %s of the secret key %s is missing from the response"
+	errEncodeSecretValue    = "cannot encode the value of the secret key %s"
 )
 
-// updateSecretLabelsAndAnnotations updates the labels and annotations of a Kubernetes Secret
-// based on the provided maps. It ensures the Secret is only updated if there are actual changes.
-func updateSecretLabelsAndAnnotations(ctx context.Context, kubeClient client.Client, logger logging.Logger, data *httpClient.HttpResponse, secret *corev1.Secret, labels map[string]string, annotations map[string]string) error {
+// patchSecretLabelsAndAnnotations patches the labels and annotations of a Kubernetes Secret
+// based on the provided maps. Returns true if any changes were made.
+func patchSecretLabelsAndAnnotations(logger logging.Logger, data *httpClient.HttpResponse, secret *corev1.Secret, labels map[string]string, annotations map[string]string, engine string, preserveRawBody bool) (bool, error) {
 	updated := false
 
-	dataMap, err := prepareDataMap(data)
+	dataMap, err := prepareDataMap(data, preserveRawBody)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Update labels
 	if secret.Labels == nil && labels != nil {
 		secret.Labels = make(map[string]string)
 	}
-	updated = syncMap(logger, &secret.Labels, labels, dataMap) || updated
+	updated = syncMap(logger, &secret.Labels, labels, dataMap, engine) || updated
 
 	// Update annotations
 	if secret.Annotations == nil && annotations != nil {
 		secret.Annotations = make(map[string]string)
 	}
-	updated = syncMap(logger, &secret.Annotations, annotations, dataMap) || updated
+	updated = syncMap(logger, &secret.Annotations, annotations, dataMap, engine) || updated
 
-	// Update the Secret only if changes were made
 	if updated {
 		logger.Debug(fmt.Sprintf(logUpdateSecretLabelsAndAnnotations, secret.Namespace, secret.Name))
-		return kubehandler.UpdateSecret(ctx, kubeClient, secret)
+	} else {
+		logger.Debug(fmt.Sprintf(logNoUpdatesRequired, secret.Namespace, secret.Name))
 	}
 
-	logger.Debug(fmt.Sprintf(logNoUpdatesRequired, secret.Namespace, secret.Name))
-	return nil
+	return updated, nil
 }
 
-// updateSecretWithPatchedValue extracts a specified value from an HTTP response,
-// transforms it if necessary, and patches it into a Kubernetes Secret. Additionally,
-// it replaces the sensitive value in the HTTP response body and headers with a placeholder.
-func updateSecretWithPatchedValue(ctx context.Context, kubeClient client.Client, logger logging.Logger, data *httpClient.HttpResponse, secret *corev1.Secret, secretKey string, requestFieldPath string) error {
+// patchSecretData extracts the values of the key mappings from an HTTP response with the given engine,
+// and patches them into the data of a Kubernetes Secret. The fields missing from the response are
+// handled according to the missing field strategy, and no key is patched when one of them fails.
+// Additionally, it replaces the sensitive values in the HTTP response. Returns true if any changes were made.
+func patchSecretData(logger logging.Logger, data *httpClient.HttpResponse, secret *corev1.Secret, mappings []common.KeyInjection, missingFieldStrategy string, engine string, preserveRawBody bool) (bool, error) {
 	// Step 1: Parse and prepare data
-	dataMap, err := prepareDataMap(data)
+	dataMap, err := prepareDataMap(data, preserveRawBody)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	// Step 2: Extract the value to patch
-	valueToPatch := extractValueToPatch(logger, dataMap, requestFieldPath)
-
-	// Step 3: Check if the value is already present
-	if isSecretDataUpToDate(secret, secretKey, valueToPatch) {
-		return nil
+	// Step 2: Extract and encode the values to patch, before patching any of them
+	extractedValues := make([]string, len(mappings))
+	valuesToPatch := make([]string, len(mappings))
+	for i, mapping := range mappings {
+		extractedValues[i] = extractValue(logger, dataMap, mapping.ResponseJQ, engine)
+		if extractedValues[i] == "" && missingFieldStrategy == common.MissingFieldStrategyFail {
+			return false, errors.Errorf(errMissingResponseField, mapping.ResponseJQ, mapping.SecretKey)
+		}
+		if valuesToPatch[i], err = encodeValue(extractedValues[i], mapping.Encoding, mapping.Decode); err != nil {
+			return false, errors.Wrapf(err, errEncodeSecretValue, mapping.SecretKey)
+		}
 	}
 
-	// Step 4: Update the secret data
-	updateSecretData(secret, secretKey, valueToPatch)
+	updated := false
+	for i, mapping := range mappings {
+		valueToPatch := valuesToPatch[i]
+		if extractedValues[i] == "" && missingFieldStrategy == common.MissingFieldStrategyPreserve {
+			logger.Debug(fmt.Sprintf(logPreserveMissingField, mapping.ResponseJQ, mapping.SecretKey))
+			continue
+		}
+
+		// Step 3: Check if the value is already present
+		if isSecretDataUpToDate(secret, mapping.SecretKey, valueToPatch) {
+			continue
+		}
+
+		// Step 4: Update the secret data
+		updateSecretData(secret, mapping.SecretKey, valueToPatch)
+		updated = true
 
-	// Step 5: Replace sensitive values in the HTTP response
-	replaceSensitiveValues(data, secret, secretKey, valueToPatch)
+		// Step 5: Replace sensitive values in the HTTP response, as extracted from it, the status code is not considered sensitive
+		if !isStatusCodeFieldPath(mapping.ResponseJQ) {
+			replaceSensitiveValues(data, secret, mapping.SecretKey, extractedValues[i])
+		}
+	}
 
-	// Step 5: Save the updated secret to the Kubernetes API
-	return kubehandler.UpdateSecret(ctx, kubeClient, secret)
+	return updated, nil
 }
 
 // prepareDataMap converts an HTTP response into a map for parsing and manipulation.
-func prepareDataMap(data *httpClient.HttpResponse) (map[string]interface{}, error) {
+// The body is available parsed, as body, and verbatim, as rawBody, when preserveRawBody is set.
+func prepareDataMap(data *httpClient.HttpResponse, preserveRawBody bool) (map[string]interface{}, error) {
 	dataMap, err := json_util.StructToMap(data)
 	if err != nil {
 		return nil, errors.Wrap(err, errConvertData)
 	}
-	json_util.ConvertJSONStringsToMaps(&dataMap)
+	if err := json_util.ConvertJSONStringsToMaps(&dataMap); err != nil {
+		return nil, errors.Wrap(err, errConvertData)
+	}
+	if preserveRawBody {
+		dataMap[rawBodyKey] = data.Body
+	}
 	return dataMap, nil
 }
 
@@ -144,6 +182,15 @@ func replaceSensitiveValues(data *httpClient.HttpResponse, secret *corev1.Secret
 	}
 }
 
+// isStatusCodeFieldPath checks if the given field path refers to the HTTP status code of the response.
+// Masking the status code would replace unrelated occurrences of the same number in the body and headers.
+func isStatusCodeFieldPath(requestFieldPath string) bool {
+	// JSONPath expressions of the status code are $.statusCode or {.statusCode}.
+	path := utils.NormalizeWhitespace(requestFieldPath)
+	path = strings.TrimPrefix(strings.TrimSuffix(strings.TrimPrefix(path, "{"), "}"), "$")
+	return path == statusCodeFieldPath
+}
+
 // isSecretDataUpToDate checks if the specified key in the Secret already contains the given value.
 func isSecretDataUpToDate(secret *corev1.Secret, secretKey, valueToPatch string) bool {
 	currentValue, exists := secret.Data[secretKey]
@@ -153,13 +200,13 @@ func isSecretDataUpToDate(secret *corev1.Secret, secretKey, valueToPatch string)
 // syncMap synchronizes a Secret's existing map (labels or annotations) with the desired state.
 // It adds or updates keys from the desired map and removes keys not present in the desired map.
 // Returns true if any changes were made.
-func syncMap(logger logging.Logger, existing *map[string]string, desired map[string]string, dataMap map[string]interface{}) bool {
+func syncMap(logger logging.Logger, existing *map[string]string, desired map[string]string, dataMap map[string]interface{}, engine string) bool {
 	changed := false
 
 	// Add or update keys
 	for key, value := range desired {
-		if jq.IsJQQuery(value) {
-			newValue := extractValueToPatch(logger, dataMap, value)
+		if isFieldPath(value, engine) {
+			newValue := extractValue(logger, dataMap, value, engine)
 			if len(newValue) != 0 {
 				value = newValue
 			}
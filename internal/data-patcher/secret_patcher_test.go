@@ -3,9 +3,12 @@ package datapatcher
 import (
 	"testing"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -157,7 +160,7 @@ func TestSyncMap(t *testing.T) {
 		tc := tc // Create local copies of loop variables
 
 		t.Run(name, func(t *testing.T) {
-			changed := syncMap(logging.NewNopLogger(), &tc.args.existing, tc.args.desired, tc.args.dataMap)
+			changed := syncMap(logging.NewNopLogger(), &tc.args.existing, tc.args.desired, tc.args.dataMap, common.ExtractionEngineJQ)
 
 			if changed != tc.want.changed {
 				t.Errorf("syncMap(...): expected changed = %v, got %v", tc.want.changed, changed)
@@ -439,9 +442,273 @@ func TestExtractValueToPatch(t *testing.T) {
 	}
 }
 
+func TestExtractValueToPatch_ParsedAndRawBody(t *testing.T) {
+	data := &httpClient.HttpResponse{
+		Body: `{"cert":"-----BEGIN CERTIFICATE-----"}`,
+	}
+
+	cases := map[string]struct {
+		requestFieldPath string
+		want             string
+	}{
+		"ShouldExtractParsedBodyField": {
+			requestFieldPath: ".body.cert",
+			want:             "-----BEGIN CERTIFICATE-----",
+		},
+		"ShouldExtractRawBody": {
+			requestFieldPath: ".rawBody",
+			want:             `{"cert":"-----BEGIN CERTIFICATE-----"}`,
+		},
+	}
+
+	// Both paths are extracted from the same data map, as in a single reconcile.
+	dataMap, err := prepareDataMap(data, true)
+	if err != nil {
+		t.Fatalf("prepareDataMap(...): unexpected error: %v", err)
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			result := extractValueToPatch(logging.NewNopLogger(), dataMap, tc.requestFieldPath)
+
+			if diff := cmp.Diff(tc.want, result); diff != "" {
+				t.Errorf("extractValueToPatch(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPatchSecretData(t *testing.T) {
+	type args struct {
+		data                 *httpClient.HttpResponse
+		secretData           map[string][]byte
+		mappings             []common.KeyInjection
+		missingFieldStrategy string
+	}
+
+	type want struct {
+		data    map[string][]byte
+		body    string
+		updated bool
+		err     error
+	}
+
+	oauthResponse := `{"access_token": "eyJhbGciOi", "refresh_token": "def50200", "expires_in": 3600}`
+	oauthMappings := []common.KeyInjection{
+		{SecretKey: "access-token", ResponseJQ: ".body.access_token"},
+		{SecretKey: "refresh-token", ResponseJQ: ".body.refresh_token"},
+		{SecretKey: "expires-in", ResponseJQ: ".body.expires_in"},
+	}
+	missingFieldMappings := []common.KeyInjection{
+		{SecretKey: "access-token", ResponseJQ: ".body.access_token"},
+		{SecretKey: "id-token", ResponseJQ: ".body.id_token"},
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldInjectStatusCodeWithoutMaskingResponse": {
+			args: args{
+				data: &httpClient.HttpResponse{
+					StatusCode: 201,
+					Body:       `{"id": 201}`,
+				},
+				mappings: []common.KeyInjection{{SecretKey: "status-code", ResponseJQ: ".statusCode"}},
+			},
+			want: want{
+				data: map[string][]byte{
+					"status-code": []byte("201"),
+				},
+				body:    `{"id": 201}`,
+				updated: true,
+			},
+		},
+		"ShouldInjectBodyFieldAndMaskResponse": {
+			args: args{
+				data: &httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"token": "secret-token"}`,
+				},
+				mappings: []common.KeyInjection{{SecretKey: "token", ResponseJQ: ".body.token"}},
+			},
+			want: want{
+				data: map[string][]byte{
+					"token": []byte("secret-token"),
+				},
+				body:    `{"token": "{{name:namespace:token}}"}`,
+				updated: true,
+			},
+		},
+		"ShouldInjectMultipleKeysFromOneResponse": {
+			args: args{
+				data:     &httpClient.HttpResponse{StatusCode: 200, Body: oauthResponse},
+				mappings: oauthMappings,
+			},
+			want: want{
+				data: map[string][]byte{
+					"access-token":  []byte("eyJhbGciOi"),
+					"refresh-token": []byte("def50200"),
+					"expires-in":    []byte("3600"),
+				},
+				body:    `{"access_token": "{{name:namespace:access-token}}", "refresh_token": "{{name:namespace:refresh-token}}", "expires_in": {{name:namespace:expires-in}}}`,
+				updated: true,
+			},
+		},
+		"ShouldNotUpdateUpToDateKeys": {
+			args: args{
+				data: &httpClient.HttpResponse{StatusCode: 200, Body: oauthResponse},
+				secretData: map[string][]byte{
+					"access-token":  []byte("eyJhbGciOi"),
+					"refresh-token": []byte("def50200"),
+					"expires-in":    []byte("3600"),
+				},
+				mappings: oauthMappings,
+			},
+			want: want{
+				data: map[string][]byte{
+					"access-token":  []byte("eyJhbGciOi"),
+					"refresh-token": []byte("def50200"),
+					"expires-in":    []byte("3600"),
+				},
+				body:    oauthResponse,
+				updated: false,
+			},
+		},
+		"ShouldInjectValueCombiningSeveralFields": {
+			args: args{
+				data: &httpClient.HttpResponse{StatusCode: 200, Body: `{"host": "db.example.com", "port": 5432, "token": "s3cr3t"}`},
+				mappings: []common.KeyInjection{
+					{SecretKey: "url", ResponseJQ: `"postgres://\(.body.host):\(.body.port)/app?token=\(.body.token)"`},
+					{SecretKey: "token", ResponseJQ: ".body.token"},
+				},
+			},
+			want: want{
+				data: map[string][]byte{
+					"url":   []byte("postgres://db.example.com:5432/app?token=s3cr3t"),
+					"token": []byte("s3cr3t"),
+				},
+				// The combined value isn't part of the response, its sensitive fields are masked by their own mappings.
+				body:    `{"host": "db.example.com", "port": 5432, "token": "{{name:namespace:token}}"}`,
+				updated: true,
+			},
+		},
+		"ShouldSetMissingFieldToEmptyByDefault": {
+			args: args{
+				data:       &httpClient.HttpResponse{StatusCode: 200, Body: `{"access_token": "new-access"}`},
+				secretData: map[string][]byte{"id-token": []byte("id")},
+				mappings:   missingFieldMappings,
+			},
+			want: want{
+				data: map[string][]byte{
+					"access-token": []byte("new-access"),
+					"id-token":     []byte(""),
+				},
+				body:    `{"access_token": "{{name:namespace:access-token}}"}`,
+				updated: true,
+			},
+		},
+		"ShouldPreserveMissingField": {
+			args: args{
+				data:                 &httpClient.HttpResponse{StatusCode: 200, Body: `{"access_token": "new-access"}`},
+				secretData:           map[string][]byte{"id-token": []byte("id")},
+				mappings:             missingFieldMappings,
+				missingFieldStrategy: common.MissingFieldStrategyPreserve,
+			},
+			want: want{
+				data: map[string][]byte{
+					"access-token": []byte("new-access"),
+					"id-token":     []byte("id"),
+				},
+				body:    `{"access_token": "{{name:namespace:access-token}}"}`,
+				updated: true,
+			},
+		},
+		"ShouldEncodeAndDecodeValuesAndMaskThemAsExtracted": {
+			args: args{
+				data: &httpClient.HttpResponse{StatusCode: 200, Body: `{"password": "s3cr3t", "cert": "Y2VydA=="}`},
+				mappings: []common.KeyInjection{
+					{SecretKey: "password", ResponseJQ: ".body.password", Encoding: common.EncodingBase64},
+					{SecretKey: "cert", ResponseJQ: ".body.cert", Encoding: common.EncodingBase64, Decode: true},
+				},
+			},
+			want: want{
+				data: map[string][]byte{
+					"password": []byte("czNjcjN0"),
+					"cert":     []byte("cert"),
+				},
+				body:    `{"password": "{{name:namespace:password}}", "cert": "{{name:namespace:cert}}"}`,
+				updated: true,
+			},
+		},
+		"ShouldFailOnInvalidEncodedValueWithoutPatchingAnyKey": {
+			args: args{
+				data: &httpClient.HttpResponse{StatusCode: 200, Body: `{"password": "s3cr3t", "cert": "zz"}`},
+				mappings: []common.KeyInjection{
+					{SecretKey: "password", ResponseJQ: ".body.password"},
+					{SecretKey: "cert", ResponseJQ: ".body.cert", Encoding: common.EncodingHex, Decode: true},
+				},
+			},
+			want: want{
+				body: `{"password": "s3cr3t", "cert": "zz"}`,
+				err:  errors.Wrapf(errors.Wrapf(errors.New("encoding/hex: invalid byte: U+007A 'z'"), errDecodeValue, common.EncodingHex), errEncodeSecretValue, "cert"),
+			},
+		},
+		"ShouldFailOnMissingFieldWithoutPatchingAnyKey": {
+			args: args{
+				data:                 &httpClient.HttpResponse{StatusCode: 200, Body: `{"access_token": "new-access"}`},
+				secretData:           map[string][]byte{"id-token": []byte("id")},
+				mappings:             missingFieldMappings,
+				missingFieldStrategy: common.MissingFieldStrategyFail,
+			},
+			want: want{
+				data: map[string][]byte{
+					"id-token": []byte("id"),
+				},
+				body: `{"access_token": "new-access"}`,
+				err:  errors.Errorf(errMissingResponseField, ".body.id_token", "id-token"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Data: tc.args.secretData,
+			}
+
+			updated, err := patchSecretData(logging.NewNopLogger(), tc.args.data, secret, tc.args.mappings, tc.args.missingFieldStrategy, common.ExtractionEngineJQ, false)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("patchSecretData(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("patchSecretData(...): -want updated, +got updated: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.data, secret.Data); diff != "" {
+				t.Errorf("patchSecretData(...): -want data, +got data: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.body, tc.args.data.Body); diff != "" {
+				t.Errorf("patchSecretData(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
 func TestPrepareDataMap(t *testing.T) {
 	type args struct {
-		data *httpClient.HttpResponse
+		data            *httpClient.HttpResponse
+		preserveRawBody bool
 	}
 
 	type want struct {
@@ -461,6 +728,7 @@ func TestPrepareDataMap(t *testing.T) {
 						"Content-Type": {"application/json"},
 					},
 				},
+				preserveRawBody: true,
 			},
 			want: want{
 				result: map[string]interface{}{
@@ -473,6 +741,24 @@ func TestPrepareDataMap(t *testing.T) {
 					"headers": map[string]interface{}{
 						"Content-Type": []any{"application/json"},
 					},
+					"rawBody":    `{"key1": "value1", "key2": {"subkey": "subvalue"}}`,
+					"statusCode": float64(0),
+				},
+				err: nil,
+			},
+		},
+		"ShouldOmitRawBodyUnlessPreserved": {
+			args: args{
+				data: &httpClient.HttpResponse{
+					Body: `{"key1": "value1"}`,
+				},
+			},
+			want: want{
+				result: map[string]interface{}{
+					"body": map[string]interface{}{
+						"key1": "value1",
+					},
+					"headers":    nil,
 					"statusCode": float64(0),
 				},
 				err: nil,
@@ -482,7 +768,7 @@ func TestPrepareDataMap(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			result, err := prepareDataMap(tc.args.data)
+			result, err := prepareDataMap(tc.args.data, tc.args.preserveRawBody)
 
 			if diff := cmp.Diff(tc.want.result, result); diff != "" {
 				t.Errorf("prepareDataMap(...): -want result, +got result: %s", diff)
@@ -0,0 +1,129 @@
+package datapatcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errSnapshotSecret  = "cannot snapshot secret %s:%s"
+	errPatchSecret     = "cannot patch secret %s:%s"
+	errDeleteSecret    = "cannot delete secret %s:%s"
+	errRolledBack      = "rolled back %d secrets"
+	errPartialRollback = "secrets left partially patched, cannot roll back %s"
+)
+
+// secretSnapshot is the state of a secret before it is patched from a response.
+type secretSnapshot struct {
+	key client.ObjectKey
+	// secret is nil when the secret didn't exist and is created by the patch.
+	secret *corev1.Secret
+}
+
+// takeSecretSnapshot records the current state of the secret with the given key.
+func takeSecretSnapshot(ctx context.Context, localKube client.Client, key client.ObjectKey) (secretSnapshot, error) {
+	var secret *corev1.Secret
+	err := withSecretOperationSlot(ctx, func() (err error) {
+		secret, err = kubehandler.GetSecret(ctx, localKube, key.Name, key.Namespace)
+		return err
+	})
+	if kerrors.IsNotFound(err) {
+		return secretSnapshot{key: key}, nil
+	}
+	if err != nil {
+		return secretSnapshot{}, errors.Wrapf(err, errSnapshotSecret, key.Name, key.Namespace)
+	}
+
+	return secretSnapshot{key: key, secret: secret.DeepCopy()}, nil
+}
+
+// restore brings the secret back to the state of the snapshot, deleting it if it didn't exist.
+func (s secretSnapshot) restore(ctx context.Context, localKube client.Client) error {
+	if s.secret == nil {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: s.key.Name, Namespace: s.key.Namespace}}
+		err := withSecretOperationSlot(ctx, func() error {
+			return client.IgnoreNotFound(localKube.Delete(ctx, secret))
+		})
+		return errors.Wrapf(err, errDeleteSecret, s.key.Name, s.key.Namespace)
+	}
+
+	return utils.RetryOnConflict(ctx, utils.DefaultConflictRetry, func() error {
+		return withSecretOperationSlot(ctx, func() error {
+			secret, err := kubehandler.GetSecret(ctx, localKube, s.key.Name, s.key.Namespace)
+			if err != nil {
+				return err
+			}
+
+			secret.Data = s.secret.Data
+			secret.Labels = s.secret.Labels
+			secret.Annotations = s.secret.Annotations
+			secret.OwnerReferences = s.secret.OwnerReferences
+			return kubehandler.UpdateSecret(ctx, localKube, secret)
+		})
+	})
+}
+
+// applyResponseDataToSecretsAtomically applies the SecretInjectionConfigs all or nothing. The secrets are
+// snapshotted before being patched, and when a config fails, the secrets patched so far, including the one
+// of the failing config, are restored in reverse order. The returned error tells whether the rollback
+// succeeded or which secrets were left partially patched. The sensitive values are masked in a copy of the
+// response, which only replaces it once all the configs are applied.
+func applyResponseDataToSecretsAtomically(ctx context.Context, localKube client.Client, logger logging.Logger, response *httpClient.HttpResponse, secretConfigs []common.SecretInjectionConfig, preserveRawBody bool, cr metav1.Object) error {
+	masked := copyResponse(response)
+	snapshots := make([]secretSnapshot, 0, len(secretConfigs))
+	snapshotted := make(map[client.ObjectKey]bool, len(secretConfigs))
+
+	for _, ref := range secretConfigs {
+		key := client.ObjectKey{Name: ref.SecretRef.Name, Namespace: ref.SecretRef.Namespace}
+
+		// Only the state before the first patch of a secret is restored.
+		if !snapshotted[key] {
+			snapshot, err := takeSecretSnapshot(ctx, localKube, key)
+			if err != nil {
+				return rollbackSecrets(ctx, localKube, snapshots, err)
+			}
+			snapshots = append(snapshots, snapshot)
+			snapshotted[key] = true
+		}
+
+		var owner metav1.Object = nil
+		if ref.SetOwnerReference {
+			owner = cr
+		}
+
+		if err := patchResponseDataToSecret(ctx, localKube, logger, masked, owner, ref, preserveRawBody); err != nil {
+			return rollbackSecrets(ctx, localKube, snapshots, errors.Wrapf(err, errPatchSecret, key.Name, key.Namespace))
+		}
+	}
+
+	*response = *masked
+	return nil
+}
+
+// rollbackSecrets restores the secrets of the snapshots in reverse order after the given error.
+func rollbackSecrets(ctx context.Context, localKube client.Client, snapshots []secretSnapshot, cause error) error {
+	var failed []string
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if err := snapshots[i].restore(ctx, localKube); err != nil {
+			failed = append(failed, fmt.Sprintf("%s:%s (%s)", snapshots[i].key.Name, snapshots[i].key.Namespace, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.Wrapf(cause, errPartialRollback, strings.Join(failed, ", "))
+	}
+
+	return errors.Wrapf(cause, errRolledBack, len(snapshots))
+}
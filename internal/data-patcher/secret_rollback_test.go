@@ -0,0 +1,181 @@
+package datapatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var errBoom = errors.New("boom")
+
+// secretStore is an in-memory store of secrets backing a mock client.
+type secretStore struct {
+	secrets      map[string]map[string][]byte
+	failUpdate   map[string]bool
+	failDeletion map[string]bool
+	// conflicts is the number of updates of each secret failing with a conflict.
+	conflicts map[string]int
+}
+
+func (s *secretStore) client() client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			data, ok := s.secrets[key.Name]
+			if !ok {
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+			}
+			secret := obj.(*corev1.Secret)
+			secret.Name, secret.Namespace = key.Name, key.Namespace
+			secret.Data = copySecretData(data)
+			return nil
+		},
+		MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			s.secrets[obj.GetName()] = copySecretData(obj.(*corev1.Secret).Data)
+			return nil
+		},
+		MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+			if s.failUpdate[obj.GetName()] {
+				return errBoom
+			}
+			if s.conflicts[obj.GetName()] > 0 {
+				s.conflicts[obj.GetName()]--
+				return kerrors.NewConflict(schema.GroupResource{Resource: "secrets"}, obj.GetName(), errBoom)
+			}
+			s.secrets[obj.GetName()] = copySecretData(obj.(*corev1.Secret).Data)
+			return nil
+		},
+		MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+			if s.failDeletion[obj.GetName()] {
+				return errBoom
+			}
+			delete(s.secrets, obj.GetName())
+			return nil
+		},
+	}
+}
+
+func copySecretData(data map[string][]byte) map[string][]byte {
+	if data == nil {
+		return nil
+	}
+	c := make(map[string][]byte, len(data))
+	for k, v := range data {
+		c[k] = append([]byte(nil), v...)
+	}
+	return c
+}
+
+func Test_applyResponseDataToSecretsAtomically(t *testing.T) {
+	secretConfigs := []common.SecretInjectionConfig{
+		{
+			SecretRef:   common.SecretRef{Name: "first", Namespace: "ns"},
+			KeyMappings: []common.KeyInjection{{SecretKey: "token", ResponseJQ: ".body.token"}},
+		},
+		{
+			SecretRef:   common.SecretRef{Name: "second", Namespace: "ns"},
+			KeyMappings: []common.KeyInjection{{SecretKey: "id", ResponseJQ: ".body.id"}},
+		},
+	}
+
+	type args struct {
+		store *secretStore
+	}
+	type want struct {
+		secrets map[string]map[string][]byte
+		body    string
+		err     error
+	}
+
+	body := `{"token":"new-token","id":"123"}`
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"AllSecretsPatched": {
+			args: args{
+				store: &secretStore{
+					secrets: map[string]map[string][]byte{
+						"first": {"token": []byte("old-token")},
+					},
+				},
+			},
+			want: want{
+				secrets: map[string]map[string][]byte{
+					"first":  {"token": []byte("new-token")},
+					"second": {"id": []byte("123")},
+				},
+				body: `{"token":"{{first:ns:token}}","id":"{{second:ns:id}}"}`,
+			},
+		},
+		"MidListFailureRollsBack": {
+			args: args{
+				store: &secretStore{
+					secrets: map[string]map[string][]byte{
+						"first": {"token": []byte("old-token")},
+					},
+					failUpdate: map[string]bool{"second": true},
+				},
+			},
+			want: want{
+				secrets: map[string]map[string][]byte{
+					"first": {"token": []byte("old-token")},
+				},
+				body: body,
+				err:  errors.Wrapf(errors.Wrapf(errors.Wrap(errors.Wrap(errBoom, "update secret failed"), errPatchToReferencedSecret), errPatchSecret, "second", "ns"), errRolledBack, 2),
+			},
+		},
+		"FailedRollbackReportsPartialState": {
+			args: args{
+				store: &secretStore{
+					secrets: map[string]map[string][]byte{
+						"first": {"token": []byte("old-token")},
+					},
+					failUpdate:   map[string]bool{"second": true},
+					failDeletion: map[string]bool{"second": true},
+				},
+			},
+			want: want{
+				secrets: map[string]map[string][]byte{
+					"first":  {"token": []byte("old-token")},
+					"second": nil,
+				},
+				body: body,
+				err: errors.Wrapf(errors.Wrapf(errors.Wrap(errors.Wrap(errBoom, "update secret failed"), errPatchToReferencedSecret), errPatchSecret, "second", "ns"),
+					errPartialRollback, "second:ns (cannot delete secret second:ns: boom)"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			response := &httpClient.HttpResponse{
+				StatusCode: 200,
+				Body:       body,
+			}
+
+			err := applyResponseDataToSecretsAtomically(context.Background(), tc.args.store.client(), logging.NewNopLogger(), response, secretConfigs, false, nil)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("applyResponseDataToSecretsAtomically(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.secrets, tc.args.store.secrets); diff != "" {
+				t.Errorf("applyResponseDataToSecretsAtomically(...): -want secrets, +got secrets: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, response.Body); diff != "" {
+				t.Errorf("applyResponseDataToSecretsAtomically(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package datapatcher
+
+import (
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+// leaveUnresolved returns true if the secret placeholders referencing a missing secret or key are kept as is
+// with the given policy, instead of failing. An empty policy fails.
+func leaveUnresolved(policy string) bool {
+	return policy == common.UnresolvedSecretPolicyLeaveUnresolved
+}
@@ -0,0 +1,10 @@
+// Package features defines the feature flags of the provider.
+package features
+
+import "github.com/crossplane/crossplane-runtime/pkg/feature"
+
+const (
+	// EnableTraceContextPropagation injects a W3C traceparent header in the
+	// requests sent for the resources, so that they appear in distributed traces.
+	EnableTraceContextPropagation feature.Flag = "EnableTraceContextPropagation"
+)
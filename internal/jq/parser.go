@@ -88,6 +88,11 @@ func ParseBool(jqQuery string, obj interface{}) (bool, error) {
 	return boolean, nil
 }
 
+// ParseInterface runs a jq query on a given object and returns the result as is.
+func ParseInterface(jqQuery string, obj interface{}) (interface{}, error) {
+	return runJQQuery(jqQuery, obj)
+}
+
 // ParseMapInterface runs a jq query on a given object and returns the result as a map[string]interface{}.
 func ParseMapInterface(jqQuery string, obj interface{}) (map[string]interface{}, error) {
 	queryRes, err := runJQQuery(jqQuery, obj)
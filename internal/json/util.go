@@ -3,8 +3,48 @@ package json
 import (
 	"bytes"
 	"encoding/json"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/pkg/errors"
 )
 
+// DefaultResponseBodyMaxDepth is the default maximum nesting depth allowed when converting JSON data into maps.
+const DefaultResponseBodyMaxDepth = 100
+
+// responseBodyMaxDepth is the maximum nesting depth allowed when converting JSON data into maps.
+var responseBodyMaxDepth atomic.Int64
+
+func init() {
+	responseBodyMaxDepth.Store(DefaultResponseBodyMaxDepth)
+}
+
+// SetResponseBodyMaxDepth sets the maximum nesting depth allowed when converting JSON data into maps, which
+// guards the recursive processing of deeply nested or maliciously crafted documents. Values that are not
+// positive restore the default.
+func SetResponseBodyMaxDepth(depth int) {
+	if depth <= 0 {
+		depth = DefaultResponseBodyMaxDepth
+	}
+	responseBodyMaxDepth.Store(int64(depth))
+}
+
+// ResponseBodyMaxDepth returns the maximum nesting depth allowed when converting JSON data into maps.
+func ResponseBodyMaxDepth() int {
+	return int(responseBodyMaxDepth.Load())
+}
+
+const (
+	errMaxDepthExceeded = "JSON nesting depth exceeds the maximum allowed depth of %d"
+	errNotJSONObject    = "not a JSON object"
+)
+
+// firstKey matches the opening of a JSON object up to the colon of its first key, capturing the
+// whitespace before the key and around the colon.
+var firstKey = regexp.MustCompile(`^\s*\{(\s*)"(?:[^"\\]|\\.)*"(\s*:\s*)`)
+
 // Contains checks if the containee map is contained within the container map, including nested JSON structures.
 func Contains(container, containee map[string]interface{}) bool {
 	for key, value := range containee {
@@ -41,21 +81,68 @@ func JsonStringToMap(jsonStr string) map[string]interface{} {
 }
 
 // ConvertJSONStringsToMaps converts JSON strings within a map to maps for JSON data processing.
-func ConvertJSONStringsToMaps(merged *map[string]interface{}) {
+// It returns an error if the resulting structure is nested deeper than ResponseBodyMaxDepth.
+func ConvertJSONStringsToMaps(merged *map[string]interface{}) error {
+	return convertJSONStringsToMaps(merged, 1)
+}
+
+// convertJSONStringsToMaps converts JSON strings within a map to maps, tracking the current nesting depth.
+func convertJSONStringsToMaps(merged *map[string]interface{}, depth int) error {
+	maxDepth := ResponseBodyMaxDepth()
+	if depth > maxDepth {
+		return errors.Errorf(errMaxDepthExceeded, maxDepth)
+	}
+
 	for key, value := range *merged {
 		switch valueToHandle := value.(type) {
 		case string:
 			if IsJSONString(valueToHandle) {
 				mappedJSON := JsonStringToMap(valueToHandle)
+				if exceedsDepth(mappedJSON, maxDepth-depth) {
+					return errors.Errorf(errMaxDepthExceeded, maxDepth)
+				}
 				(*merged)[key] = mappedJSON
 			}
 		case map[string]interface{}:
-			ConvertJSONStringsToMaps(&valueToHandle)
+			if err := convertJSONStringsToMaps(&valueToHandle, depth+1); err != nil {
+				return err
+			}
 		case []interface{}:
 			structToMap, _ := StructToMap(valueToHandle)
-			ConvertJSONStringsToMaps(&structToMap)
+			if err := convertJSONStringsToMaps(&structToMap, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// exceedsDepth reports whether the given value is nested deeper than the remaining depth.
+// The traversal stops as soon as the remaining depth is exhausted.
+func exceedsDepth(value interface{}, remaining int) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if remaining <= 0 {
+			return true
+		}
+		for _, nested := range v {
+			if exceedsDepth(nested, remaining-1) {
+				return true
+			}
+		}
+	case []interface{}:
+		if remaining <= 0 {
+			return true
+		}
+		for _, nested := range v {
+			if exceedsDepth(nested, remaining-1) {
+				return true
+			}
 		}
 	}
+
+	return false
 }
 
 // StructToMap converts a struct to a map.
@@ -68,6 +155,68 @@ func StructToMap(obj interface{}) (newMap map[string]interface{}, err error) {
 	return
 }
 
+// DeepMerge returns the result of merging the overlay into the base. Nested objects are merged
+// recursively and the values of the overlay take precedence. The given maps are not modified.
+func DeepMerge(base, overlay map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overlay))
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range overlay {
+		baseMap, baseIsMap := merged[key].(map[string]interface{})
+		overlayMap, overlayIsMap := value.(map[string]interface{})
+		if baseIsMap && overlayIsMap {
+			merged[key] = DeepMerge(baseMap, overlayMap)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// AppendKeys appends the keys, in order, with their values to the JSON object. The existing keys are
+// kept as they are, including their order and formatting, and the appended keys are indented and spaced
+// like the first key of the object.
+func AppendKeys(object string, keys []string, values map[string]interface{}) (string, error) {
+	end := strings.LastIndex(object, "}")
+	if end < 0 {
+		return "", errors.New(errNotJSONObject)
+	}
+	head := strings.TrimRightFunc(object[:end], unicode.IsSpace)
+	tail := object[len(head):]
+
+	indent, colon := "", ":"
+	if match := firstKey.FindStringSubmatch(object); match != nil {
+		indent, colon = match[1], match[2]
+	}
+
+	appended := strings.Builder{}
+	appended.WriteString(head)
+	for i, key := range keys {
+		if i > 0 || !strings.HasSuffix(head, "{") {
+			appended.WriteString(",")
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return "", err
+		}
+		value, err := json.Marshal(values[key])
+		if err != nil {
+			return "", err
+		}
+		appended.WriteString(indent)
+		appended.Write(name)
+		appended.WriteString(colon)
+		appended.Write(value)
+	}
+	appended.WriteString(tail)
+
+	return appended.String(), nil
+}
+
 // ConvertMapToJson converts a map to a JSON string.
 func ConvertMapToJson(m map[string]interface{}) (string, error) {
 	jsonBytes, err := json.Marshal(m)
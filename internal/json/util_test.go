@@ -1,10 +1,13 @@
 package json
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 )
 
 var (
@@ -219,12 +222,93 @@ func Test_JsonStringToMap(t *testing.T) {
 	}
 }
 
+func Test_AppendKeys(t *testing.T) {
+	type args struct {
+		object string
+		keys   []string
+		values map[string]interface{}
+	}
+	type want struct {
+		result string
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Compact": {
+			args: args{
+				object: `{"username":"john_doe"}`,
+				keys:   []string{"region", "tags"},
+				values: map[string]interface{}{"region": "eu-west-1", "tags": []interface{}{"a"}},
+			},
+			want: want{
+				result: `{"username":"john_doe","region":"eu-west-1","tags":["a"]}`,
+			},
+		},
+		"Spaced": {
+			args: args{
+				object: `{ "username": "john_doe" }`,
+				keys:   []string{"region"},
+				values: map[string]interface{}{"region": "eu-west-1"},
+			},
+			want: want{
+				result: `{ "username": "john_doe", "region": "eu-west-1" }`,
+			},
+		},
+		"Indented": {
+			args: args{
+				object: "{\n\t\"username\": \"john_doe\"\n}\n",
+				keys:   []string{"region"},
+				values: map[string]interface{}{"region": "eu-west-1"},
+			},
+			want: want{
+				result: "{\n\t\"username\": \"john_doe\",\n\t\"region\": \"eu-west-1\"\n}\n",
+			},
+		},
+		"EmptyObject": {
+			args: args{
+				object: `{}`,
+				keys:   []string{"region", "zone"},
+				values: map[string]interface{}{"region": "eu-west-1", "zone": "b"},
+			},
+			want: want{
+				result: `{"region":"eu-west-1","zone":"b"}`,
+			},
+		},
+		"NotAnObject": {
+			args: args{
+				object: `null`,
+				keys:   []string{"region"},
+			},
+			want: want{
+				err: errors.New(errNotJSONObject),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, err := AppendKeys(tc.args.object, tc.args.keys, tc.args.values)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("AppendKeys(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("AppendKeys(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_ConvertJSONStringsToMaps(t *testing.T) {
 	type args struct {
-		merged map[string]interface{}
+		merged   map[string]interface{}
+		maxDepth int
 	}
 	type want struct {
 		result map[string]interface{}
+		err    error
 	}
 	cases := map[string]struct {
 		args args
@@ -248,10 +332,55 @@ func Test_ConvertJSONStringsToMaps(t *testing.T) {
 				},
 			},
 		},
+		"NestedBodyWithinLimit": {
+			args: args{
+				merged: map[string]any{
+					"body": nestedJSON(10),
+				},
+			},
+			want: want{
+				result: map[string]any{
+					"body": JsonStringToMap(nestedJSON(10)),
+				},
+			},
+		},
+		"NestedBodyExceedsLimit": {
+			args: args{
+				merged: map[string]any{
+					"body": nestedJSON(ResponseBodyMaxDepth() + 1),
+				},
+			},
+			want: want{
+				result: map[string]any{
+					"body": nestedJSON(ResponseBodyMaxDepth() + 1),
+				},
+				err: errors.Errorf(errMaxDepthExceeded, ResponseBodyMaxDepth()),
+			},
+		},
+		"NestedBodyExceedsConfiguredLimit": {
+			args: args{
+				merged: map[string]any{
+					"body": nestedJSON(10),
+				},
+				maxDepth: 5,
+			},
+			want: want{
+				result: map[string]any{
+					"body": nestedJSON(10),
+				},
+				err: errors.Errorf(errMaxDepthExceeded, 5),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			ConvertJSONStringsToMaps(&tc.args.merged)
+			SetResponseBodyMaxDepth(tc.args.maxDepth)
+			defer SetResponseBodyMaxDepth(DefaultResponseBodyMaxDepth)
+
+			gotErr := ConvertJSONStringsToMaps(&tc.args.merged)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ConvertJSONStringsToMaps(...): -want error, +got error: %s", diff)
+			}
 			if diff := cmp.Diff(tc.args.merged, tc.want.result); diff != "" {
 				t.Fatalf("ConvertJSONStringsToMaps(...): -want result, +got result: %s", diff)
 			}
@@ -259,6 +388,11 @@ func Test_ConvertJSONStringsToMaps(t *testing.T) {
 	}
 }
 
+// nestedJSON returns a JSON object string nested to the given depth.
+func nestedJSON(depth int) string {
+	return strings.Repeat(`{"a":`, depth-1) + `{}` + strings.Repeat(`}`, depth-1)
+}
+
 func Test_StructToMap(t *testing.T) {
 	type args struct {
 		obj interface{}
@@ -338,3 +472,59 @@ func Test_StructToMap(t *testing.T) {
 		})
 	}
 }
+
+func Test_DeepMerge(t *testing.T) {
+	type args struct {
+		base    map[string]interface{}
+		overlay map[string]interface{}
+	}
+	type want struct {
+		result map[string]interface{}
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"OverlayTakesPrecedence": {
+			args: args{
+				base:    map[string]interface{}{"name": "old", "region": "eu"},
+				overlay: map[string]interface{}{"name": "new"},
+			},
+			want: want{
+				result: map[string]interface{}{"name": "new", "region": "eu"},
+			},
+		},
+		"NestedObjectsMergedRecursively": {
+			args: args{
+				base:    map[string]interface{}{"tags": map[string]interface{}{"team": "core", "env": "dev"}},
+				overlay: map[string]interface{}{"tags": map[string]interface{}{"env": "prod"}},
+			},
+			want: want{
+				result: map[string]interface{}{"tags": map[string]interface{}{"team": "core", "env": "prod"}},
+			},
+		},
+		"NonObjectOverlayReplacesObject": {
+			args: args{
+				base:    map[string]interface{}{"tags": map[string]interface{}{"team": "core"}},
+				overlay: map[string]interface{}{"tags": []interface{}{"core"}},
+			},
+			want: want{
+				result: map[string]interface{}{"tags": []interface{}{"core"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			base, _ := StructToMap(tc.args.base)
+			got := DeepMerge(tc.args.base, tc.args.overlay)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("DeepMerge(...): -want result, +got result: %s", diff)
+			}
+			if diff := cmp.Diff(base, tc.args.base); diff != "" {
+				t.Fatalf("DeepMerge(...): should not modify the base: %s", diff)
+			}
+		})
+	}
+}
@@ -16,8 +16,10 @@ import (
 const (
 	errCreateSecret      = "create secret failed"
 	errGetSecret         = "failed to get secret %s:%s"
+	errGetConfigMap      = "failed to get ConfigMap %s:%s"
 	errUpdateFailed      = "update secret failed"
 	errSetOwnerReference = "could not set owner reference to secret"
+	errSecretForbidden   = "the provider is not allowed to %s secret %s:%s, grant its service account the %s verb on secrets in namespace %s"
 )
 
 // GetSecret retrieves a Kubernetes Secret from the cluster.
@@ -29,12 +31,27 @@ func GetSecret(ctx context.Context, kubeClient client.Client, name string, names
 	}, secret)
 
 	if err != nil {
-		return nil, errors.Wrap(err, fmt.Sprintf(errGetSecret, name, namespace))
+		return nil, wrapSecretError(err, "get", name, namespace, fmt.Sprintf(errGetSecret, name, namespace))
 	}
 
 	return secret, nil
 }
 
+// GetConfigMap retrieves a Kubernetes ConfigMap from the cluster.
+func GetConfigMap(ctx context.Context, kubeClient client.Client, name string, namespace string) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{}
+	err := kubeClient.Get(ctx, client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}, configMap)
+
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf(errGetConfigMap, name, namespace))
+	}
+
+	return configMap, nil
+}
+
 // GetOrCreateSecret retrieves a Kubernetes Secret from the cluster. If the secret does not exist, it creates a new one.
 // If the secret exists but has no owner reference, it sets the owner reference and updates the secret.
 func GetOrCreateSecret(ctx context.Context, kubeClient client.Client, name, namespace string, owner metav1.Object) (*corev1.Secret, error) {
@@ -63,7 +80,7 @@ func GetOrCreateSecret(ctx context.Context, kubeClient client.Client, name, name
 func UpdateSecret(ctx context.Context, kubeClient client.Client, secret *corev1.Secret) error {
 	err := kubeClient.Update(ctx, secret)
 	if err != nil {
-		return errors.Wrap(err, errUpdateFailed)
+		return wrapSecretError(err, "update", secret.Name, secret.Namespace, errUpdateFailed)
 	}
 
 	return nil
@@ -86,7 +103,7 @@ func createSecret(ctx context.Context, kubeClient client.Client, name, namespace
 
 	err := kubeClient.Create(ctx, secret)
 	if err != nil {
-		return nil, errors.Wrap(err, errCreateSecret)
+		return nil, wrapSecretError(err, "create", name, namespace, errCreateSecret)
 	}
 
 	return secret, nil
@@ -101,3 +118,12 @@ func hasOwnerReference(secret *corev1.Secret, owner metav1.Object) bool {
 	}
 	return false
 }
+
+// wrapSecretError wraps an error of a request for a secret with the given message, or with the RBAC permission
+// the provider lacks when the request was forbidden, e.g. for a secret in a namespace it can't write to.
+func wrapSecretError(err error, verb, name, namespace, message string) error {
+	if errs.IsForbidden(err) {
+		return errors.Wrapf(err, errSecretForbidden, verb, name, namespace, verb, namespace)
+	}
+	return errors.Wrap(err, message)
+}
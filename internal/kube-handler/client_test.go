@@ -11,12 +11,15 @@ import (
 	"github.com/google/go-cmp/cmp"
 	errorspkg "github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
-	errBoom = errors.New("boom")
+	errBoom      = errors.New("boom")
+	errForbidden = kerrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "update-secret-name", errBoom)
 )
 
 func createSpecificSecret(name, namespace, key, value string) *corev1.Secret {
@@ -93,6 +96,19 @@ func Test_GetSecret(t *testing.T) {
 				err:    errorspkg.Wrap(errBoom, fmt.Sprintf(errGetSecret, "secret", "default")),
 			},
 		},
+		"ShouldExplainForbidden": {
+			args: args{
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errForbidden),
+				},
+				name:      "secret",
+				namespace: "team-c",
+			},
+			want: want{
+				result: nil,
+				err:    errorspkg.Wrapf(errForbidden, errSecretForbidden, "get", "secret", "team-c", "get", "team-c"),
+			},
+		},
 	}
 	for name, tc := range cases {
 		tc := tc // Create local copies of loop variables
@@ -109,6 +125,68 @@ func Test_GetSecret(t *testing.T) {
 	}
 }
 
+func Test_GetConfigMap(t *testing.T) {
+	type args struct {
+		localKube client.Client
+		name      string
+		namespace string
+	}
+	type want struct {
+		result *corev1.ConfigMap
+		err    error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldGetConfigMap": {
+			args: args{
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.(*corev1.ConfigMap).Data = map[string]string{"logic": "true"}
+						return nil
+					}),
+				},
+				name:      "checks",
+				namespace: "default",
+			},
+			want: want{
+				result: &corev1.ConfigMap{
+					Data: map[string]string{"logic": "true"},
+				},
+				err: nil,
+			},
+		},
+		"ShouldFail": {
+			args: args{
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				name:      "checks",
+				namespace: "default",
+			},
+			want: want{
+				result: nil,
+				err:    errorspkg.Wrap(errBoom, fmt.Sprintf(errGetConfigMap, "checks", "default")),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := GetConfigMap(context.Background(), tc.args.localKube, tc.args.name, tc.args.namespace)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("GetConfigMap(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("GetConfigMap(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_GetOrCreateSecret(t *testing.T) {
 	type args struct {
 		localKube client.Client
@@ -258,6 +336,17 @@ func Test_UpdateSecret(t *testing.T) {
 				err: errorspkg.Wrap(errBoom, errUpdateFailed),
 			},
 		},
+		"ShouldExplainForbidden": {
+			args: args{
+				localKube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(errForbidden),
+				},
+				secret: createSpecificSecret("update-secret-name", "team-c", "update-key", "update-value"),
+			},
+			want: want{
+				err: errorspkg.Wrapf(errForbidden, errSecretForbidden, "update", "update-secret-name", "team-c", "update", "team-c"),
+			},
+		},
 	}
 	for name, tc := range cases {
 		tc := tc // Create local copies of loop variables
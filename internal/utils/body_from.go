@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+const (
+	errBodyFromConfigMapKey = "key %s not found in ConfigMap %s:%s"
+	errBodyFromSecretKey    = "key %s not found in secret %s:%s"
+)
+
+// BodyFrom returns the body of a request read from the referenced ConfigMap or Secret key. A body read
+// from a Secret is masked with its secret placeholder, so that it isn't recorded in the status.
+func BodyFrom(ctx context.Context, kube client.Client, ref *common.BodyFrom) (httpClient.Data, error) {
+	if ref.SecretKeyRef != nil {
+		selector := ref.SecretKeyRef
+		secret, err := kubehandler.GetSecret(ctx, kube, selector.Name, selector.Namespace)
+		if err != nil {
+			return httpClient.Data{}, err
+		}
+
+		body, ok := secret.Data[selector.Key]
+		if !ok {
+			return httpClient.Data{}, errors.Errorf(errBodyFromSecretKey, selector.Key, selector.Namespace, selector.Name)
+		}
+
+		return httpClient.Data{
+			Encrypted: fmt.Sprintf("{{%s:%s:%s}}", selector.Name, selector.Namespace, selector.Key),
+			Decrypted: string(body),
+		}, nil
+	}
+
+	configMapRef := ref.ConfigMapKeyRef
+	configMap, err := kubehandler.GetConfigMap(ctx, kube, configMapRef.Name, configMapRef.Namespace)
+	if err != nil {
+		return httpClient.Data{}, err
+	}
+
+	body, ok := configMap.Data[configMapRef.Key]
+	if !ok {
+		return httpClient.Data{}, errors.Errorf(errBodyFromConfigMapKey, configMapRef.Key, configMapRef.Namespace, configMapRef.Name)
+	}
+
+	return httpClient.Data{Encrypted: body, Decrypted: body}, nil
+}
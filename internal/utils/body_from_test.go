@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func TestBodyFrom(t *testing.T) {
+	type args struct {
+		ref     *common.BodyFrom
+		getErr  error
+		content map[string]string
+	}
+	type want struct {
+		body httpClient.Data
+		err  error
+	}
+
+	configMapRef := &common.BodyFrom{
+		ConfigMapKeyRef: &common.ConfigMapKeyRef{Name: "payloads", Namespace: "default", Key: "user.json"},
+	}
+	secretRef := &common.BodyFrom{
+		SecretKeyRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "certificates", Namespace: "default"},
+			Key:             "tls.crt",
+		},
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"FromConfigMap": {
+			args: args{
+				ref:     configMapRef,
+				content: map[string]string{"user.json": `{"username": "john_doe"}`},
+			},
+			want: want{
+				body: httpClient.Data{Encrypted: `{"username": "john_doe"}`, Decrypted: `{"username": "john_doe"}`},
+			},
+		},
+		"FromSecret": {
+			args: args{
+				ref:     secretRef,
+				content: map[string]string{"tls.crt": "-----BEGIN CERTIFICATE-----"},
+			},
+			want: want{
+				body: httpClient.Data{Encrypted: "{{certificates:default:tls.crt}}", Decrypted: "-----BEGIN CERTIFICATE-----"},
+			},
+		},
+		"MissingConfigMapKey": {
+			args: args{
+				ref:     configMapRef,
+				content: map[string]string{"group.json": `{}`},
+			},
+			want: want{
+				err: errors.Errorf(errBodyFromConfigMapKey, "user.json", "default", "payloads"),
+			},
+		},
+		"MissingSecretKey": {
+			args: args{
+				ref:     secretRef,
+				content: map[string]string{},
+			},
+			want: want{
+				err: errors.Errorf(errBodyFromSecretKey, "tls.crt", "default", "certificates"),
+			},
+		},
+		"GetFailed": {
+			args: args{
+				ref:    configMapRef,
+				getErr: errBoom,
+			},
+			want: want{
+				err: errors.Wrap(errBoom, "failed to get ConfigMap payloads:default"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(tc.args.getErr, func(obj client.Object) error {
+					switch o := obj.(type) {
+					case *corev1.ConfigMap:
+						o.Data = tc.args.content
+					case *corev1.Secret:
+						o.Data = map[string][]byte{}
+						for key, value := range tc.args.content {
+							o.Data[key] = []byte(value)
+						}
+					}
+					return nil
+				}),
+			}
+
+			got, err := BodyFrom(context.Background(), kube, tc.args.ref)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("BodyFrom(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, got); diff != "" {
+				t.Errorf("BodyFrom(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"context"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const (
+	// AnnotationKeyResetCircuitBreaker is the annotation closing the circuit breaker of the host
+	// the resource sends its requests to. It is removed once the circuit breaker is closed.
+	AnnotationKeyResetCircuitBreaker = "http.crossplane.io/reset-circuit-breaker"
+
+	// TypeUpstreamCircuitOpen resources don't send requests because the circuit breaker of their
+	// host is open.
+	TypeUpstreamCircuitOpen xpv1.ConditionType = "UpstreamCircuitOpen"
+
+	// ReasonCircuitOpen means the circuit breaker of the host is open after consecutive failures.
+	ReasonCircuitOpen xpv1.ConditionReason = "CircuitOpen"
+	// ReasonCircuitClosed means the circuit breaker of the host is closed again.
+	ReasonCircuitClosed xpv1.ConditionReason = "CircuitClosed"
+
+	errResetCircuitBreaker = "cannot remove the circuit breaker reset annotation"
+)
+
+// UpstreamCircuitOpen returns a condition indicating that the circuit breaker of the host
+// of the resource is open.
+func UpstreamCircuitOpen() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUpstreamCircuitOpen,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCircuitOpen,
+	}
+}
+
+// UpstreamCircuitClosed returns a condition indicating that the circuit breaker of the host
+// of the resource is closed.
+func UpstreamCircuitClosed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUpstreamCircuitOpen,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCircuitClosed,
+	}
+}
+
+// ResetUpstreamCircuit closes the circuit breaker of the host of the given URL if the resource has
+// the reset annotation, and removes the annotation.
+func ResetUpstreamCircuit(ctx context.Context, kube client.Client, mg resource.Managed, url string) error {
+	if _, ok := mg.GetAnnotations()[AnnotationKeyResetCircuitBreaker]; !ok {
+		return nil
+	}
+
+	httpClient.ResetCircuit(url)
+	meta.RemoveAnnotations(mg, AnnotationKeyResetCircuitBreaker)
+	return errors.Wrap(kube.Update(ctx, mg), errResetCircuitBreaker)
+}
+
+// SetUpstreamCircuitCondition sets the UpstreamCircuitOpen condition of the resource from the
+// circuit breaker of the host of the given URL. Resources whose circuit was never open don't get
+// the condition. It must be set after the resource is fetched again, which drops the condition.
+func SetUpstreamCircuitCondition(mg resource.Managed, url string) {
+	switch {
+	case httpClient.CircuitOpen(url):
+		mg.SetConditions(UpstreamCircuitOpen())
+	case mg.GetCondition(TypeUpstreamCircuitOpen).Status == corev1.ConditionTrue:
+		mg.SetConditions(UpstreamCircuitClosed())
+	}
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1alpha2_request "github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_SetUpstreamCircuitCondition(t *testing.T) {
+	type args struct {
+		open        bool
+		annotations map[string]string
+		conditions  []xpv1.Condition
+		updateErr   error
+	}
+	type want struct {
+		status      corev1.ConditionStatus
+		reason      xpv1.ConditionReason
+		annotations map[string]string
+		updated     bool
+		err         error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NeverOpen": {
+			args: args{},
+			want: want{status: corev1.ConditionUnknown},
+		},
+		"Open": {
+			args: args{open: true},
+			want: want{status: corev1.ConditionTrue, reason: ReasonCircuitOpen},
+		},
+		"ClosedAfterOpen": {
+			args: args{conditions: []xpv1.Condition{UpstreamCircuitOpen()}},
+			want: want{status: corev1.ConditionFalse, reason: ReasonCircuitClosed},
+		},
+		"ResetAnnotationClosesCircuit": {
+			args: args{
+				open:        true,
+				annotations: map[string]string{AnnotationKeyResetCircuitBreaker: "true", "other": "kept"},
+				conditions:  []xpv1.Condition{UpstreamCircuitOpen()},
+			},
+			want: want{
+				status:      corev1.ConditionFalse,
+				reason:      ReasonCircuitClosed,
+				annotations: map[string]string{"other": "kept"},
+				updated:     true,
+			},
+		},
+		"ResetAnnotationUpdateFailed": {
+			args: args{
+				open:        true,
+				annotations: map[string]string{AnnotationKeyResetCircuitBreaker: "true"},
+				updateErr:   errBoom,
+			},
+			want: want{
+				status:      corev1.ConditionUnknown,
+				annotations: map[string]string{},
+				updated:     true,
+				err:         errors.Wrap(errBoom, errResetCircuitBreaker),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			httpClient.SetCircuitBreaker(1, time.Minute)
+			defer httpClient.SetCircuitBreaker(0, httpClient.DefaultCircuitOpenDuration)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			if tc.args.open {
+				c, err := httpClient.NewClient(logging.NewNopLogger(), time.Minute, "")
+				if err != nil {
+					t.Fatalf("NewClient(...): unexpected error: %s", err)
+				}
+				empty := httpClient.Data{Encrypted: "", Decrypted: ""}
+				headers := httpClient.Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+				if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, empty, headers, false); err != nil {
+					t.Fatalf("SendRequest(...): unexpected error: %s", err)
+				}
+			}
+
+			updated := false
+			kube := &test.MockClient{
+				MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+					updated = true
+					return tc.args.updateErr
+				},
+			}
+
+			cr := &v1alpha2_request.Request{}
+			cr.SetAnnotations(tc.args.annotations)
+			cr.SetConditions(tc.args.conditions...)
+
+			err := ResetUpstreamCircuit(context.Background(), kube, cr, server.URL+"/v1/users")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("ResetUpstreamCircuit(...): -want error, +got error: %s", diff)
+			}
+			if err == nil {
+				SetUpstreamCircuitCondition(cr, server.URL+"/v1/users")
+			}
+			got := cr.GetCondition(TypeUpstreamCircuitOpen)
+			if diff := cmp.Diff(tc.want.status, got.Status); diff != "" {
+				t.Errorf("SetUpstreamCircuitCondition(...): -want status, +got status: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, got.Reason); diff != "" {
+				t.Errorf("SetUpstreamCircuitCondition(...): -want reason, +got reason: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.annotations, cr.GetAnnotations()); diff != "" {
+				t.Errorf("SetUpstreamCircuitCondition(...): -want annotations, +got annotations: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("SetUpstreamCircuitCondition(...): -want updated, +got updated: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+const (
+	errClientCertificateKey = "key %s not found in secret %s:%s"
+	errClientCertificate    = "cannot load the client certificate of secret %s:%s"
+	errCABundleKey          = "key %s not found in secret %s:%s"
+)
+
+// CABundle returns the PEM encoded certificate authorities of the ca.crt of the given secret, nil when
+// there is no secret.
+func CABundle(ctx context.Context, kube client.Client, ref *common.SecretRef) ([]byte, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	secret, err := kubehandler.GetSecret(ctx, kube, ref.Name, ref.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, ok := secret.Data[corev1.ServiceAccountRootCAKey]
+	if !ok {
+		return nil, errors.Errorf(errCABundleKey, corev1.ServiceAccountRootCAKey, ref.Namespace, ref.Name)
+	}
+
+	return bundle, nil
+}
+
+// ClientCertificate returns a function loading the client certificate from the given kubernetes.io/tls
+// secret, nil when there is no secret. The secret is read on every call, so that the certificate is
+// rotated with it.
+func ClientCertificate(kube client.Client, ref *common.SecretRef) func(ctx context.Context) (*tls.Certificate, error) {
+	if ref == nil {
+		return nil
+	}
+
+	return func(ctx context.Context) (*tls.Certificate, error) {
+		secret, err := kubehandler.GetSecret(ctx, kube, ref.Name, ref.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+			if _, ok := secret.Data[key]; !ok {
+				return nil, errors.Errorf(errClientCertificateKey, key, ref.Namespace, ref.Name)
+			}
+		}
+
+		certificate, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, errors.Wrapf(err, errClientCertificate, ref.Namespace, ref.Name)
+		}
+
+		return &certificate, nil
+	}
+}
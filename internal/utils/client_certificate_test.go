@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+var testClientCertificateRef = &common.SecretRef{Name: "client-tls", Namespace: "crossplane-system"}
+
+// clientCertificateSecretData returns the data of a kubernetes.io/tls secret holding a self-signed
+// certificate with the given common name.
+func clientCertificateSecretData(t *testing.T, commonName string) map[string][]byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(...): unexpected error: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate(...): unexpected error: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey(...): unexpected error: %s", err)
+	}
+
+	return map[string][]byte{
+		corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// secretClient returns a client reading a secret with the data returned by the given function.
+func secretClient(data func() map[string][]byte) client.Client {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = data()
+			return nil
+		}),
+	}
+}
+
+func TestClientCertificate(t *testing.T) {
+	type want struct {
+		commonName string
+		err        error
+	}
+
+	cases := map[string]struct {
+		data map[string][]byte
+		want want
+	}{
+		"ValidCertificate": {
+			data: clientCertificateSecretData(t, "provider-http"),
+			want: want{
+				commonName: "provider-http",
+			},
+		},
+		"MissingKey": {
+			data: map[string][]byte{corev1.TLSCertKey: []byte("certificate")},
+			want: want{
+				err: errors.Errorf(errClientCertificateKey, corev1.TLSPrivateKeyKey, "crossplane-system", "client-tls"),
+			},
+		},
+		"InvalidKeyPair": {
+			data: map[string][]byte{corev1.TLSCertKey: []byte("certificate"), corev1.TLSPrivateKeyKey: []byte("key")},
+			want: want{
+				err: errors.Wrapf(errors.New("tls: failed to find any PEM data in certificate input"), errClientCertificate, "crossplane-system", "client-tls"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			load := ClientCertificate(secretClient(func() map[string][]byte { return tc.data }), testClientCertificateRef)
+			got, err := load(context.Background())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("ClientCertificate(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			leaf, err := x509.ParseCertificate(got.Certificate[0])
+			if err != nil {
+				t.Fatalf("ParseCertificate(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.commonName, leaf.Subject.CommonName); diff != "" {
+				t.Errorf("ClientCertificate(...): -want common name, +got common name: %s", diff)
+			}
+		})
+	}
+}
+
+func TestClientCertificate_NoSecret(t *testing.T) {
+	if load := ClientCertificate(nil, nil); load != nil {
+		t.Errorf("ClientCertificate(...): expected no loader without a secret")
+	}
+}
+
+func TestClientCertificate_Rotation(t *testing.T) {
+	var presented []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented = append(presented, r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert} // #nosec G402
+	server.StartTLS()
+	defer server.Close()
+
+	data := clientCertificateSecretData(t, "before-rotation")
+	kube := secretClient(func() map[string][]byte { return data })
+
+	// A client is created for each reconcile, and its requests share their connections, so the rotated
+	// certificate is presented by the client of the next reconcile.
+	send := func() {
+		c, err := httpClient.NewClient(logging.NewNopLogger(), 5*time.Second, "", httpClient.WithTLSConfig(httpClient.TLSConfigData{
+			ClientCertificate: ClientCertificate(kube, testClientCertificateRef),
+		}))
+		if err != nil {
+			t.Fatalf("NewClient(...): unexpected error: %s", err)
+		}
+
+		body := httpClient.Data{Encrypted: "", Decrypted: ""}
+		headers := httpClient.Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+		if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, body, headers, true); err != nil {
+			t.Fatalf("SendRequest(...): unexpected error: %s", err)
+		}
+	}
+
+	send()
+	data = clientCertificateSecretData(t, "after-rotation")
+	send()
+
+	if diff := cmp.Diff([]string{"before-rotation", "after-rotation"}, presented); diff != "" {
+		t.Errorf("SendRequest(...): -want presented certificates, +got presented certificates: %s", diff)
+	}
+}
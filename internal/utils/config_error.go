@@ -0,0 +1,52 @@
+package utils
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TypeConfigError resources can't be reconciled until their configuration is fixed.
+	TypeConfigError xpv1.ConditionType = "ConfigError"
+
+	// ReasonInvalidConfig means the configuration of the resource or of its ProviderConfig is invalid.
+	ReasonInvalidConfig xpv1.ConditionReason = "InvalidConfig"
+	// ReasonValidConfig means the configuration is no longer invalid.
+	ReasonValidConfig xpv1.ConditionReason = "ValidConfig"
+)
+
+// ConfigError returns a condition indicating that the resource can't be
+// reconciled because of the given configuration error.
+func ConfigError(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConfigError,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalidConfig,
+		Message:            err.Error(),
+	}
+}
+
+// ConfigValid returns a condition indicating that the configuration error was fixed.
+func ConfigValid() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConfigError,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonValidConfig,
+	}
+}
+
+// SetConfigErrorCondition sets the ConfigError condition of the resource from the given
+// configuration error, nil meaning the configuration is valid. Resources that never had
+// a configuration error don't get the condition.
+func SetConfigErrorCondition(cr resource.Conditioned, err error) {
+	switch {
+	case err != nil:
+		cr.SetConditions(ConfigError(err))
+	case cr.GetCondition(TypeConfigError).Status == corev1.ConditionTrue:
+		cr.SetConditions(ConfigValid())
+	}
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConflictRetry configures the retries of the Kubernetes operations failing with a conflict, e.g. a
+// status update or a secret patch racing with another writer of the same object.
+type ConflictRetry struct {
+	// Attempts is the maximum number of attempts, the first one included.
+	Attempts int
+
+	// Backoff is the delay before the first retry. It doubles after every retry, and every delay is
+	// jittered by up to its own value so that concurrent writers don't retry in lockstep.
+	Backoff time.Duration
+}
+
+// DefaultConflictRetry is the ConflictRetry of the status updates and secret patches.
+var DefaultConflictRetry = ConflictRetry{Attempts: 5, Backoff: 10 * time.Millisecond}
+
+// SetConflictRetry sets the DefaultConflictRetry, before the controllers are started. At least one
+// attempt is made.
+func SetConflictRetry(attempts int, backoff time.Duration) {
+	DefaultConflictRetry = ConflictRetry{Attempts: max(attempts, 1), Backoff: backoff}
+}
+
+// UpdateStatus updates the status of the object, retrying on conflicts with the resource version of its
+// latest version. The status is owned by the controller, so the one computed for the object is kept.
+func UpdateStatus(ctx context.Context, kube client.Client, obj client.Object) error {
+	return RetryOnConflict(ctx, DefaultConflictRetry, func() error {
+		err := kube.Status().Update(ctx, obj)
+		if !kerrors.IsConflict(err) {
+			return err
+		}
+
+		latest, ok := obj.DeepCopyObject().(client.Object)
+		if !ok {
+			return err
+		}
+		if getErr := kube.Get(ctx, client.ObjectKeyFromObject(obj), latest); getErr != nil {
+			return getErr
+		}
+		obj.SetResourceVersion(latest.GetResourceVersion())
+		return err
+	})
+}
+
+// RetryOnConflict calls fn until it doesn't fail with a conflict, up to the configured number of attempts,
+// and returns its last error. fn must read the latest version of the object it writes, since the
+// version it wrote last is stale.
+func RetryOnConflict(ctx context.Context, r ConflictRetry, fn func() error) error {
+	backoff := r.Backoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !kerrors.IsConflict(err) || attempt >= r.Attempts {
+			return err
+		}
+
+		timer := time.NewTimer(wait.Jitter(backoff, 1.0))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
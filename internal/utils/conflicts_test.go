@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_RetryOnConflict(t *testing.T) {
+	errConflict := kerrors.NewConflict(schema.GroupResource{Resource: "secrets"}, "token", errors.New("the object has been modified"))
+	errBoom := errors.New("boom")
+
+	type args struct {
+		retry     ConflictRetry
+		failures  []error
+		cancelled bool
+	}
+	type want struct {
+		err   error
+		calls int
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SucceedsFirstTime": {
+			args: args{
+				retry: ConflictRetry{Attempts: 3, Backoff: time.Millisecond},
+			},
+			want: want{
+				calls: 1,
+			},
+		},
+		"SucceedsAfterConflicts": {
+			args: args{
+				retry:    ConflictRetry{Attempts: 3, Backoff: time.Millisecond},
+				failures: []error{errConflict, errors.Wrap(errConflict, "cannot update secret")},
+			},
+			want: want{
+				calls: 3,
+			},
+		},
+		"GivesUpAfterTheCap": {
+			args: args{
+				retry:    ConflictRetry{Attempts: 3, Backoff: time.Millisecond},
+				failures: []error{errConflict, errConflict, errConflict, errConflict},
+			},
+			want: want{
+				err:   errConflict,
+				calls: 3,
+			},
+		},
+		"OtherErrorsAreNotRetried": {
+			args: args{
+				retry:    ConflictRetry{Attempts: 3, Backoff: time.Millisecond},
+				failures: []error{errBoom},
+			},
+			want: want{
+				err:   errBoom,
+				calls: 1,
+			},
+		},
+		"CancelledContextStopsRetries": {
+			args: args{
+				retry:     ConflictRetry{Attempts: 3, Backoff: time.Hour},
+				failures:  []error{errConflict, errConflict},
+				cancelled: true,
+			},
+			want: want{
+				err:   errConflict,
+				calls: 1,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.args.cancelled {
+				cancel()
+			}
+
+			calls := 0
+			err := RetryOnConflict(ctx, tc.args.retry, func() error {
+				calls++
+				if calls <= len(tc.args.failures) {
+					return tc.args.failures[calls-1]
+				}
+				return nil
+			})
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("RetryOnConflict(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("RetryOnConflict(...): -want calls, +got calls: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_UpdateStatus(t *testing.T) {
+	errConflict := kerrors.NewConflict(schema.GroupResource{Resource: "requests"}, "request", errors.New("the object has been modified"))
+
+	type args struct {
+		conflicts int
+	}
+	type want struct {
+		err             error
+		resourceVersion string
+		updates         int
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpdatedFirstTime": {
+			want: want{
+				resourceVersion: "1",
+				updates:         1,
+			},
+		},
+		"UpdatedWithTheLatestResourceVersion": {
+			args: args{
+				conflicts: 2,
+			},
+			want: want{
+				resourceVersion: "2",
+				updates:         3,
+			},
+		},
+		"GivesUpAfterTheCap": {
+			args: args{
+				conflicts: 10,
+			},
+			want: want{
+				err:             errConflict,
+				resourceVersion: "2",
+				updates:         DefaultConflictRetry.Attempts,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			updates := 0
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					obj.SetResourceVersion("2")
+					return nil
+				},
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					updates++
+					if updates <= tc.args.conflicts {
+						return errConflict
+					}
+					return nil
+				},
+			}
+
+			obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "request", ResourceVersion: "1"}}
+			err := UpdateStatus(context.Background(), kube, obj)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("UpdateStatus(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.resourceVersion, obj.GetResourceVersion()); diff != "" {
+				t.Errorf("UpdateStatus(...): -want resource version, +got resource version: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.updates, updates); diff != "" {
+				t.Errorf("UpdateStatus(...): -want updates, +got updates: %s", diff)
+			}
+		})
+	}
+}
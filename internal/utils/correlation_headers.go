@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+// CorrelationHeaders returns the correlation headers of the requests sent by a reconcile of the given resource
+// at the given time, whose failed attempts are recorded in its status. Nil is returned when none is configured.
+func CorrelationHeaders(config *common.CorrelationHeaders, obj metav1.Object, failed int32, now time.Time) map[string]string {
+	if config == nil {
+		return nil
+	}
+
+	headers := map[string]string{}
+	if config.Timestamp != "" {
+		headers[config.Timestamp] = now.UTC().Format(time.RFC3339)
+	}
+	if config.Attempt != "" {
+		headers[config.Attempt] = strconv.FormatInt(int64(failed)+1, 10)
+	}
+	if config.Generation != "" {
+		headers[config.Generation] = strconv.FormatInt(obj.GetGeneration(), 10)
+	}
+
+	if len(headers) == 0 {
+		return nil
+	}
+	return headers
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+func Test_CorrelationHeaders(t *testing.T) {
+	now := time.Date(2024, 5, 13, 12, 38, 22, 0, time.FixedZone("CEST", 2*60*60))
+	allHeaders := &common.CorrelationHeaders{
+		Timestamp:  "X-Reconcile-Timestamp",
+		Attempt:    "X-Reconcile-Attempt",
+		Generation: "X-Resource-Generation",
+	}
+
+	type args struct {
+		config     *common.CorrelationHeaders
+		generation int64
+		failed     int32
+	}
+
+	cases := map[string]struct {
+		args args
+		want map[string]string
+	}{
+		"NotConfigured": {
+			args: args{generation: 3},
+		},
+		"NoHeaderNamed": {
+			args: args{config: &common.CorrelationHeaders{}, generation: 3},
+		},
+		"FirstAttempt": {
+			args: args{config: allHeaders, generation: 3},
+			want: map[string]string{
+				"X-Reconcile-Timestamp": "2024-05-13T10:38:22Z",
+				"X-Reconcile-Attempt":   "1",
+				"X-Resource-Generation": "3",
+			},
+		},
+		"AttemptAfterFailures": {
+			args: args{config: allHeaders, generation: 4, failed: 2},
+			want: map[string]string{
+				"X-Reconcile-Timestamp": "2024-05-13T10:38:22Z",
+				"X-Reconcile-Attempt":   "3",
+				"X-Resource-Generation": "4",
+			},
+		},
+		"OnlyAttempt": {
+			args: args{config: &common.CorrelationHeaders{Attempt: "X-Attempt"}, generation: 3, failed: 1},
+			want: map[string]string{
+				"X-Attempt": "2",
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Generation: tc.args.generation}
+			got := CorrelationHeaders(tc.args.config, obj, tc.args.failed, now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("CorrelationHeaders(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
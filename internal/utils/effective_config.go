@@ -0,0 +1,249 @@
+package utils
+
+import (
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const errWaitTimeout = "invalid wait timeout"
+
+// ConnectionParameters are the parameters of a resource that are merged with its ProviderConfig
+// into the configuration of its HTTP client. Failed is the number of failed attempts recorded in
+// the status of the resource, and PropagateTraceContext whether the provider was started with
+// trace context propagation.
+type ConnectionParameters struct {
+	WaitTimeout           *metav1.Duration
+	InsecureSkipTLSVerify bool
+	TLSRenegotiation      string
+	RoutingProfile        string
+	Proxy                 *common.ProxyConfig
+	TLS                   *common.TLSConfig
+	MaxResponseBodyBytes  *int64
+	MaxStatusBodyBytes    int
+	UseCookieJar          bool
+	CorrelationHeaders    *common.CorrelationHeaders
+	Failed                int32
+	PropagateTraceContext bool
+}
+
+// EffectiveConfig is the configuration a resource is reconciled with, once the parameters of the
+// resource are merged with its ProviderConfig and the defaults. It holds no secret values: the
+// credentials are described by their secret references and the proxy URL is redacted. The options
+// of the HTTP client of the resource are built from it, see ClientOptions.
+type EffectiveConfig struct {
+	Kind                  string                       `json:"kind"`
+	Name                  string                       `json:"name"`
+	ProviderConfig        string                       `json:"providerConfig"`
+	Credentials           EffectiveCredentials         `json:"credentials"`
+	OAuth2                *EffectiveOAuth2             `json:"oauth2,omitempty"`
+	WaitTimeout           string                       `json:"waitTimeout"`
+	ConnectTimeout        string                       `json:"connectTimeout"`
+	Retry                 *apisv1alpha1.RetryPolicy    `json:"retry,omitempty"`
+	RetryConnectionDrops  bool                         `json:"retryConnectionDrops"`
+	FollowRedirects       bool                         `json:"followRedirects"`
+	MaxRedirects          int                          `json:"maxRedirects"`
+	MaxResponseBodyBytes  int64                        `json:"maxResponseBodyBytes"`
+	MaxStatusBodyBytes    int                          `json:"maxStatusBodyBytes,omitempty"`
+	Proxy                 *common.ProxyConfig          `json:"proxy,omitempty"`
+	RoutingProfile        *apisv1alpha1.RoutingProfile `json:"routingProfile,omitempty"`
+	TLS                   *common.TLSConfig            `json:"tls,omitempty"`
+	InsecureSkipTLSVerify bool                         `json:"insecureSkipTLSVerify"`
+	TLSRenegotiation      string                       `json:"tlsRenegotiation,omitempty"`
+	TLSDiagnostics        bool                         `json:"tlsDiagnostics"`
+	Tracing               *apisv1alpha1.TracingConfig  `json:"tracing,omitempty"`
+	DuplicateHeaderPolicy string                       `json:"duplicateHeaderPolicy,omitempty"`
+	RequestHedging        *apisv1alpha1.RequestHedging `json:"requestHedging,omitempty"`
+	RedactedHeaders       []string                     `json:"redactedHeaders,omitempty"`
+	RequestInterceptorRef *common.ConfigMapKeyRef      `json:"requestInterceptorRef,omitempty"`
+	TraceContext          bool                         `json:"traceContext"`
+	CorrelationHeaders    *common.CorrelationHeaders   `json:"correlationHeaders,omitempty"`
+	UseCookieJar          bool                         `json:"useCookieJar,omitempty"`
+
+	mg             resource.Managed
+	failed         int32
+	waitTimeout    time.Duration
+	connectTimeout time.Duration
+	proxyURL       string
+	redirects      bool
+}
+
+// EffectiveCredentials describes where the credentials of the requests come from.
+type EffectiveCredentials struct {
+	Source    xpv1.CredentialsSource  `json:"source"`
+	SecretRef *xpv1.SecretKeySelector `json:"secretRef,omitempty"`
+}
+
+// EffectiveOAuth2 describes the OAuth2 client credentials grant of the requests.
+type EffectiveOAuth2 struct {
+	TokenURL              string                 `json:"tokenURL"`
+	ClientIDSecretRef     xpv1.SecretKeySelector `json:"clientIDSecretRef"`
+	ClientSecretSecretRef xpv1.SecretKeySelector `json:"clientSecretSecretRef"`
+	Scopes                []string               `json:"scopes,omitempty"`
+	Audience              string                 `json:"audience,omitempty"`
+}
+
+// MaxResponseBodyBytes returns the maximum size of the response bodies of a resource: its own limit
+// when it overrides the one of its ProviderConfig, or else the limit of the ProviderConfig, nil
+// meaning the default limit is used.
+func MaxResponseBodyBytes(override *int64, pc *apisv1alpha1.ProviderConfig) *int64 {
+	if override != nil {
+		return override
+	}
+
+	return pc.Spec.MaxResponseBodyBytes
+}
+
+// NewEffectiveConfig merges the connection parameters of a resource with its ProviderConfig and
+// the defaults. Invalid configurations return the error the resource is reconciled with.
+func NewEffectiveConfig(mg resource.Managed, kind string, params ConnectionParameters, pc *apisv1alpha1.ProviderConfig) (*EffectiveConfig, error) {
+	timeout, err := WaitTimeout(params.WaitTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, errWaitTimeout)
+	}
+
+	ec := &EffectiveConfig{
+		Kind:                  kind,
+		Name:                  mg.GetName(),
+		ProviderConfig:        pc.GetName(),
+		Credentials:           EffectiveCredentials{Source: pc.Spec.Credentials.Source, SecretRef: pc.Spec.Credentials.SecretRef},
+		WaitTimeout:           timeout.String(),
+		ConnectTimeout:        httpClient.DefaultConnectTimeout.String(),
+		Retry:                 pc.Spec.Retry,
+		RetryConnectionDrops:  true,
+		FollowRedirects:       true,
+		MaxRedirects:          httpClient.DefaultMaxRedirects,
+		MaxResponseBodyBytes:  httpClient.DefaultMaxResponseBodyBytes,
+		MaxStatusBodyBytes:    params.MaxStatusBodyBytes,
+		TLS:                   TLSConfig(params.TLS, pc),
+		InsecureSkipTLSVerify: params.InsecureSkipTLSVerify,
+		TLSRenegotiation:      params.TLSRenegotiation,
+		TLSDiagnostics:        pc.Spec.TLSDiagnostics,
+		Tracing:               pc.Spec.Tracing,
+		DuplicateHeaderPolicy: pc.Spec.DuplicateHeaderPolicy,
+		RequestHedging:        pc.Spec.RequestHedging,
+		RedactedHeaders:       pc.Spec.RedactedHeaders,
+		RequestInterceptorRef: pc.Spec.RequestInterceptorRef,
+		TraceContext:          params.PropagateTraceContext,
+		CorrelationHeaders:    params.CorrelationHeaders,
+		UseCookieJar:          params.UseCookieJar,
+		mg:                    mg,
+		failed:                params.Failed,
+		waitTimeout:           timeout,
+		connectTimeout:        httpClient.DefaultConnectTimeout,
+		// The redirect policy of the Go client is kept unless the ProviderConfig configures one.
+		redirects: pc.Spec.FollowRedirects != nil || pc.Spec.MaxRedirects != nil,
+	}
+
+	if o := pc.Spec.OAuth2; o != nil {
+		ec.OAuth2 = &EffectiveOAuth2{
+			TokenURL:              o.TokenURL,
+			ClientIDSecretRef:     o.ClientIDSecretRef,
+			ClientSecretSecretRef: o.ClientSecretSecretRef,
+			Scopes:                o.Scopes,
+			Audience:              o.Audience,
+		}
+	}
+	if pc.Spec.ConnectTimeout != nil {
+		ec.connectTimeout = pc.Spec.ConnectTimeout.Duration
+		ec.ConnectTimeout = ec.connectTimeout.String()
+	}
+	if pc.Spec.RetryConnectionDrops != nil {
+		ec.RetryConnectionDrops = *pc.Spec.RetryConnectionDrops
+	}
+	if pc.Spec.FollowRedirects != nil {
+		ec.FollowRedirects = *pc.Spec.FollowRedirects
+	}
+	if pc.Spec.MaxRedirects != nil {
+		ec.MaxRedirects = *pc.Spec.MaxRedirects
+	}
+	if maxBytes := MaxResponseBodyBytes(params.MaxResponseBodyBytes, pc); maxBytes != nil {
+		ec.MaxResponseBodyBytes = *maxBytes
+	}
+	if proxy := ProxyConfig(params.Proxy, pc); proxy != nil {
+		ec.proxyURL = proxy.URL
+		ec.Proxy = &common.ProxyConfig{URL: httpClient.RedactProxyURL(proxy.URL), NoProxy: proxy.NoProxy}
+	}
+	if params.RoutingProfile != "" {
+		profile, err := RoutingProfile(pc, params.RoutingProfile)
+		if err != nil {
+			return nil, err
+		}
+		ec.RoutingProfile = &profile
+	}
+
+	return ec, nil
+}
+
+// WaitTimeoutDuration returns the maximum time to wait for the responses.
+func (ec *EffectiveConfig) WaitTimeoutDuration() time.Duration {
+	return ec.waitTimeout
+}
+
+// ClientOptions returns the options of the HTTP client of the resource. The OAuth2 client
+// credentials, the CA bundle and the request interceptor are left to the caller, as reading them may fail.
+func (ec *EffectiveConfig) ClientOptions(kube client.Client) []httpClient.ClientOption {
+	opts := []httpClient.ClientOption{
+		httpClient.WithMetricsLabels(ec.Kind, ec.ProviderConfig),
+		httpClient.WithConnectTimeout(ec.connectTimeout),
+		httpClient.WithMaxResponseBodyBytes(ec.MaxResponseBodyBytes),
+		httpClient.WithConnectionDropRetries(ec.RetryConnectionDrops),
+	}
+	if ec.UseCookieJar {
+		opts = append(opts, httpClient.WithCookieJar())
+	}
+	if ec.TLSRenegotiation != "" {
+		opts = append(opts, httpClient.WithTLSRenegotiation(ec.TLSRenegotiation))
+	}
+	if rp := ec.Retry; rp != nil {
+		opts = append(opts, httpClient.WithRetryPolicy(rp.MaxRetries, rp.IdempotentMethods))
+	}
+	if ec.Tracing != nil {
+		opts = append(opts, httpClient.WithTracing(ec.Tracing.Metrics))
+	}
+	if ec.DuplicateHeaderPolicy != "" {
+		opts = append(opts, httpClient.WithDuplicateHeaderPolicy(ec.DuplicateHeaderPolicy))
+	}
+	if len(ec.RedactedHeaders) != 0 {
+		opts = append(opts, httpClient.WithRedactedHeaders(ec.RedactedHeaders))
+	}
+	if ec.TLSDiagnostics {
+		opts = append(opts, httpClient.WithTLSDiagnostics())
+	}
+	if ec.RequestHedging != nil {
+		opts = append(opts, httpClient.WithHedging(ec.RequestHedging.Delay.Duration))
+	}
+	if ec.Proxy != nil {
+		opts = append(opts, httpClient.WithProxy(ec.proxyURL, ec.Proxy.NoProxy))
+	}
+	if tlsConfig := ec.TLS; tlsConfig != nil {
+		opts = append(opts, httpClient.WithTLSConfig(httpClient.TLSConfigData{
+			MinVersion:        tlsConfig.MinVersion,
+			CipherSuites:      tlsConfig.CipherSuites,
+			ServerName:        tlsConfig.ServerName,
+			ClientCertificate: ClientCertificate(kube, tlsConfig.ClientCertificateSecretRef),
+		}))
+	}
+	if ec.TraceContext {
+		opts = append(opts, httpClient.WithTraceContextPropagation())
+	}
+	if headers := CorrelationHeaders(ec.CorrelationHeaders, ec.mg, ec.failed, time.Now()); headers != nil {
+		opts = append(opts, httpClient.WithExtraHeaders(headers))
+	}
+	if ec.redirects {
+		opts = append(opts, httpClient.WithRedirects(ec.FollowRedirects, ec.MaxRedirects))
+	}
+	if profile := ec.RoutingProfile; profile != nil {
+		opts = append(opts, httpClient.WithRouting(profile.Address, profile.Host, profile.ServerName))
+	}
+
+	return opts
+}
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// ManagementPolicies returns the checker of the actions the management policies of the resource allow,
+// which also takes its deletionPolicy into account. Unset policies default as in the CRDs.
+func ManagementPolicies(mg resource.Managed) managed.ManagementPoliciesChecker {
+	policies := mg.GetManagementPolicies()
+	if len(policies) == 0 {
+		policies = xpv1.ManagementPolicies{xpv1.ManagementActionAll}
+	}
+	deletionPolicy := mg.GetDeletionPolicy()
+	if deletionPolicy == "" {
+		deletionPolicy = xpv1.DeletionDelete
+	}
+
+	return managed.NewManagementPoliciesResolver(true, policies, deletionPolicy)
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+const errOAuth2SecretKey = "key %s not found in secret %s:%s"
+
+// OAuth2Config returns the OAuth2 configuration of the ProviderConfig, with the client credentials
+// read from their secrets.
+func OAuth2Config(ctx context.Context, kube client.Client, oauth2 *apisv1alpha1.OAuth2, pc *apisv1alpha1.ProviderConfig) (httpClient.OAuth2Config, error) {
+	clientID, err := secretKeyValue(ctx, kube, oauth2.ClientIDSecretRef)
+	if err != nil {
+		return httpClient.OAuth2Config{}, err
+	}
+
+	clientSecret, err := secretKeyValue(ctx, kube, oauth2.ClientSecretSecretRef)
+	if err != nil {
+		return httpClient.OAuth2Config{}, err
+	}
+
+	var caBundle []byte
+	if tlsConfig := pc.Spec.TLS; tlsConfig != nil {
+		if caBundle, err = CABundle(ctx, kube, tlsConfig.CABundleSecretRef); err != nil {
+			return httpClient.OAuth2Config{}, err
+		}
+	}
+
+	return httpClient.OAuth2Config{
+		TokenURL:         oauth2.TokenURL,
+		ClientID:         clientID,
+		ClientSecret:     clientSecret,
+		Scopes:           oauth2.Scopes,
+		Audience:         oauth2.Audience,
+		TransportOptions: oauth2TransportOptions(kube, pc, caBundle),
+		TransportKey:     oauth2TransportKey(pc, caBundle),
+	}, nil
+}
+
+// oauth2TransportOptions configure the connections to the token endpoint with the TLS and proxy of the
+// ProviderConfig. The settings of the resources, e.g. insecureSkipTLSVerify or their routing profile, don't
+// apply, and neither does the server name, which is the one of the servers of the requests.
+func oauth2TransportOptions(kube client.Client, pc *apisv1alpha1.ProviderConfig, caBundle []byte) []httpClient.ClientOption {
+	var opts []httpClient.ClientOption
+	if proxy := pc.Spec.Proxy; proxy != nil {
+		opts = append(opts, httpClient.WithProxy(proxy.URL, proxy.NoProxy))
+	}
+	if tlsConfig := pc.Spec.TLS; tlsConfig != nil {
+		opts = append(opts, httpClient.WithTLSConfig(httpClient.TLSConfigData{
+			MinVersion:        tlsConfig.MinVersion,
+			CipherSuites:      tlsConfig.CipherSuites,
+			ClientCertificate: ClientCertificate(kube, tlsConfig.ClientCertificateSecretRef),
+		}))
+	}
+	if caBundle != nil {
+		opts = append(opts, httpClient.WithRootCAs(caBundle))
+	}
+
+	return opts
+}
+
+// oauth2TransportKey identifies the TLS, CA bundle and proxy of the ProviderConfig, without exposing the
+// credentials of its proxy.
+func oauth2TransportKey(pc *apisv1alpha1.ProviderConfig, caBundle []byte) string {
+	settings, _ := json.Marshal(struct {
+		Proxy    *common.ProxyConfig `json:"proxy"`
+		TLS      *common.TLSConfig   `json:"tls"`
+		CABundle []byte              `json:"caBundle"`
+	}{Proxy: pc.Spec.Proxy, TLS: pc.Spec.TLS, CABundle: caBundle})
+	sum := sha256.Sum256(settings)
+	return hex.EncodeToString(sum[:])
+}
+
+// secretKeyValue returns the value of the key of a secret.
+func secretKeyValue(ctx context.Context, kube client.Client, ref xpv1.SecretKeySelector) (string, error) {
+	secret, err := kubehandler.GetSecret(ctx, kube, ref.Name, ref.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf(errOAuth2SecretKey, ref.Key, ref.Namespace, ref.Name)
+	}
+
+	return string(value), nil
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+// DefaultOutcomeWindow is the number of recent requests of a resource its success rate is computed over.
+const DefaultOutcomeWindow = 20
+
+// requestSuccessRate is the success rate of the recent requests of each resource. The requests themselves
+// are counted by status code class by the HTTP client.
+var requestSuccessRate = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "provider_http_request_success_rate",
+	Help: "Ratio of the recent HTTP requests of a resource that got a response without a server error.",
+}, []string{"kind", "name", "uid"})
+
+func init() {
+	metrics.Registry.MustRegister(requestSuccessRate)
+}
+
+// OutcomeTracker tracks the outcomes of the recent requests of each resource of a kind over a sliding window,
+// to expose their success rate. A request succeeds when it gets a response that is not a server error, so that
+// e.g. the 404 of an observation isn't reported as a flaky integration.
+type OutcomeTracker struct {
+	kind   string
+	window int
+
+	mu       sync.Mutex
+	outcomes map[types.UID][]bool
+}
+
+// NewOutcomeTracker returns an OutcomeTracker computing the success rate of the resources
+// of the given kind over their last window requests.
+func NewOutcomeTracker(kind string, window int) *OutcomeTracker {
+	return &OutcomeTracker{
+		kind:     kind,
+		window:   window,
+		outcomes: make(map[types.UID][]bool),
+	}
+}
+
+// Record records the outcome of a request sent for the resource and updates its metrics.
+func (t *OutcomeTracker) Record(obj metav1.Object, statusCode int, err error) {
+	if t == nil {
+		return
+	}
+
+	class := httpClient.StatusCodeClass(statusCode, err)
+
+	// The success rate of a resource being deleted is not tracked anymore.
+	if obj.GetDeletionTimestamp() != nil {
+		t.Forget(obj)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	outcomes := append(t.outcomes[obj.GetUID()], class != httpClient.StatusCodeClassError && statusCode < http.StatusInternalServerError)
+	if len(outcomes) > t.window {
+		outcomes = outcomes[len(outcomes)-t.window:]
+	}
+	t.outcomes[obj.GetUID()] = outcomes
+
+	requestSuccessRate.WithLabelValues(t.kind, obj.GetName(), string(obj.GetUID())).Set(successRatio(outcomes))
+}
+
+// SuccessRate returns the success rate of the recent requests of the resource, and false
+// when no request was recorded for it.
+func (t *OutcomeTracker) SuccessRate(obj metav1.Object) (float64, bool) {
+	if t == nil {
+		return 0, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	outcomes, ok := t.outcomes[obj.GetUID()]
+	return successRatio(outcomes), ok
+}
+
+// Forget stops tracking the resource and removes its success rate metric.
+func (t *OutcomeTracker) Forget(obj metav1.Object) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.outcomes, obj.GetUID())
+	requestSuccessRate.DeleteLabelValues(t.kind, obj.GetName(), string(obj.GetUID()))
+}
+
+// successRatio returns the ratio of the successful outcomes.
+func successRatio(outcomes []bool) float64 {
+	if len(outcomes) == 0 {
+		return 0
+	}
+
+	succeeded := 0
+	for _, ok := range outcomes {
+		if ok {
+			succeeded++
+		}
+	}
+
+	return float64(succeeded) / float64(len(outcomes))
+}
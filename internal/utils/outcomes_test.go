@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestOutcomeTracker(t *testing.T) {
+	type outcome struct {
+		statusCode int
+		err        error
+	}
+	type want struct {
+		successRate float64
+	}
+
+	cases := map[string]struct {
+		window   int
+		outcomes []outcome
+		want     want
+	}{
+		"AllSucceeded": {
+			window:   DefaultOutcomeWindow,
+			outcomes: []outcome{{statusCode: 200}, {statusCode: 201}, {statusCode: 204}},
+			want:     want{successRate: 1},
+		},
+		"ClientErrorsSucceed": {
+			window:   DefaultOutcomeWindow,
+			outcomes: []outcome{{statusCode: 404}, {statusCode: 200}},
+			want:     want{successRate: 1},
+		},
+		"ServerErrorsAndNoResponseFail": {
+			window:   DefaultOutcomeWindow,
+			outcomes: []outcome{{statusCode: 200}, {statusCode: 503}, {err: errBoom}, {statusCode: 200}},
+			want:     want{successRate: 0.5},
+		},
+		"OnlyRecentOutcomesCount": {
+			window:   2,
+			outcomes: []outcome{{statusCode: 500}, {statusCode: 500}, {statusCode: 200}, {statusCode: 500}},
+			want:     want{successRate: 0.5},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			obj := &v1.ObjectMeta{Name: name, UID: types.UID(name)}
+			tracker := NewOutcomeTracker("Request", tc.window)
+			for _, o := range tc.outcomes {
+				tracker.Record(obj, o.statusCode, o.err)
+			}
+
+			got, ok := tracker.SuccessRate(obj)
+			if !ok {
+				t.Fatalf("SuccessRate(...): no outcome recorded")
+			}
+			if diff := cmp.Diff(tc.want.successRate, got); diff != "" {
+				t.Errorf("SuccessRate(...): -want success rate, +got success rate: %s", diff)
+			}
+			gauge := testutil.ToFloat64(requestSuccessRate.WithLabelValues("Request", name, name))
+			if diff := cmp.Diff(tc.want.successRate, gauge); diff != "" {
+				t.Errorf("Record(...): -want gauge, +got gauge: %s", diff)
+			}
+
+			tracker.Forget(obj)
+			if _, ok := tracker.SuccessRate(obj); ok {
+				t.Errorf("Forget(...): the resource is still tracked")
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"context"
+	"strconv"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// PausedKey is the key of the pause ConfigMap that pauses all reconciles when set to true.
+	PausedKey = "paused"
+
+	// TypePaused resources are not reconciled because the provider is paused.
+	TypePaused xpv1.ConditionType = "Paused"
+
+	// ReasonProviderPaused means the provider is paused by its pause ConfigMap.
+	ReasonProviderPaused xpv1.ConditionReason = "ProviderPaused"
+	// ReasonProviderResumed means the provider is no longer paused.
+	ReasonProviderResumed xpv1.ConditionReason = "ProviderResumed"
+
+	errGetPauseConfigMap = "cannot get pause ConfigMap"
+)
+
+// PauseSwitch pauses the reconciles of all resources, without sending any request,
+// while the paused key of a ConfigMap is set to true.
+type PauseSwitch struct {
+	kube      client.Client
+	configMap types.NamespacedName
+}
+
+// NewPauseSwitch returns a PauseSwitch reading the given ConfigMap. The
+// switch is disabled when the name of the ConfigMap is empty.
+func NewPauseSwitch(kube client.Client, configMap types.NamespacedName) *PauseSwitch {
+	return &PauseSwitch{kube: kube, configMap: configMap}
+}
+
+// Paused returns true if the reconciles are paused. A missing ConfigMap doesn't pause them.
+func (p *PauseSwitch) Paused(ctx context.Context) (bool, error) {
+	if p == nil || p.configMap.Name == "" {
+		return false, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := p.kube.Get(ctx, p.configMap, cm); err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, errGetPauseConfigMap)
+	}
+
+	paused, _ := strconv.ParseBool(cm.Data[PausedKey])
+	return paused, nil
+}
+
+// Paused returns a condition indicating that the resource is not reconciled
+// because the provider is paused.
+func Paused() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonProviderPaused,
+	}
+}
+
+// Resumed returns a condition indicating that the provider is no longer paused.
+func Resumed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonProviderResumed,
+	}
+}
+
+// SetPausedCondition sets the Paused condition of the resource. Resources that were never
+// paused don't get the condition.
+func SetPausedCondition(cr resource.Conditioned, paused bool) {
+	switch {
+	case paused:
+		cr.SetConditions(Paused())
+	case cr.GetCondition(TypePaused).Status == corev1.ConditionTrue:
+		cr.SetConditions(Resumed())
+	}
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPauseSwitch_Paused(t *testing.T) {
+	type args struct {
+		configMap types.NamespacedName
+		kube      client.Client
+	}
+	type want struct {
+		paused bool
+		err    error
+	}
+
+	pauseConfigMap := types.NamespacedName{Namespace: "crossplane-system", Name: "provider-http-pause"}
+	withData := func(data map[string]string) client.Client {
+		return &test.MockClient{
+			MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*corev1.ConfigMap).Data = data
+				return nil
+			}),
+		}
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Disabled": {
+			args: args{},
+			want: want{paused: false},
+		},
+		"Paused": {
+			args: args{
+				configMap: pauseConfigMap,
+				kube:      withData(map[string]string{PausedKey: "true"}),
+			},
+			want: want{paused: true},
+		},
+		"NotPaused": {
+			args: args{
+				configMap: pauseConfigMap,
+				kube:      withData(map[string]string{PausedKey: "false"}),
+			},
+			want: want{paused: false},
+		},
+		"ConfigMapNotFound": {
+			args: args{
+				configMap: pauseConfigMap,
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, pauseConfigMap.Name)),
+				},
+			},
+			want: want{paused: false},
+		},
+		"GetFailed": {
+			args: args{
+				configMap: pauseConfigMap,
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetPauseConfigMap)},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, err := NewPauseSwitch(tc.args.kube, tc.args.configMap).Paused(context.Background())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Paused(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.paused, got); diff != "" {
+				t.Fatalf("Paused(...): -want paused, +got paused: %s", diff)
+			}
+		})
+	}
+}
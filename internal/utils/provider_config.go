@@ -0,0 +1,46 @@
+package utils
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	// AnnotationKeyProviderConfig selects the ProviderConfig of a resource that doesn't reference one explicitly.
+	AnnotationKeyProviderConfig = "http.crossplane.io/provider-config"
+
+	// DefaultProviderConfigName is the name of the ProviderConfig used by the resources that don't reference one.
+	DefaultProviderConfigName = "default"
+)
+
+// ProviderConfigReference returns the reference to the ProviderConfig of the resource. A resource that doesn't
+// reference a ProviderConfig, or only references the default one, uses the ProviderConfig selected by its
+// annotation, if any, and the default one otherwise.
+func ProviderConfigReference(mg resource.Managed) *xpv1.Reference {
+	ref := mg.GetProviderConfigReference()
+	if ref != nil && ref.Name != DefaultProviderConfigName {
+		return ref
+	}
+
+	if name := mg.GetAnnotations()[AnnotationKeyProviderConfig]; name != "" {
+		return &xpv1.Reference{Name: name}
+	}
+
+	if ref == nil {
+		return &xpv1.Reference{Name: DefaultProviderConfigName}
+	}
+
+	return ref
+}
+
+// WithProviderConfigReference returns a copy of the resource referencing the given ProviderConfig,
+// e.g. to track the usage of the ProviderConfig selected by its annotation.
+func WithProviderConfigReference(mg resource.Managed, ref *xpv1.Reference) resource.Managed {
+	if mg.GetProviderConfigReference() != nil && *mg.GetProviderConfigReference() == *ref {
+		return mg
+	}
+
+	selected := mg.DeepCopyObject().(resource.Managed)
+	selected.SetProviderConfigReference(ref)
+	return selected
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha2_request "github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func Test_ProviderConfigReference(t *testing.T) {
+	type args struct {
+		ref         *xpv1.Reference
+		annotations map[string]string
+	}
+	type want struct {
+		ref *xpv1.Reference
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"AnnotationOverridesDefault": {
+			args: args{
+				ref:         &xpv1.Reference{Name: DefaultProviderConfigName},
+				annotations: map[string]string{AnnotationKeyProviderConfig: "tenant-a"},
+			},
+			want: want{ref: &xpv1.Reference{Name: "tenant-a"}},
+		},
+		"AnnotationUsedWithoutReference": {
+			args: args{
+				annotations: map[string]string{AnnotationKeyProviderConfig: "tenant-a"},
+			},
+			want: want{ref: &xpv1.Reference{Name: "tenant-a"}},
+		},
+		"ExplicitReferenceWins": {
+			args: args{
+				ref:         &xpv1.Reference{Name: "explicit"},
+				annotations: map[string]string{AnnotationKeyProviderConfig: "tenant-a"},
+			},
+			want: want{ref: &xpv1.Reference{Name: "explicit"}},
+		},
+		"DefaultWithoutAnnotation": {
+			args: args{
+				ref: &xpv1.Reference{Name: DefaultProviderConfigName},
+			},
+			want: want{ref: &xpv1.Reference{Name: DefaultProviderConfigName}},
+		},
+		"DefaultWithoutReference": {
+			args: args{},
+			want: want{ref: &xpv1.Reference{Name: DefaultProviderConfigName}},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2_request.Request{ObjectMeta: v1.ObjectMeta{Name: "test", Annotations: tc.args.annotations}}
+			cr.SetProviderConfigReference(tc.args.ref)
+
+			got := ProviderConfigReference(cr)
+			if diff := cmp.Diff(tc.want.ref, got); diff != "" {
+				t.Fatalf("ProviderConfigReference(...): -want reference, +got reference: %s", diff)
+			}
+
+			tracked := WithProviderConfigReference(cr, got)
+			if diff := cmp.Diff(tc.want.ref, tracked.GetProviderConfigReference()); diff != "" {
+				t.Fatalf("WithProviderConfigReference(...): -want reference, +got reference: %s", diff)
+			}
+			if diff := cmp.Diff(tc.args.ref, cr.GetProviderConfigReference()); diff != "" {
+				t.Fatalf("WithProviderConfigReference(...): the resource was modified: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+const errRequestInterceptorKey = "key %s not found in ConfigMap %s:%s"
+
+// RequestInterceptor returns the jq program of the request interceptor, read from the referenced
+// ConfigMap key.
+func RequestInterceptor(ctx context.Context, kube client.Client, ref *common.ConfigMapKeyRef) (string, error) {
+	configMap, err := kubehandler.GetConfigMap(ctx, kube, ref.Name, ref.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	program, ok := configMap.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf(errRequestInterceptorKey, ref.Key, ref.Namespace, ref.Name)
+	}
+
+	return program, nil
+}
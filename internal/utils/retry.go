@@ -3,11 +3,14 @@ package utils
 import (
 	"time"
 
+	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	defaultWaitTimeout = 5 * time.Minute
+
+	errNegativeWaitTimeout = "waitTimeout must not be negative, got %s"
 )
 
 // ShouldRetry determines if the request should be retried based on the status of the request and the rollback retries limit.
@@ -25,12 +28,18 @@ func RetriesLimitReached(statusFailed int32, rollbackRetriesLimit *int32) bool {
 	return statusFailed >= *rollbackRetriesLimit
 }
 
-// WaitTimeout returns the wait timeout duration.
-func WaitTimeout(timeout *v1.Duration) time.Duration {
-	if timeout != nil {
-		return timeout.Duration
+// WaitTimeout returns the wait timeout duration. An unset or zero timeout means the
+// provider default is used, while a negative timeout is rejected.
+func WaitTimeout(timeout *v1.Duration) (time.Duration, error) {
+	if timeout == nil || timeout.Duration == 0 {
+		return defaultWaitTimeout, nil
+	}
+
+	if timeout.Duration < 0 {
+		return 0, errors.Errorf(errNegativeWaitTimeout, timeout.Duration)
 	}
-	return defaultWaitTimeout
+
+	return timeout.Duration, nil
 }
 
 // GetRollbackRetriesLimit returns the rollback retries limit.
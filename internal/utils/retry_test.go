@@ -4,7 +4,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -155,12 +157,13 @@ func Test_WaitTimeout(t *testing.T) {
 	}
 	type want struct {
 		result time.Duration
+		err    error
 	}
 	cases := map[string]struct {
 		args args
 		want want
 	}{
-		"ResultTrue": {
+		"Positive": {
 			args: args{
 				timeout: testTimeout,
 			},
@@ -168,7 +171,7 @@ func Test_WaitTimeout(t *testing.T) {
 				result: testTimeout.Duration,
 			},
 		},
-		"ResultFalse": {
+		"Unset": {
 			args: args{
 				timeout: nil,
 			},
@@ -176,10 +179,29 @@ func Test_WaitTimeout(t *testing.T) {
 				result: defaultWaitTimeout,
 			},
 		},
+		"ZeroUsesDefault": {
+			args: args{
+				timeout: &v1.Duration{Duration: 0},
+			},
+			want: want{
+				result: defaultWaitTimeout,
+			},
+		},
+		"NegativeRejected": {
+			args: args{
+				timeout: &v1.Duration{Duration: -time.Second},
+			},
+			want: want{
+				err: errors.Errorf(errNegativeWaitTimeout, -time.Second),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got := WaitTimeout(tc.args.timeout)
+			got, gotErr := WaitTimeout(tc.args.timeout)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("WaitTimeout(...): -want error, +got error: %s", diff)
+			}
 			if diff := cmp.Diff(tc.want.result, got); diff != "" {
 				t.Fatalf("WaitTimeout(...): -want result, +got result: %s", diff)
 			}
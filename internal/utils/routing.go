@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+const errRoutingProfileNotFound = "routing profile %s not found in ProviderConfig %s"
+
+// RoutingProfile returns the routing profile of the ProviderConfig with the given name.
+func RoutingProfile(pc *apisv1alpha1.ProviderConfig, name string) (apisv1alpha1.RoutingProfile, error) {
+	for _, profile := range pc.Spec.RoutingProfiles {
+		if profile.Name == name {
+			return profile, nil
+		}
+	}
+
+	return apisv1alpha1.RoutingProfile{}, errors.Errorf(errRoutingProfileNotFound, name, pc.GetName())
+}
+
+// ProxyConfig returns the proxy of the requests of a resource: its own proxy when it overrides the
+// one of its ProviderConfig, or else the proxy of the ProviderConfig, nil meaning the proxy of the
+// environment is used.
+func ProxyConfig(override *common.ProxyConfig, pc *apisv1alpha1.ProviderConfig) *common.ProxyConfig {
+	if override != nil {
+		return override
+	}
+
+	return pc.Spec.Proxy
+}
+
+// TLSConfig returns the TLS settings of the requests of a resource: the settings of its ProviderConfig,
+// each overridden by the one of the resource when set, nil meaning the defaults. The cipher suites of the
+// ProviderConfig are not inherited when the resource requires TLS 1.3, which doesn't allow configuring them.
+func TLSConfig(override *common.TLSConfig, pc *apisv1alpha1.ProviderConfig) *common.TLSConfig {
+	switch {
+	case override == nil:
+		return pc.Spec.TLS
+	case pc.Spec.TLS == nil:
+		return override
+	}
+
+	merged := pc.Spec.TLS.DeepCopy()
+	if override.MinVersion != "" {
+		merged.MinVersion = override.MinVersion
+		if override.MinVersion == "1.3" {
+			merged.CipherSuites = nil
+		}
+	}
+	if len(override.CipherSuites) != 0 {
+		merged.CipherSuites = override.CipherSuites
+	}
+	if override.ServerName != "" {
+		merged.ServerName = override.ServerName
+	}
+	if override.ClientCertificateSecretRef != nil {
+		merged.ClientCertificateSecretRef = override.ClientCertificateSecretRef
+	}
+	if override.CABundleSecretRef != nil {
+		merged.CABundleSecretRef = override.CABundleSecretRef
+	}
+
+	return merged
+}
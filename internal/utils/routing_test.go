@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+func Test_ProxyConfig(t *testing.T) {
+	providerProxy := &common.ProxyConfig{URL: "http://egress-a.internal:3128"}
+	resourceProxy := &common.ProxyConfig{URL: "http://egress-b.internal:3128", NoProxy: []string{".internal"}}
+
+	cases := map[string]struct {
+		override *common.ProxyConfig
+		pcProxy  *common.ProxyConfig
+		want     *common.ProxyConfig
+	}{
+		"Environment": {},
+		"ProviderConfigProxy": {
+			pcProxy: providerProxy,
+			want:    providerProxy,
+		},
+		"ResourceOverride": {
+			override: resourceProxy,
+			pcProxy:  providerProxy,
+			want:     resourceProxy,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			pc := &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{Proxy: tc.pcProxy}}
+			if diff := cmp.Diff(tc.want, ProxyConfig(tc.override, pc)); diff != "" {
+				t.Fatalf("ProxyConfig(...): -want proxy, +got proxy: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_TLSConfig(t *testing.T) {
+	providerTLS := &common.TLSConfig{
+		MinVersion:                 "1.2",
+		CipherSuites:               []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		ServerName:                 "api.internal",
+		ClientCertificateSecretRef: &common.SecretRef{Name: "client-cert", Namespace: "default"},
+	}
+
+	cases := map[string]struct {
+		override *common.TLSConfig
+		pcTLS    *common.TLSConfig
+		want     *common.TLSConfig
+	}{
+		"Defaults": {},
+		"ProviderConfigTLS": {
+			pcTLS: providerTLS,
+			want:  providerTLS,
+		},
+		"ResourceTLS": {
+			override: &common.TLSConfig{ServerName: "other.internal"},
+			want:     &common.TLSConfig{ServerName: "other.internal"},
+		},
+		"PartialOverride": {
+			override: &common.TLSConfig{ServerName: "other.internal"},
+			pcTLS:    providerTLS,
+			want: &common.TLSConfig{
+				MinVersion:                 "1.2",
+				CipherSuites:               []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+				ServerName:                 "other.internal",
+				ClientCertificateSecretRef: &common.SecretRef{Name: "client-cert", Namespace: "default"},
+			},
+		},
+		"TLS13DropsInheritedCipherSuites": {
+			override: &common.TLSConfig{MinVersion: "1.3"},
+			pcTLS:    providerTLS,
+			want: &common.TLSConfig{
+				MinVersion:                 "1.3",
+				ServerName:                 "api.internal",
+				ClientCertificateSecretRef: &common.SecretRef{Name: "client-cert", Namespace: "default"},
+			},
+		},
+		"CABundleOverride": {
+			override: &common.TLSConfig{CABundleSecretRef: &common.SecretRef{Name: "private-ca", Namespace: "default"}},
+			pcTLS:    providerTLS,
+			want: &common.TLSConfig{
+				MinVersion:                 "1.2",
+				CipherSuites:               []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+				ServerName:                 "api.internal",
+				ClientCertificateSecretRef: &common.SecretRef{Name: "client-cert", Namespace: "default"},
+				CABundleSecretRef:          &common.SecretRef{Name: "private-ca", Namespace: "default"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			pc := &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{TLS: tc.pcTLS}}
+			if diff := cmp.Diff(tc.want, TLSConfig(tc.override, pc)); diff != "" {
+				t.Fatalf("TLSConfig(...): -want TLS, +got TLS: %s", diff)
+			}
+			if tc.pcTLS != nil && tc.pcTLS.ServerName != "api.internal" {
+				t.Fatalf("TLSConfig(...): the settings of the ProviderConfig must not be modified")
+			}
+		})
+	}
+}
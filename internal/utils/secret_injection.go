@@ -0,0 +1,52 @@
+package utils
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TypeSecretInjectionFailed resources couldn't inject the data of their last response into their secrets.
+	TypeSecretInjectionFailed xpv1.ConditionType = "SecretInjectionFailed"
+
+	// ReasonSecretsRolledBack means the secrets were rolled back after one of them couldn't be patched.
+	ReasonSecretsRolledBack xpv1.ConditionReason = "SecretsRolledBack"
+	// ReasonSecretsInjected means the secrets were injected again.
+	ReasonSecretsInjected xpv1.ConditionReason = "SecretsInjected"
+)
+
+// SecretInjectionFailed returns a condition indicating that the secrets couldn't be injected from the
+// response, because of the given error.
+func SecretInjectionFailed(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSecretInjectionFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSecretsRolledBack,
+		Message:            err.Error(),
+	}
+}
+
+// SecretsInjected returns a condition indicating that the secrets were injected after a failure.
+func SecretsInjected() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSecretInjectionFailed,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSecretsInjected,
+	}
+}
+
+// SetSecretInjectionCondition sets the SecretInjectionFailed condition of the resource from the error of
+// the secret injection, nil meaning the secrets were injected. Resources whose injection never failed
+// don't get the condition.
+func SetSecretInjectionCondition(cr resource.Conditioned, err error) {
+	switch {
+	case err != nil:
+		cr.SetConditions(SecretInjectionFailed(err))
+	case cr.GetCondition(TypeSecretInjectionFailed).Status == corev1.ConditionTrue:
+		cr.SetConditions(SecretsInjected())
+	}
+}
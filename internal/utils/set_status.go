@@ -21,6 +21,9 @@ type RequestResource struct {
 	HttpResponse   httpClient.HttpResponse
 	HttpRequest    httpClient.HttpRequest
 	LocalClient    client.Client
+
+	// MaxStatusBodyBytes caps the response body stored in the status, 0 meaning no cap.
+	MaxStatusBodyBytes int
 }
 
 func (rr *RequestResource) SetStatusCode() SetRequestStatusFunc {
@@ -47,7 +50,27 @@ func (rr *RequestResource) SetBody() SetRequestStatusFunc {
 	return func() {
 		if resp, ok := rr.Resource.(ResponseSetter); ok {
 			if rr.HttpResponse.Body != "" {
-				resp.SetBody(rr.HttpResponse.Body)
+				resp.SetBody(TruncateBody(rr.HttpResponse.Body, rr.MaxStatusBodyBytes))
+			}
+		}
+	}
+}
+
+func (rr *RequestResource) SetDurationMs() SetRequestStatusFunc {
+	return func() {
+		if resp, ok := rr.Resource.(ResponseTransportSetter); ok {
+			if rr.HttpResponse.StatusCode != 0 {
+				resp.SetDurationMs(rr.HttpResponse.DurationMs)
+			}
+		}
+	}
+}
+
+func (rr *RequestResource) SetProto() SetRequestStatusFunc {
+	return func() {
+		if resp, ok := rr.Resource.(ResponseTransportSetter); ok {
+			if rr.HttpResponse.Proto != "" {
+				resp.SetProto(rr.HttpResponse.Proto)
 			}
 		}
 	}
@@ -103,6 +126,22 @@ func (rr *RequestResource) ResetFailures() SetRequestStatusFunc {
 	}
 }
 
+func (rr *RequestResource) SetAtProvider() SetRequestStatusFunc {
+	return func() {
+		if atProvider, ok := rr.Resource.(AtProviderSetter); ok {
+			atProvider.SetAtProvider(rr.HttpRequest.Method, rr.HttpRequest.URL, rr.HttpRequest.Body, rr.HttpRequest.Headers, rr.HttpResponse.StatusCode, rr.HttpResponse.Headers, rr.HttpResponse.Body)
+		}
+	}
+}
+
+func (rr *RequestResource) SetMessage(message string) SetRequestStatusFunc {
+	return func() {
+		if messageSetter, ok := rr.Resource.(MessageSetter); ok {
+			messageSetter.SetMessage(message)
+		}
+	}
+}
+
 // ResponseSetter is an interface that defines the methods to set the status code, headers, and body of a resource.
 type ResponseSetter interface {
 	SetStatusCode(statusCode int)
@@ -110,6 +149,13 @@ type ResponseSetter interface {
 	SetBody(body string)
 }
 
+// ResponseTransportSetter is an interface that defines the methods to set the duration and protocol version of
+// the last response of a resource.
+type ResponseTransportSetter interface {
+	SetDurationMs(durationMs int64)
+	SetProto(proto string)
+}
+
 // CacheSetter is an interface that defines the method to set the cache of a resource.
 type CacheSetter interface {
 	SetCache(statusCode int, headers map[string][]string, body string)
@@ -140,11 +186,21 @@ type RequestDetailsSetter interface {
 	SetRequestDetails(url, method, body string, headers map[string][]string)
 }
 
-// SetRequestResourceStatus sets the status of a resource.
+// AtProviderSetter is an interface that defines the method to mirror the last request and response of a resource.
+type AtProviderSetter interface {
+	SetAtProvider(method, url, requestBody string, requestHeaders map[string][]string, statusCode int, responseHeaders map[string][]string, responseBody string)
+}
+
+// MessageSetter is an interface that defines the method to set the status message of a resource.
+type MessageSetter interface {
+	SetMessage(message string)
+}
+
+// SetRequestResourceStatus sets the status of a resource. The update is retried on conflicts.
 func SetRequestResourceStatus(rr RequestResource, statusFuncs ...SetRequestStatusFunc) error {
 	for _, updateStatusFunc := range statusFuncs {
 		updateStatusFunc()
 	}
 
-	return rr.LocalClient.Status().Update(rr.RequestContext, rr.Resource)
+	return UpdateStatus(rr.RequestContext, rr.LocalClient, rr.Resource)
 }
@@ -96,6 +96,8 @@ var (
 		HttpResponse: httpClient.HttpResponse{
 			StatusCode: 200,
 			Body:       `{"ids":"123","username":"john_doe"}`,
+			DurationMs: 42,
+			Proto:      "HTTP/2.0",
 		},
 		HttpRequest: httpClient.HttpRequest{
 			Method: "GET",
@@ -128,6 +130,8 @@ func Test_SetRequestResourceStatus(t *testing.T) {
 					testRequestResource.SetRequestDetails(),
 					testRequestResource.SetHeaders(),
 					testRequestResource.SetStatusCode(),
+					testRequestResource.SetDurationMs(),
+					testRequestResource.SetProto(),
 					testRequestResource.ResetFailures(),
 					testRequestResource.SetCache(),
 				},
@@ -145,6 +149,8 @@ func Test_SetRequestResourceStatus(t *testing.T) {
 					testRequestResource.SetRequestDetails(),
 					testRequestResource.SetHeaders(),
 					testRequestResource.SetStatusCode(),
+					testRequestResource.SetDurationMs(),
+					testRequestResource.SetProto(),
 					testRequestResource.ResetFailures(),
 					testRequestResource.SetCache(),
 					testRequestResource.SetError(errBoom),
@@ -175,6 +181,14 @@ func Test_SetRequestResourceStatus(t *testing.T) {
 				t.Fatalf("SetRequestResourceStatus(...): -want response status code, +got response status code: %s", diff)
 			}
 
+			if diff := cmp.Diff(tc.args.rr.HttpResponse.DurationMs, testRequestCr.Status.Response.DurationMs); diff != "" {
+				t.Fatalf("SetRequestResourceStatus(...): -want response duration, +got response duration: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.args.rr.HttpResponse.Proto, testRequestCr.Status.Response.Proto); diff != "" {
+				t.Fatalf("SetRequestResourceStatus(...): -want response protocol, +got response protocol: %s", diff)
+			}
+
 			if diff := cmp.Diff(tc.args.rr.HttpResponse.StatusCode, testRequestCr.Status.Cache.Response.StatusCode); diff != "" {
 				t.Fatalf("SetRequestResourceStatus(...): -want cache status code, +got cahce status code: %s", diff)
 			}
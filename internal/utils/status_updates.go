@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// DefaultStatusUpdateFailureThreshold is the number of consecutive failed status updates after
+	// which a resource is backed off.
+	DefaultStatusUpdateFailureThreshold = 5
+	// DefaultStatusUpdateBackoff is how long a resource whose status updates keep failing is backed off.
+	DefaultStatusUpdateBackoff = time.Minute
+
+	reasonStatusUpdateFailing event.Reason = "StatusUpdateFailing"
+
+	errStatusUpdateFailing = "status update failed %d consecutive times, the resource schema may not match the installed CRD: %s"
+	errStatusUpdateBackoff = "status update failed %d consecutive times, backing off until %s"
+)
+
+type statusUpdateFailures struct {
+	count      int
+	retryAfter time.Time
+}
+
+// StatusUpdateTracker counts the consecutive failed status updates of each resource. Since these
+// failures can't be recorded in the status itself, it surfaces them as an event once a threshold
+// is reached and backs off from reconciling the resource for a while, instead of tight-looping.
+type StatusUpdateTracker struct {
+	recorder  event.Recorder
+	threshold int
+	backoff   time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures map[types.NamespacedName]statusUpdateFailures
+}
+
+// NewStatusUpdateTracker returns a new StatusUpdateTracker.
+func NewStatusUpdateTracker(recorder event.Recorder, threshold int, backoff time.Duration) *StatusUpdateTracker {
+	return &StatusUpdateTracker{
+		recorder:  recorder,
+		threshold: threshold,
+		backoff:   backoff,
+		now:       time.Now,
+		failures:  map[types.NamespacedName]statusUpdateFailures{},
+	}
+}
+
+// Client returns a client whose status updates are tracked.
+func (t *StatusUpdateTracker) Client(c client.Client) client.Client {
+	if t == nil {
+		return c
+	}
+
+	return &statusTrackingClient{Client: c, tracker: t}
+}
+
+// Manager returns a manager whose client's status updates are tracked, so that the status updates of a
+// managed.Reconciler created with it, which uses the client of its manager, are tracked as well.
+func (t *StatusUpdateTracker) Manager(m manager.Manager) manager.Manager {
+	if t == nil {
+		return m
+	}
+
+	return &statusTrackingManager{Manager: m, tracker: t}
+}
+
+// BackOff returns an error if the status updates of the given resource keep failing and it
+// should not be reconciled yet.
+func (t *StatusUpdateTracker) BackOff(obj client.Object) error {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f, ok := t.failures[client.ObjectKeyFromObject(obj)]
+	if !ok || f.count < t.threshold || !t.now().Before(f.retryAfter) {
+		return nil
+	}
+
+	return errors.Errorf(errStatusUpdateBackoff, f.count, f.retryAfter.Format(time.RFC3339))
+}
+
+// Forget stops tracking the status updates of the given resource, e.g. once it is deleted.
+func (t *StatusUpdateTracker) Forget(obj client.Object) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.failures, client.ObjectKeyFromObject(obj))
+}
+
+// record records the result of a status update of the given resource.
+func (t *StatusUpdateTracker) record(obj client.Object, err error) {
+	key := client.ObjectKeyFromObject(obj)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err == nil {
+		delete(t.failures, key)
+		return
+	}
+
+	f := t.failures[key]
+	f.count++
+	if f.count >= t.threshold {
+		f.retryAfter = t.now().Add(t.backoff)
+		t.recorder.Event(obj, event.Warning(reasonStatusUpdateFailing, errors.Errorf(errStatusUpdateFailing, f.count, err.Error())))
+	}
+	t.failures[key] = f
+}
+
+// statusTrackingManager is a manager whose client reports the results of its status updates to a
+// StatusUpdateTracker.
+type statusTrackingManager struct {
+	manager.Manager
+	tracker *StatusUpdateTracker
+}
+
+// GetClient returns the client of the manager, whose status updates are tracked.
+func (m *statusTrackingManager) GetClient() client.Client {
+	return m.tracker.Client(m.Manager.GetClient())
+}
+
+// statusTrackingClient is a client reporting the results of its status updates to a StatusUpdateTracker.
+type statusTrackingClient struct {
+	client.Client
+	tracker *StatusUpdateTracker
+}
+
+// Status returns a status writer reporting the results of its updates to the tracker.
+func (c *statusTrackingClient) Status() client.SubResourceWriter {
+	return &statusTrackingWriter{SubResourceWriter: c.Client.Status(), tracker: c.tracker}
+}
+
+type statusTrackingWriter struct {
+	client.SubResourceWriter
+	tracker *StatusUpdateTracker
+}
+
+// Update updates the status of the given object and records the result.
+func (w *statusTrackingWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	err := w.SubResourceWriter.Update(ctx, obj, opts...)
+	w.tracker.record(obj, err)
+	return err
+}
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	v1alpha2_request "github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+type eventCounter struct {
+	events []event.Event
+}
+
+func (r *eventCounter) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventCounter) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+// clientManager is a manager only returning its client.
+type clientManager struct {
+	manager.Manager
+	client client.Client
+}
+
+func (m *clientManager) GetClient() client.Client {
+	return m.client
+}
+
+func TestStatusUpdateTracker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	retryAfter := now.Add(time.Minute).Format(time.RFC3339)
+
+	type args struct {
+		updateErrs []error
+		elapsed    time.Duration
+		forget     bool
+		manager    bool
+	}
+	type want struct {
+		events     int
+		backOffErr error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"BelowThreshold": {
+			args: args{
+				updateErrs: []error{errBoom, errBoom},
+			},
+			want: want{},
+		},
+		"PersistentFailuresBackOff": {
+			args: args{
+				updateErrs: []error{errBoom, errBoom, errBoom},
+			},
+			want: want{
+				events:     1,
+				backOffErr: errors.Errorf(errStatusUpdateBackoff, 3, retryAfter),
+			},
+		},
+		"BackOffExpired": {
+			args: args{
+				updateErrs: []error{errBoom, errBoom, errBoom},
+				elapsed:    time.Minute,
+			},
+			want: want{
+				events: 1,
+			},
+		},
+		"SuccessResetsFailures": {
+			args: args{
+				updateErrs: []error{errBoom, errBoom, nil, errBoom},
+			},
+			want: want{},
+		},
+		"ManagerClientTracked": {
+			args: args{
+				updateErrs: []error{errBoom, errBoom, errBoom},
+				manager:    true,
+			},
+			want: want{
+				events:     1,
+				backOffErr: errors.Errorf(errStatusUpdateBackoff, 3, retryAfter),
+			},
+		},
+		"ForgottenResourceNotBackedOff": {
+			args: args{
+				updateErrs: []error{errBoom, errBoom, errBoom},
+				forget:     true,
+			},
+			want: want{
+				events: 1,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			recorder := &eventCounter{}
+			tracker := NewStatusUpdateTracker(recorder, 3, time.Minute)
+			tracker.now = func() time.Time { return now }
+
+			updateErrs := tc.args.updateErrs
+			var mock client.Client = &test.MockClient{
+				MockStatusUpdate: func(_ context.Context, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+					err := updateErrs[0]
+					updateErrs = updateErrs[1:]
+					return err
+				},
+			}
+			kube := tracker.Client(mock)
+			if tc.args.manager {
+				kube = tracker.Manager(&clientManager{client: mock}).GetClient()
+			}
+
+			cr := &v1alpha2_request.Request{ObjectMeta: v1.ObjectMeta{Name: "test"}}
+			for range tc.args.updateErrs {
+				_ = kube.Status().Update(context.Background(), cr)
+			}
+
+			if tc.args.forget {
+				tracker.Forget(cr)
+			}
+
+			tracker.now = func() time.Time { return now.Add(tc.args.elapsed) }
+			if diff := cmp.Diff(tc.want.backOffErr, tracker.BackOff(cr), test.EquateErrors()); diff != "" {
+				t.Fatalf("BackOff(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.events, len(recorder.events)); diff != "" {
+				t.Fatalf("BackOff(...): -want events, +got events: %s", diff)
+			}
+		})
+	}
+}
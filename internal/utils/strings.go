@@ -1,10 +1,31 @@
 package utils
 
 import (
+	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
+// truncatedBodyMarker is appended to the bodies truncated by TruncateBody.
+const truncatedBodyMarker = "...[truncated, %d bytes in total]"
+
 // NormalizeWhitespace removes extra whitespace from a string.
 func NormalizeWhitespace(input string) string {
 	return strings.Join(strings.Fields(input), " ")
 }
+
+// TruncateBody returns the first maxBytes bytes of the body, without splitting a UTF-8 character, followed
+// by a marker with the size of the whole body. The body is returned unchanged when maxBytes is not positive
+// or when it is not larger.
+func TruncateBody(body string, maxBytes int) string {
+	if maxBytes <= 0 || len(body) <= maxBytes {
+		return body
+	}
+
+	end := maxBytes
+	for end > 0 && !utf8.RuneStart(body[end]) {
+		end--
+	}
+
+	return body[:end] + fmt.Sprintf(truncatedBodyMarker, len(body))
+}
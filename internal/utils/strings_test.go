@@ -39,3 +39,41 @@ func Test_ConvertStringToJQQuery(t *testing.T) {
 		})
 	}
 }
+
+func Test_TruncateBody(t *testing.T) {
+	cases := map[string]struct {
+		body     string
+		maxBytes int
+		want     string
+	}{
+		"NoCap": {
+			body: `{"id":"123"}`,
+			want: `{"id":"123"}`,
+		},
+		"UnderCap": {
+			body:     `{"id":"123"}`,
+			maxBytes: 12,
+			want:     `{"id":"123"}`,
+		},
+		"OverCap": {
+			body:     `{"id":"123","name":"example"}`,
+			maxBytes: 11,
+			want:     `{"id":"123"...[truncated, 29 bytes in total]`,
+		},
+		"MultiByteCharacterNotSplit": {
+			body:     "héllo",
+			maxBytes: 2,
+			want:     "h...[truncated, 6 bytes in total]",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got := TruncateBody(tc.body, tc.maxBytes)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("TruncateBody(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
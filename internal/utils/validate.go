@@ -2,16 +2,77 @@ package utils
 
 import (
 	"net/url"
+	"slices"
+	"sync/atomic"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const (
 	errEmptyMethod = "no method is specified"
 	ErrInvalidURL  = "invalid url %s"
 	ErrStatusCode  = "HTTP %s request failed with status code: %s"
+
+	errTooManyMappings = "the Request has %d mappings, more than the %d allowed by the provider, reduce its mappings and mappingTemplate or raise --max-mappings"
 )
 
+// DefaultMaxMappings is the default maximum number of mappings of a Request.
+const DefaultMaxMappings = 100
+
+// maxMappings is the maximum number of mappings of a Request, 0 meaning unbounded.
+var maxMappings atomic.Int64
+
+func init() {
+	maxMappings.Store(DefaultMaxMappings)
+}
+
+// resourceRefKinds are the kinds the resourceRefs of the Requests can reference, none by default.
+var resourceRefKinds atomic.Pointer[[]string]
+
+// SetResourceRefKinds sets the kinds the resourceRefs of the Requests can reference, as Kind.group, e.g.
+// Instance.ec2.aws.upbound.io, or as Kind alone for the core group, e.g. ConfigMap. *.group allows all the
+// kinds of a group.
+func SetResourceRefKinds(kinds []string) {
+	kinds = slices.Clone(kinds)
+	resourceRefKinds.Store(&kinds)
+}
+
+// ResourceRefKindAllowed returns whether the resourceRefs of the Requests can reference the kind.
+func ResourceRefKindAllowed(gk schema.GroupKind) bool {
+	kinds := resourceRefKinds.Load()
+	if kinds == nil {
+		return false
+	}
+
+	wildcard := schema.GroupKind{Group: gk.Group, Kind: "*"}.String()
+	for _, kind := range *kinds {
+		if kind == gk.String() || kind == wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMaxMappings sets the maximum number of mappings of a Request, to protect the controller from oversized
+// manifests. Values that are not positive remove the bound.
+func SetMaxMappings(limit int) {
+	if limit < 0 {
+		limit = 0
+	}
+	maxMappings.Store(int64(limit))
+}
+
+// ValidateMappingCount returns an error if a Request has more mappings than allowed.
+func ValidateMappingCount(count int) error {
+	limit := maxMappings.Load()
+	if limit > 0 && int64(count) > limit {
+		return errors.Errorf(errTooManyMappings, count, limit)
+	}
+
+	return nil
+}
+
 // IsRequestValid checks if an HTTP request is valid.
 func IsRequestValid(method string, url string) error {
 	if method == "" {
@@ -7,6 +7,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func Test_IsRequestValid(t *testing.T) {
@@ -163,3 +164,115 @@ func Test_IsUrlValid(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateMappingCount(t *testing.T) {
+	type args struct {
+		limit int
+		count int
+	}
+	type want struct {
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UnderTheCap": {
+			args: args{limit: 4, count: 3},
+		},
+		"AtTheCap": {
+			args: args{limit: 4, count: 4},
+		},
+		"OverTheCap": {
+			args: args{limit: 4, count: 5},
+			want: want{
+				err: errors.Errorf(errTooManyMappings, 5, 4),
+			},
+		},
+		"DefaultCap": {
+			args: args{limit: DefaultMaxMappings, count: DefaultMaxMappings + 1},
+			want: want{
+				err: errors.Errorf(errTooManyMappings, DefaultMaxMappings+1, DefaultMaxMappings),
+			},
+		},
+		"Unbounded": {
+			args: args{limit: 0, count: 10000},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			SetMaxMappings(tc.args.limit)
+			defer SetMaxMappings(DefaultMaxMappings)
+
+			err := ValidateMappingCount(tc.args.count)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("ValidateMappingCount(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_ResourceRefKindAllowed(t *testing.T) {
+	type args struct {
+		kinds []string
+		kind  schema.GroupKind
+	}
+	type want struct {
+		allowed bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoneByDefault": {
+			args: args{kind: schema.GroupKind{Group: "ec2.aws.upbound.io", Kind: "Instance"}},
+		},
+		"AllowedKind": {
+			args: args{
+				kinds: []string{"Instance.ec2.aws.upbound.io"},
+				kind:  schema.GroupKind{Group: "ec2.aws.upbound.io", Kind: "Instance"},
+			},
+			want: want{allowed: true},
+		},
+		"OtherKindOfTheGroup": {
+			args: args{
+				kinds: []string{"Instance.ec2.aws.upbound.io"},
+				kind:  schema.GroupKind{Group: "ec2.aws.upbound.io", Kind: "VPC"},
+			},
+		},
+		"AllKindsOfTheGroup": {
+			args: args{
+				kinds: []string{"*.ec2.aws.upbound.io"},
+				kind:  schema.GroupKind{Group: "ec2.aws.upbound.io", Kind: "VPC"},
+			},
+			want: want{allowed: true},
+		},
+		"CoreGroup": {
+			args: args{
+				kinds: []string{"ConfigMap"},
+				kind:  schema.GroupKind{Kind: "ConfigMap"},
+			},
+			want: want{allowed: true},
+		},
+		"SameKindOfAnotherGroup": {
+			args: args{
+				kinds: []string{"ConfigMap"},
+				kind:  schema.GroupKind{Group: "example.org", Kind: "ConfigMap"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			SetResourceRefKinds(tc.args.kinds)
+			defer SetResourceRefKinds(nil)
+
+			if diff := cmp.Diff(tc.want.allowed, ResourceRefKindAllowed(tc.args.kind)); diff != "" {
+				t.Errorf("ResourceRefKindAllowed(...): -want allowed, +got allowed: %s", diff)
+			}
+		})
+	}
+}